@@ -0,0 +1,137 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/zdb"
+	"zgo.at/zvalidate"
+)
+
+// Annotation is a note displayed on the charts, such as "deployed v1.2" or
+// "started ad campaign".
+type Annotation struct {
+	ID     int64 `db:"annotation_id" json:"id"`
+	SiteID int64 `db:"site_id" json:"-"`
+
+	// Time of the annotation; defaults to the current time.
+	At time.Time `db:"at" json:"at"`
+
+	// Only display on the chart for this path; this is displayed on all charts
+	// if empty. Ending the path with a * will match everything starting with
+	// the path (e.g. "/blog/*").
+	Path string `db:"path" json:"path"`
+
+	// Annotation text. {required}
+	Text string `db:"text" json:"text"`
+
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Defaults sets fields to default values, unless they're already set.
+func (a *Annotation) Defaults(ctx context.Context) {
+	a.SiteID = MustGetSite(ctx).ID
+	if a.At.IsZero() {
+		a.At = Now()
+	}
+	a.At = a.At.UTC().Truncate(time.Second)
+	a.Path = strings.TrimSpace(a.Path)
+	a.Text = strings.TrimSpace(a.Text)
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = Now()
+	}
+}
+
+// Validate the object.
+func (a *Annotation) Validate(ctx context.Context) error {
+	v := zvalidate.New()
+	v.Required("site_id", a.SiteID)
+	v.Required("text", a.Text)
+	v.Len("text", a.Text, 0, 1000)
+	v.Len("path", a.Path, 0, 2048)
+	if a.At.After(Now().Add(24 * time.Hour * 365)) {
+		v.Append("at", "more than a year in the future")
+	}
+	return v.ErrorOrNil()
+}
+
+// Insert a new row.
+func (a *Annotation) Insert(ctx context.Context) error {
+	if a.ID > 0 {
+		return errors.New("ID > 0")
+	}
+
+	a.Defaults(ctx)
+	err := a.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.ID, err = zdb.InsertID(ctx, "annotation_id",
+		`insert into annotations (site_id, at, path, text, created_at) values (?, ?, ?, ?, ?)`,
+		a.SiteID, a.At, a.Path, a.Text, a.CreatedAt)
+	return errors.Wrap(err, "Annotation.Insert")
+}
+
+// ByID gets an annotation by ID.
+func (a *Annotation) ByID(ctx context.Context, id int64) error {
+	return errors.Wrapf(zdb.Get(ctx, a, `/* Annotation.ByID */
+		select * from annotations where annotation_id=$1 and site_id=$2`,
+		id, MustGetSite(ctx).ID), "Annotation.ByID %d", id)
+}
+
+// Delete this annotation.
+func (a *Annotation) Delete(ctx context.Context) error {
+	err := zdb.Exec(ctx,
+		`/* Annotation.Delete */ delete from annotations where annotation_id=$1 and site_id=$2`,
+		a.ID, MustGetSite(ctx).ID)
+	return errors.Wrapf(err, "Annotation.Delete %d", a.ID)
+}
+
+// Matches reports if the path filter of this annotation matches path.
+func (a Annotation) Matches(path string) bool {
+	if a.Path == "" {
+		return true
+	}
+	if strings.HasSuffix(a.Path, "*") {
+		return strings.HasPrefix(path, a.Path[:len(a.Path)-1])
+	}
+	return a.Path == path
+}
+
+type Annotations []Annotation
+
+// List all annotations for this site, most recent first.
+func (a *Annotations) List(ctx context.Context) error {
+	return errors.Wrap(zdb.Select(ctx, a, `/* Annotations.List */
+		select * from annotations where site_id=$1 order by at desc, annotation_id desc`,
+		MustGetSite(ctx).ID), "Annotations.List")
+}
+
+// ListRange lists all annotations between start and end.
+func (a *Annotations) ListRange(ctx context.Context, start, end time.Time) error {
+	return errors.Wrap(zdb.Select(ctx, a, `/* Annotations.ListRange */
+		select * from annotations where site_id=$1 and at >= $2 and at <= $3
+		order by at asc, annotation_id asc`,
+		MustGetSite(ctx).ID, start, end), "Annotations.ListRange")
+}
+
+// ForPath gets all annotations to display on the chart for path.
+//
+// Annotations without a path are always included; the empty path (for the
+// totals) only includes those.
+func (a Annotations) ForPath(path string) Annotations {
+	var r Annotations
+	for _, aa := range a {
+		if aa.Path == "" || (path != "" && aa.Matches(path)) {
+			r = append(r, aa)
+		}
+	}
+	return r
+}
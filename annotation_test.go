@@ -0,0 +1,89 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+)
+
+func TestAnnotations(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	for _, a := range []Annotation{
+		{Text: "deploy", At: time.Date(2020, 6, 18, 12, 0, 0, 0, time.UTC)},
+		{Text: "blog", Path: "/blog/*", At: time.Date(2020, 6, 19, 12, 0, 0, 0, time.UTC)},
+		{Text: "page", Path: "/page", At: time.Date(2020, 6, 20, 12, 0, 0, 0, time.UTC)},
+		{Text: "later", At: time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)},
+	} {
+		err := a.Insert(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var all Annotations
+	err := all.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 || all[0].Text != "later" {
+		t.Fatalf("wrong list: %v", all)
+	}
+
+	var annot Annotations
+	err = annot.ListRange(ctx,
+		time.Date(2020, 6, 18, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 6, 30, 23, 59, 59, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(annot) != 3 {
+		t.Fatalf("len %d: %v", len(annot), annot)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"", []string{"deploy"}},
+		{"/blog/post", []string{"deploy", "blog"}},
+		{"/blog", []string{"deploy"}},
+		{"/page", []string{"deploy", "page"}},
+		{"/page/x", []string{"deploy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var got []string
+			for _, a := range annot.ForPath(tt.path) {
+				got = append(got, a.Text)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("\ngot:  %v\nwant: %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("\ngot:  %v\nwant: %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	err = annot[0].Delete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var after Annotations
+	err = after.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 3 {
+		t.Errorf("len %d after delete", len(after))
+	}
+}
@@ -6,69 +6,200 @@ package goatcounter
 
 import (
 	"context"
-	"database/sql/driver"
+	"database/sql"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"zgo.at/errors"
 	"zgo.at/json"
 	"zgo.at/zdb"
 	"zgo.at/zstd/zcrypto"
-	"zgo.at/zstd/zjson"
+	"zgo.at/zstd/zstring"
 	"zgo.at/zvalidate"
 )
 
+// APIToken is a token for the API; tokens are stored for the parent site, and
+// can be used on all sites of the account unless Sites is set.
 type APIToken struct {
 	ID     int64 `db:"api_token_id" json:"-"`
 	SiteID int64 `db:"site_id" json:"-"`
 	UserID int64 `db:"user_id" json:"-"`
 
-	Name        string              `db:"name" json:"name"`
-	Token       string              `db:"token" json:"-"`
-	Permissions APITokenPermissions `db:"permissions" json:"permissions"`
+	Name        string         `db:"name" json:"name"`
+	Token       string         `db:"token" json:"-"`
+	Permissions APIPermissions `db:"permissions" json:"permissions"`
 
-	CreatedAt time.Time `db:"created_at" json:"-"`
+	// Only allow using the token for these sites; it can be used for the
+	// parent site and all its child sites if this is empty.
+	Sites Ints `db:"sites" json:"sites"`
+
+	// Only allow using the token from these IP addresses or CIDR ranges; it can
+	// be used from anywhere if this is empty.
+	IPRanges Strings `db:"ip_ranges" json:"ip_ranges"`
+
+	// Rate limits for this token; groups that aren't set use the server
+	// defaults.
+	Ratelimits APIRatelimits `db:"ratelimits" json:"ratelimits"`
+
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	LastUsedIP string     `db:"last_used_ip" json:"last_used_ip"`
+	CreatedAt  time.Time  `db:"created_at" json:"-"`
+}
+
+// APIPermissions is a bitmask of the permissions for an API token.
+type APIPermissions uint64
+
+// Permissions for API tokens.
+//
+// These values are stored in the database, so never change the order.
+const (
+	APIPermCount APIPermissions = 1 << iota
+	APIPermExport
+	APIPermSiteRead
+	APIPermSiteCreate
+	APIPermSiteUpdate
+	APIPermAnnotations
+	APIPermStats
+	APIPermAudit
+	APIPermUsers
+)
+
+// APIPermissionInfo describes a permission.
+type APIPermissionInfo struct {
+	Perm  APIPermissions
+	Name  string // Name in the API.
+	Label string // Label in the UI.
+	Help  string
 }
 
-// TODO: this shoud really be a bitmask; this is awkward to deal with.
-type APITokenPermissions struct {
-	Count      bool `db:"count" json:"count"`
-	Export     bool `db:"export" json:"export"`
-	SiteRead   bool `db:"site_read" json:"site_read"`
-	SiteCreate bool `db:"site_create" json:"site_create"`
-	SiteUpdate bool `db:"site_update" json:"site_update"`
+// APIPermissionList lists all permissions, in the order they're displayed.
+var APIPermissionList = []APIPermissionInfo{
+	{APIPermCount, "count", "Record pageviews", "Record pageviews with /api/v0/count"},
+	{APIPermExport, "export", "Export", "Export data with /api/v0/export"},
+	{APIPermSiteRead, "site_read", "Read sites", "List sites and views with /api/v0/sites"},
+	{APIPermSiteCreate, "site_create", "Create sites", "Create sites with /api/v0/sites"},
+	{APIPermSiteUpdate, "site_update", "Update sites", "Change site settings and views with /api/v0/sites"},
+	{APIPermAnnotations, "annotations", "Annotations", "Add chart annotations with /api/v0/annotations"},
+	{APIPermStats, "stats", "Read statistics", "Read statistics with /api/v0/stats"},
+	{APIPermAudit, "audit", "Audit log", "Read the audit log with /api/v0/audit"},
+	{APIPermUsers, "users", "Read users", "List users with /api/v0/users"},
 }
 
-func (tp APITokenPermissions) String() string { return string(zjson.MustMarshal(tp)) }
+// ParseAPIPermissions parses a list of permission names, such as "count" or
+// "site_read".
+func ParseAPIPermissions(names []string) (APIPermissions, error) {
+	var p APIPermissions
+outer:
+	for _, n := range names {
+		for _, pp := range APIPermissionList {
+			if pp.Name == n {
+				p |= pp.Perm
+				continue outer
+			}
+		}
+		return 0, fmt.Errorf("unknown permission: %q", n)
+	}
+	return p, nil
+}
 
-// Value implements the SQL Value function to determine what to store in the DB.
-func (tp APITokenPermissions) Value() (driver.Value, error) { return json.Marshal(tp) }
+// Has reports if all the permissions in perm are set.
+func (p APIPermissions) Has(perm APIPermissions) bool { return p&perm == perm }
 
-// Scan converts the data returned from the DB into the struct.
-func (tp *APITokenPermissions) Scan(v interface{}) error {
-	switch vv := v.(type) {
-	case []byte:
-		return json.Unmarshal(vv, tp)
-	case string:
-		return json.Unmarshal([]byte(vv), tp)
-	default:
-		panic(fmt.Sprintf("unsupported type: %T", v))
+// Names gets the names of all permissions that are set.
+func (p APIPermissions) Names() []string {
+	names := make([]string, 0, len(APIPermissionList))
+	for _, pp := range APIPermissionList {
+		if p.Has(pp.Perm) {
+			names = append(names, pp.Name)
+		}
 	}
+	return names
+}
+
+// Missing gets the names of all permissions in perm that aren't set.
+func (p APIPermissions) Missing(perm APIPermissions) []string {
+	return (perm &^ p).Names()
+}
+
+func (p APIPermissions) String() string { return strings.Join(p.Names(), ", ") }
+
+// MarshalJSON converts the permissions to a list of names.
+func (p APIPermissions) MarshalJSON() ([]byte, error) { return json.Marshal(p.Names()) }
+
+// UnmarshalJSON reads the permissions from a list of names.
+func (p *APIPermissions) UnmarshalJSON(v []byte) error {
+	var names []string
+	err := json.Unmarshal(v, &names)
+	if err != nil {
+		return err
+	}
+	*p, err = ParseAPIPermissions(names)
+	return err
 }
 
 // Defaults sets fields to default values, unless they're already set.
 func (t *APIToken) Defaults(ctx context.Context) {
-	t.SiteID = MustGetSite(ctx).ID
+	t.SiteID = MustGetSite(ctx).IDOrParent()
+	t.UserID = GetUser(ctx).ID
 	t.Token = zcrypto.Secret256()
 	t.CreatedAt = Now()
+
+	t.Name = strings.TrimSpace(t.Name)
+	for i, r := range t.IPRanges {
+		r = strings.TrimSpace(r)
+		if r != "" && !strings.Contains(r, "/") {
+			if strings.Contains(r, ":") {
+				r += "/128"
+			} else {
+				r += "/32"
+			}
+		}
+		t.IPRanges[i] = r
+	}
+	t.IPRanges = zstring.Filter(t.IPRanges, zstring.FilterEmpty)
+	if t.ExpiresAt != nil {
+		e := t.ExpiresAt.UTC().Truncate(time.Second)
+		t.ExpiresAt = &e
+	}
 }
 
 func (t *APIToken) Validate(ctx context.Context) error {
 	v := zvalidate.New()
 	v.Required("name", t.Name)
 	v.Required("site_id", t.SiteID)
-	v.Required("user_id", t.SiteID)
+	v.Required("user_id", t.UserID)
 	v.Required("token", t.Token)
+	v.Len("name", t.Name, 0, 200)
+
+	for i, r := range t.IPRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			v.Append(fmt.Sprintf("ip_ranges[%d]", i), "not a valid IP address or CIDR range")
+		}
+	}
+
+	if len(t.Sites) > 0 {
+		var sites Sites
+		err := sites.ForThisAccount(ctx, false)
+		if err != nil {
+			return err
+		}
+	outer:
+		for i, id := range t.Sites {
+			for _, s := range sites {
+				if s.ID == id {
+					continue outer
+				}
+			}
+			v.Append(fmt.Sprintf("sites[%d]", i), "not a site in this account")
+		}
+	}
+
+	if t.ExpiresAt != nil && t.ID == 0 && t.ExpiresAt.Before(Now()) {
+		v.Append("expires_at", "is in the past")
+	}
 	return v.ErrorOrNil()
 }
 
@@ -84,28 +215,90 @@ func (t *APIToken) Insert(ctx context.Context) error {
 		return err
 	}
 
+	// Ratelimits is a map, which zdb would use as named parameters.
+	rl := sql.NullString{String: t.Ratelimits.String(), Valid: len(t.Ratelimits) > 0}
 	t.ID, err = zdb.InsertID(ctx, "api_token_id",
-		`insert into api_tokens (site_id, user_id, name, token, permissions, created_at) values (?, ?, ?, ?, ?, ?)`,
-		t.SiteID, GetUser(ctx).ID, t.Name, t.Token, t.Permissions, t.CreatedAt)
+		`insert into api_tokens (site_id, user_id, name, token, permissions, sites, ip_ranges, ratelimits, expires_at, created_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.SiteID, t.UserID, t.Name, t.Token, t.Permissions, t.Sites, t.IPRanges, rl, t.ExpiresAt, t.CreatedAt)
 	return errors.Wrap(err, "APIToken.Insert")
 }
 
+// ByID gets a token by ID; this only finds tokens owned by the current user.
 func (t *APIToken) ByID(ctx context.Context, id int64) error {
 	return errors.Wrapf(zdb.Get(ctx, t, `/* APIToken.ByID */
-		select * from api_tokens where api_token_id=$1 and site_id=$2`,
-		id, MustGetSite(ctx).ID), "APIToken.ByID %d", id)
+		select * from api_tokens where api_token_id=$1 and site_id=$2 and user_id=$3`,
+		id, MustGetSite(ctx).IDOrParent(), GetUser(ctx).ID), "APIToken.ByID %d", id)
 }
 
+// ByToken gets a token by the token string; this finds tokens for all sites in
+// the account, so use AllowSite to check if it can be used for a site.
 func (t *APIToken) ByToken(ctx context.Context, token string) error {
 	return errors.Wrap(zdb.Get(ctx, t,
-		`/* APIToken.ByID */ select * from api_tokens where token=$1 and site_id=$2`,
-		token, MustGetSite(ctx).ID), "APIToken.ByToken")
+		`/* APIToken.ByToken */ select * from api_tokens where token=$1 and site_id=$2`,
+		token, MustGetSite(ctx).IDOrParent()), "APIToken.ByToken")
+}
+
+// UpdateLastUsed records that the token was used from the IP address.
+//
+// This is only updated once a minute, unless the IP address changed.
+func (t *APIToken) UpdateLastUsed(ctx context.Context, ip string) error {
+	now := Now()
+	if t.LastUsedAt != nil && now.Sub(*t.LastUsedAt) < time.Minute && t.LastUsedIP == ip {
+		return nil
+	}
+
+	t.LastUsedAt, t.LastUsedIP = &now, ip
+	err := zdb.Exec(ctx, `/* APIToken.UpdateLastUsed */
+		update api_tokens set last_used_at=$1, last_used_ip=$2 where api_token_id=$3`,
+		t.LastUsedAt, t.LastUsedIP, t.ID)
+	return errors.Wrap(err, "APIToken.UpdateLastUsed")
+}
+
+// Expired reports if this token has expired.
+func (t APIToken) Expired() bool {
+	return t.ExpiresAt != nil && !t.ExpiresAt.After(Now())
+}
+
+// AllowSite reports if the token can be used for this site.
+func (t APIToken) AllowSite(siteID int64) bool {
+	if len(t.Sites) == 0 {
+		return true
+	}
+	for _, id := range t.Sites {
+		if id == siteID {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowIP reports if the token can be used from this address; the port is
+// ignored.
+func (t APIToken) AllowIP(addr string) bool {
+	if len(t.IPRanges) == 0 {
+		return true
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, r := range t.IPRanges {
+		_, n, err := net.ParseCIDR(r)
+		if err == nil && n.Contains(ip) {
+			return true
+		}
+	}
+	return false
 }
 
 func (t *APIToken) Delete(ctx context.Context) error {
 	err := zdb.Exec(ctx,
-		`/* APIToken.Delete */ delete from api_tokens where api_token_id=$1 and site_id=$2`,
-		t.ID, MustGetSite(ctx).ID)
+		`/* APIToken.Delete */ delete from api_tokens where api_token_id=$1 and site_id=$2 and user_id=$3`,
+		t.ID, MustGetSite(ctx).IDOrParent(), GetUser(ctx).ID)
 	return errors.Wrapf(err, "APIToken.Delete %d", t.ID)
 }
 
@@ -114,5 +307,5 @@ type APITokens []APIToken
 func (t *APITokens) List(ctx context.Context) error {
 	return errors.Wrap(zdb.Select(ctx, t,
 		`select * from api_tokens where site_id=$1 and user_id=$2`,
-		MustGetSite(ctx).ID, GetUser(ctx).ID), "APITokens.List")
+		MustGetSite(ctx).IDOrParent(), GetUser(ctx).ID), "APITokens.List")
 }
@@ -0,0 +1,145 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/json"
+	"zgo.at/zdb"
+	"zgo.at/zvalidate"
+)
+
+func TestAPIPermissions(t *testing.T) {
+	p, err := ParseAPIPermissions([]string{"count", "site_read", "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != APIPermCount|APIPermSiteRead|APIPermUsers {
+		t.Errorf("wrong permissions: %d", p)
+	}
+	if !p.Has(APIPermCount|APIPermUsers) || p.Has(APIPermCount|APIPermExport) {
+		t.Error("Has")
+	}
+	if g := p.Missing(APIPermCount | APIPermExport | APIPermAudit); len(g) != 2 || g[0] != "export" || g[1] != "audit" {
+		t.Errorf("Missing: %v", g)
+	}
+
+	j, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `["count","site_read","users"]` {
+		t.Errorf("json: %s", j)
+	}
+	var p2 APIPermissions
+	err = json.Unmarshal(j, &p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2 != p {
+		t.Errorf("json: %d", p2)
+	}
+
+	_, err = ParseAPIPermissions([]string{"count", "nope"})
+	if err == nil {
+		t.Error("no error for unknown permission")
+	}
+}
+
+func TestAPIToken(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	past := Now().Add(-time.Hour)
+	for _, tt := range []APIToken{
+		{},
+		{Name: "x", IPRanges: Strings{"nope"}},
+		{Name: "x", Sites: Ints{42}},
+		{Name: "x", ExpiresAt: &past},
+	} {
+		err := tt.Insert(ctx)
+		var vErr *zvalidate.Validator
+		if !errors.As(err, &vErr) {
+			t.Errorf("%v: wrong error: %#v", tt, err)
+		}
+	}
+
+	future := Now().Add(time.Hour)
+	tok := APIToken{Name: "x", Permissions: APIPermStats, Sites: Ints{1},
+		IPRanges: Strings{"192.0.2.0/24", "2001:db8::1"}, ExpiresAt: &future}
+	err := tok.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got APIToken
+	err = got.ByToken(ctx, tok.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Permissions != APIPermStats || got.Expired() {
+		t.Errorf("wrong token: %#v", got)
+	}
+
+	if !got.AllowSite(1) || got.AllowSite(2) {
+		t.Error("AllowSite")
+	}
+	for addr, want := range map[string]bool{
+		"192.0.2.42:1234":   true,
+		"192.0.2.42":        true,
+		"[2001:db8::1]:443": true,
+		"192.0.3.1":         false,
+		"2001:db8::2":       false,
+		"nope":              false,
+	} {
+		if g := got.AllowIP(addr); g != want {
+			t.Errorf("AllowIP(%q): %t", addr, g)
+		}
+	}
+
+	err = got.UpdateLastUsed(ctx, "192.0.2.42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = got.ByToken(ctx, tok.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LastUsedAt == nil || got.LastUsedIP != "192.0.2.42" {
+		t.Errorf("not updated: %v %q", got.LastUsedAt, got.LastUsedIP)
+	}
+
+	// Other users can't see or delete the token.
+	{
+		other := User{Site: 1, Email: "other@example.com", Password: []byte("coconuts"), Access: AccessAdmin}
+		err := other.Insert(ctx, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherCtx := WithUser(ctx, &other)
+
+		var o APIToken
+		if err := o.ByID(otherCtx, tok.ID); !zdb.ErrNoRows(err) {
+			t.Errorf("ByID for other user: %v", err)
+		}
+		err = tok.Delete(otherCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = o.ByID(ctx, tok.ID)
+		if err != nil {
+			t.Errorf("deleted by other user: %v", err)
+		}
+	}
+
+	gctest.SetNow(t, future.Add(time.Minute))
+	if !got.Expired() {
+		t.Error("not expired")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/json"
+	"zgo.at/zdb"
+	"zgo.at/zstd/zjson"
+	"zgo.at/zvalidate"
+)
+
+// AuditEvent is an entry in the audit log, which records who changed what.
+//
+// The action is something like "site.settings" or "user.invite"; the data
+// depends on the action, but for changes it's usually a diff as created by
+// AuditDiff.
+type AuditEvent struct {
+	ID     int64  `db:"audit_log_id" json:"id"`
+	SiteID int64  `db:"site_id" json:"site_id"`
+	UserID *int64 `db:"user_id" json:"user_id"`
+
+	// Email of the user; this is stored as users may be removed later.
+	Email     string    `db:"email" json:"email"`
+	Action    string    `db:"action" json:"action"`
+	Data      AuditData `db:"data" json:"data"`
+	IP        string    `db:"ip" json:"ip"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// AuditData is additional data for an audit event.
+type AuditData map[string]interface{}
+
+func (d AuditData) String() string {
+	if len(d) == 0 {
+		return ""
+	}
+	return string(zjson.MustMarshal(d))
+}
+
+// Value implements the SQL Value function to determine what to store in the DB.
+func (d AuditData) Value() (driver.Value, error) { return json.Marshal(d) }
+
+// Scan converts the data returned from the DB into the struct.
+func (d *AuditData) Scan(v interface{}) error {
+	switch vv := v.(type) {
+	case []byte:
+		return json.Unmarshal(vv, d)
+	case string:
+		return json.Unmarshal([]byte(vv), d)
+	default:
+		return fmt.Errorf("AuditData.Scan: unsupported type: %T", v)
+	}
+}
+
+// AuditDiff gets all the fields that differ between before and after, as
+// {"field": {"old": .., "new": ..}}. Only the top-level JSON fields are
+// compared.
+func AuditDiff(before, after interface{}) AuditData {
+	var b, a map[string]json.RawMessage
+	zjson.MustUnmarshal(zjson.MustMarshal(before), &b)
+	zjson.MustUnmarshal(zjson.MustMarshal(after), &a)
+
+	keys := make(map[string]struct{})
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+
+	diff := make(AuditData)
+	for k := range keys {
+		if bytes.Equal(b[k], a[k]) {
+			continue
+		}
+
+		var o, n interface{}
+		if b[k] != nil {
+			zjson.MustUnmarshal(b[k], &o)
+		}
+		if a[k] != nil {
+			zjson.MustUnmarshal(a[k], &n)
+		}
+		diff[k] = map[string]interface{}{"old": o, "new": n}
+	}
+	return diff
+}
+
+// Fields gets the names of all top-level fields in the data, sorted.
+func (d AuditData) Fields() []string {
+	f := make([]string, 0, len(d))
+	for k := range d {
+		f = append(f, k)
+	}
+	sort.Strings(f)
+	return f
+}
+
+// Display gets a field for displaying; changes from AuditDiff are displayed as
+// "old → new".
+func (d AuditData) Display(field string) string {
+	str := func(v interface{}) string {
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return string(zjson.MustMarshal(v))
+	}
+
+	v := d[field]
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 2 {
+		o, okOld := m["old"]
+		n, okNew := m["new"]
+		if okOld && okNew {
+			return str(o) + " → " + str(n)
+		}
+	}
+	return str(v)
+}
+
+// Defaults sets fields to default values, unless they're already set.
+func (e *AuditEvent) Defaults(ctx context.Context) {
+	if e.SiteID == 0 {
+		if s := GetSite(ctx); s != nil {
+			e.SiteID = s.ID
+		}
+	}
+	if u := GetUser(ctx); u != nil && u.ID > 0 && e.UserID == nil {
+		e.UserID, e.Email = &u.ID, u.Email
+	}
+	if e.Data == nil {
+		e.Data = AuditData{}
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = Now()
+	}
+}
+
+// Validate the object.
+func (e *AuditEvent) Validate(ctx context.Context) error {
+	v := zvalidate.New()
+	v.Required("site_id", e.SiteID)
+	v.Required("action", e.Action)
+	return v.ErrorOrNil()
+}
+
+// Insert a new row.
+func (e *AuditEvent) Insert(ctx context.Context) error {
+	if e.ID > 0 {
+		return errors.New("ID > 0")
+	}
+
+	e.Defaults(ctx)
+	err := e.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Data is a map, which zdb would use as named parameters.
+	e.ID, err = zdb.InsertID(ctx, "audit_log_id",
+		`insert into audit_log (site_id, user_id, email, action, data, ip, created_at) values (?, ?, ?, ?, ?, ?, ?)`,
+		e.SiteID, e.UserID, e.Email, e.Action, string(zjson.MustMarshal(e.Data)), e.IP, e.CreatedAt)
+	return errors.Wrap(err, "AuditEvent.Insert")
+}
+
+type AuditLog []AuditEvent
+
+// List the events for all sites in this account, newest first.
+//
+// Only events with an ID lower than before are listed if it's not 0, and it
+// reports if there are more events after limit.
+func (l *AuditLog) List(ctx context.Context, before int64, limit int) (bool, error) {
+	err := zdb.Select(ctx, l, `/* AuditLog.List */
+		select * from audit_log
+		where
+			site_id in (select site_id from sites where site_id=$1 or parent=$1) and
+			($2 = 0 or audit_log_id < $2)
+		order by audit_log_id desc
+		limit $3`,
+		MustGetSite(ctx).IDOrParent(), before, limit+1)
+	if err != nil {
+		return false, errors.Wrap(err, "AuditLog.List")
+	}
+
+	more := len(*l) > limit
+	if more {
+		*l = (*l)[:limit]
+	}
+	return more, nil
+}
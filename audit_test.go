@@ -0,0 +1,84 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"testing"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+)
+
+func TestAuditDiff(t *testing.T) {
+	type s struct {
+		A string   `json:"a"`
+		B int      `json:"b"`
+		C []string `json:"c"`
+	}
+
+	tests := []struct {
+		before, after s
+		want          string
+	}{
+		{s{}, s{}, ``},
+		{s{A: "x", B: 1}, s{A: "x", B: 1}, ``},
+		{s{A: "x"}, s{A: "y"}, `{"a":{"new":"y","old":"x"}}`},
+		{s{B: 1, C: []string{"x"}}, s{B: 2, C: []string{"x", "y"}},
+			`{"b":{"new":2,"old":1},"c":{"new":["x","y"],"old":["x"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := AuditDiff(tt.before, tt.after)
+			if g := got.String(); g != tt.want {
+				t.Errorf("\ngot:  %s\nwant: %s", g, tt.want)
+			}
+		})
+	}
+
+	d := AuditDiff(s{A: "x", B: 1}, s{A: "y", B: 2})
+	if g := d.Display("a"); g != "x → y" {
+		t.Errorf("Display: %q", g)
+	}
+	if g := d.Display("b"); g != "1 → 2" {
+		t.Errorf("Display: %q", g)
+	}
+	if g := (AuditData{"paths": []int{1, 2}}).Display("paths"); g != "[1,2]" {
+		t.Errorf("Display: %q", g)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	for _, a := range []string{"one", "two", "three"} {
+		e := AuditEvent{Action: a, Data: AuditData{"x": a}, IP: "192.0.2.1"}
+		err := e.Insert(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var l AuditLog
+	more, err := l.List(ctx, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !more || len(l) != 2 || l[0].Action != "three" || l[1].Action != "two" {
+		t.Fatalf("wrong list: %t %v", more, l)
+	}
+	if l[0].Email != "test@gctest.localhost" || l[0].Data["x"] != "three" {
+		t.Errorf("wrong event: %#v", l[0])
+	}
+
+	var l2 AuditLog
+	more, err = l2.List(ctx, l[1].ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if more || len(l2) != 1 || l2[0].Action != "one" {
+		t.Fatalf("wrong list: %t %v", more, l2)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"zgo.at/errors"
+)
+
+// AuthProxy is a reverse proxy that handles authentication and sets a header
+// with the email address of the user.
+type AuthProxy struct {
+	Header string       // Header with the email address.
+	From   []*net.IPNet // Only trust the header from these addresses.
+	Create bool         // Create users that don't exist yet.
+}
+
+// NewAuthProxy creates a new AuthProxy; from is a comma-separated list of IP
+// addresses or CIDR ranges.
+func NewAuthProxy(header, from string, create bool) (*AuthProxy, error) {
+	p := &AuthProxy{Header: http.CanonicalHeaderKey(strings.TrimSpace(header)), Create: create}
+	if p.Header == "" {
+		return nil, errors.New("NewAuthProxy: header is blank")
+	}
+
+	for _, f := range strings.Split(from, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !strings.Contains(f, "/") {
+			if strings.Contains(f, ":") {
+				f += "/128"
+			} else {
+				f += "/32"
+			}
+		}
+		_, n, err := net.ParseCIDR(f)
+		if err != nil {
+			return nil, errors.Errorf("NewAuthProxy: invalid address %q", f)
+		}
+		p.From = append(p.From, n)
+	}
+	if len(p.From) == 0 {
+		return nil, errors.New("NewAuthProxy: need at least one address to trust")
+	}
+	return p, nil
+}
+
+// Trusted reports if the header can be trusted for a connection from addr; the
+// port is ignored.
+func (p AuthProxy) Trusted(addr string) bool {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range p.From {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
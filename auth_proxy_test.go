@@ -0,0 +1,61 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"testing"
+
+	"zgo.at/goatcounter"
+	"zgo.at/zstd/ztest"
+)
+
+func TestNewAuthProxy(t *testing.T) {
+	tests := []struct {
+		header, from string
+		wantErr      string
+		trusted      map[string]bool
+	}{
+		{"remote-email", "127.0.0.1,::1", "", map[string]bool{
+			"127.0.0.1":      true,
+			"127.0.0.1:4321": true,
+			"[::1]:4321":     true,
+			"127.0.0.2":      false,
+			"10.0.0.1":       false,
+			"":               false,
+			"not-an-address": false,
+		}},
+		{"Remote-Email", "10.0.0.0/8, 192.0.2.1", "", map[string]bool{
+			"10.1.2.3":  true,
+			"192.0.2.1": true,
+			"192.0.2.2": false,
+			"11.0.0.1":  false,
+		}},
+
+		{"", "127.0.0.1", "header is blank", nil},
+		{"Remote-Email", "", "need at least one address", nil},
+		{"Remote-Email", "localhost", "invalid address", nil},
+		{"Remote-Email", "10.0.0.0/33", "invalid address", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header+" "+tt.from, func(t *testing.T) {
+			p, err := goatcounter.NewAuthProxy(tt.header, tt.from, false)
+			if !ztest.ErrorContains(err, tt.wantErr) {
+				t.Fatalf("wrong error\nwant: %s\ngot:  %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if p.Header != "Remote-Email" {
+				t.Errorf("header: %q", p.Header)
+			}
+			for addr, want := range tt.trusted {
+				if got := p.Trusted(addr); got != want {
+					t.Errorf("%q: got %t; want %t", addr, got, want)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"go/types"
 	"runtime"
 	"testing"
 
@@ -20,6 +21,11 @@ func TestDefer(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip()
 	}
+	// The version of x/tools we use expects a *types.StdSizes, which newer Go
+	// versions no longer return.
+	if _, ok := types.SizesFor("gc", runtime.GOARCH).(*types.StdSizes); !ok {
+		t.Skip("golang.org/x/tools is too old for this Go version")
+	}
 
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, Defer, "deferr")
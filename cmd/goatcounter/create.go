@@ -143,8 +143,9 @@ func cmdCreate(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
 			}
 
 			if parent == "" {
-				u := goatcounter.User{Site: s.ID, Email: email, Password: []byte(password), EmailVerified: true}
-				err = u.Insert(ctx)
+				u := goatcounter.User{Site: s.ID, Email: email, Password: []byte(password),
+					EmailVerified: true, Access: goatcounter.AccessOwner}
+				err = u.Insert(ctx, false)
 				if err != nil {
 					return err
 				}
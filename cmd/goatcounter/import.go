@@ -554,7 +554,7 @@ func findSite(siteFlag, dbConnect string) (string, string, func(), error) {
 		token := goatcounter.APIToken{
 			SiteID:      site.ID,
 			Name:        "goatcounter import",
-			Permissions: goatcounter.APITokenPermissions{Count: true},
+			Permissions: goatcounter.APIPermCount,
 		}
 		err = token.Insert(ctx)
 		if err != nil {
@@ -594,7 +594,7 @@ func checkSite(url, key string) error {
 	if err != nil {
 		return err
 	}
-	if !perm.Token.Permissions.Count {
+	if !perm.Token.Permissions.Has(goatcounter.APIPermCount) {
 		return fmt.Errorf("the API token %q is missing the 'count' permission", perm.Token.Name)
 	}
 
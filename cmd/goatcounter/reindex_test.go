@@ -19,7 +19,7 @@ func TestReindex(t *testing.T) {
 	gctest.StoreHits(ctx, t, false, goatcounter.Hit{})
 
 	tables := []string{"hit_stats", "system_stats", "browser_stats",
-		"location_stats", "size_stats", "hit_counts", "ref_counts"}
+		"location_stats", "size_stats", "visitor_stats", "hit_counts", "ref_counts"}
 
 	for _, tbl := range tables {
 		err := zdb.Exec(ctx, `delete from `+tbl)
@@ -47,10 +47,12 @@ func TestReindex(t *testing.T) {
 		1        1        2020-06-18 00:00:00            1      0
 		site_id  path_id  day                  width  count  count_unique
 		1        1        2020-06-18 00:00:00  0      1      0
+		site_id  path_id  day                  count_new  count_returning
+		1        1        2020-06-18 00:00:00  0          1
 		site_id  path_id  hour                 total  total_unique
 		1        1        2020-06-18 12:00:00  1      0
-		site_id  path_id  ref  ref_scheme  hour                 total  total_unique
-		1        1             NULL        2020-06-18 12:00:00  1      0`
+		site_id  path_id  ref  ref_scheme  channel  hour                 total  total_unique
+		1        1             NULL        direct   2020-06-18 12:00:00  1      0`
 
 	if d := zdb.Diff(got, want); d != "" {
 		t.Error(d)
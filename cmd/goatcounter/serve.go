@@ -26,6 +26,7 @@ import (
 	"zgo.at/goatcounter/bgrun"
 	"zgo.at/goatcounter/cron"
 	"zgo.at/goatcounter/handlers"
+	"zgo.at/goatcounter/oidc"
 	"zgo.at/zdb"
 	"zgo.at/zhttp"
 	"zgo.at/zhttp/ztpl"
@@ -95,6 +96,64 @@ Flags:
   -static      Serve static files from a different domain, such as a CDN or
                cookieless domain. Default: not set.
 
+  -oidc        Log in with an OpenID Connect provider, as an URL with the
+               issuer and parameters:
+
+                 https://sso.example.com?client_id=..&client_secret=..
+
+               Other parameters:
+
+                 domains=..     Comma-separated list of email domains users
+                                are allowed to log in with.
+                 role_claim=..  Claim to set the user's role from; the values
+                                viewer, settings, and admin are recognized.
+                                New users are viewers if it's not set.
+                 roles=..       Use other values in role_claim for the roles,
+                                as a comma-separated list of value:role, e.g.
+                                roles=gc-admins:admin,staff:viewer
+                 password=true  Also allow logging in with a password.
+                 allow_unverified_email=true
+                                Also allow email addresses that the provider
+                                doesn't report as verified with the
+                                email_verified claim.
+
+               The client secret can also be read from a file with
+               client_secret_file=.. or from the GOATCOUNTER_OIDC_SECRET
+               environment variable, so it doesn't show up in the process
+               list or shell history.
+
+               Users are created automatically on first login if domains or
+               role_claim is set and the user has one of the roles; otherwise
+               anyone with an account at the provider could sign in, and users
+               need to be invited first. The redirect URL to configure in the
+               provider is /user/oidc/callback on the site. Default: not set.
+
+  -auth-proxy  Sign in users with the email address in this header, which is
+               set by a reverse proxy that handles authentication, such as
+               "Remote-Email" or "X-Forwarded-Email". The header is only
+               trusted for requests from the -auth-proxy-from addresses.
+               Default: not set.
+
+  -auth-proxy-from
+               Comma-separated list of IP addresses or CIDR ranges of the
+               authentication proxy. Default: 127.0.0.1,::1
+
+  -auth-proxy-create
+               Create users from -auth-proxy if they don't exist yet; they're
+               created as viewers. Default: false
+
+  -api-ratelimit
+               Rate limits for the API, as a comma-separated list of
+               group:requests/seconds; every API token gets its own limit for
+               every group. The groups are:
+
+                 count     Recording pageviews.
+                 export    Starting exports.
+                 sites     Creating and updating sites.
+                 default   Everything else.
+
+               Default: count:60/120,export:10/3600,sites:30/120,default:60/120
+
   -geodb       Path to mmdb GeoIP database; can be either the City or Country
                version, but regional information is only recorded with the City
                version.
@@ -113,6 +172,9 @@ Environment:
   TMPDIR       Directory for temporary files; only used to store CSV exports at
                the moment. On Windows it will use the first non-empty value of
                %TMP%, %TEMP%, and %USERPROFILE%.
+
+  GOATCOUNTER_OIDC_SECRET
+               Client secret for -oidc, if it's not given in the URL.
 `
 
 func cmdServe(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
@@ -121,13 +183,18 @@ func cmdServe(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
 	var (
 		port         = f.String("", "port").Pointer()
 		domainStatic = f.String("", "static").Pointer()
+		flagOIDC     = f.String("", "oidc").Pointer()
+		proxyHeader  = f.String("", "auth-proxy").Pointer()
+		proxyFrom    = f.String("127.0.0.1,::1", "auth-proxy-from").Pointer()
+		proxyCreate  = f.Bool(false, "auth-proxy-create").Pointer()
+		apiRatelimit = f.String("", "api-ratelimit").Pointer()
 	)
 	dbConnect, dev, automigrate, listen, flagTLS, from, err := flagsServe(f, &v)
 	if err != nil {
 		return err
 	}
 
-	return func(port, domainStatic string) error {
+	return func(port, domainStatic, flagOIDC string) error {
 		if flagTLS == "" {
 			flagTLS = map[bool]string{true: "none", false: "acme,rdr"}[dev]
 		}
@@ -143,6 +210,31 @@ func cmdServe(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
 			domainCount = domainStatic
 		}
 
+		var provider *oidc.Provider
+		if flagOIDC != "" {
+			var err error
+			provider, err = oidc.Parse(flagOIDC)
+			if err != nil {
+				v.Append("-oidc", err.Error())
+			} else if provider.ClientSecret == "" {
+				provider.ClientSecret = os.Getenv("GOATCOUNTER_OIDC_SECRET")
+			}
+		}
+
+		var authProxy *goatcounter.AuthProxy
+		if *proxyHeader != "" {
+			var err error
+			authProxy, err = goatcounter.NewAuthProxy(*proxyHeader, *proxyFrom, *proxyCreate)
+			if err != nil {
+				v.Append("-auth-proxy", err.Error())
+			}
+		}
+
+		ratelimits, err := goatcounter.NewAPIRatelimits(*apiRatelimit)
+		if err != nil {
+			v.Append("-api-ratelimit", err.Error())
+		}
+
 		//from := flagFrom(from, "cfg.Domain", &v)
 		from := flagFrom(from, "", &v)
 		if v.HasErrors() {
@@ -164,6 +256,9 @@ func cmdServe(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
 		c.Dev = dev
 		c.URLStatic = urlStatic
 		c.DomainCount = domainCount
+		c.OIDC = provider
+		c.AuthProxy = authProxy
+		c.APIRatelimits = ratelimits
 
 		// Set up HTTP handler and servers.
 		hosts := map[string]http.Handler{
@@ -189,7 +284,7 @@ func cmdServe(f zli.Flags, ready chan<- struct{}, stop chan struct{}) error {
 			}
 			ready <- struct{}{}
 		})
-	}(*port, *domainStatic)
+	}(*port, *domainStatic, *flagOIDC)
 }
 
 func doServe(ctx context.Context, db zdb.DB,
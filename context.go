@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"zgo.at/goatcounter/oidc"
 	"zgo.at/zcache"
 	"zgo.at/zdb"
 	"zgo.at/zhttp/ctxkey"
@@ -28,7 +29,9 @@ var (
 	keyChangedTitles   = &struct{ n string }{""}
 	keyCacheSitesProxy = &struct{ n string }{""}
 
-	keyConfig = &struct{ n string }{""}
+	keyConfig     = &struct{ n string }{""}
+	keyShareToken = &struct{ n string }{""}
+	keyAPIToken   = &struct{ n string }{""}
 )
 
 type GlobalConfig struct {
@@ -42,6 +45,9 @@ type GlobalConfig struct {
 	Port           string
 	EmailFrom      string
 	BcryptMinCost  bool
+	OIDC           *oidc.Provider
+	AuthProxy      *AuthProxy
+	APIRatelimits  APIRatelimits
 }
 
 // WithSite adds the site to the context.
@@ -75,6 +81,31 @@ func GetUser(ctx context.Context) *User {
 	return u
 }
 
+// WithShareToken adds the share token used to view the dashboard to the
+// context.
+func WithShareToken(ctx context.Context, t *ShareToken) context.Context {
+	return context.WithValue(ctx, keyShareToken, t)
+}
+
+// GetShareToken gets the share token used to view the dashboard; this is nil
+// if the dashboard isn't viewed with a share token.
+func GetShareToken(ctx context.Context) *ShareToken {
+	t, _ := ctx.Value(keyShareToken).(*ShareToken)
+	return t
+}
+
+// WithAPIToken adds the API token used for the request to the context.
+func WithAPIToken(ctx context.Context, t *APIToken) context.Context {
+	return context.WithValue(ctx, keyAPIToken, t)
+}
+
+// GetAPIToken gets the API token used for the request; this is nil if the
+// request wasn't made with an API token.
+func GetAPIToken(ctx context.Context) *APIToken {
+	t, _ := ctx.Value(keyAPIToken).(*APIToken)
+	return t
+}
+
 // CopyContextValues creates a new context with the all the request values set.
 //
 // Useful for tests, or for "removing" the timeout on the request context so it
@@ -115,6 +146,12 @@ func CopyContextValues(ctx context.Context) context.Context {
 	if u := GetUser(ctx); u != nil {
 		n = context.WithValue(n, ctxkey.User, u)
 	}
+	if t := GetShareToken(ctx); t != nil {
+		n = context.WithValue(n, keyShareToken, t)
+	}
+	if t := GetAPIToken(ctx); t != nil {
+		n = context.WithValue(n, keyAPIToken, t)
+	}
 	return n
 }
 
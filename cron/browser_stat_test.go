@@ -27,7 +27,7 @@ func TestBrowserStats(t *testing.T) {
 	}...)
 
 	var stats goatcounter.HitStats
-	err := stats.ListBrowsers(ctx, now, now, nil, 10, 0)
+	err := stats.ListBrowsers(ctx, now, now, goatcounter.Filter{}, 10, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,7 +47,7 @@ func TestBrowserStats(t *testing.T) {
 	}...)
 
 	stats = goatcounter.HitStats{}
-	err = stats.ListBrowsers(ctx, now, now, nil, 10, 0)
+	err = stats.ListBrowsers(ctx, now, now, goatcounter.Filter{}, 10, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -60,7 +60,7 @@ func TestBrowserStats(t *testing.T) {
 
 	// List just Firefox.
 	stats = goatcounter.HitStats{}
-	err = stats.ListBrowser(ctx, "Firefox", now, now, nil)
+	err = stats.ListBrowser(ctx, "Firefox", now, now, goatcounter.Filter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -28,7 +28,7 @@ func TestHitStats(t *testing.T) {
 
 	check := func(wantT, want0, want1 string) {
 		var stats goatcounter.HitLists
-		display, displayUnique, more, err := stats.List(ctx, now.Add(-1*time.Hour), now.Add(1*time.Hour), nil, nil, false)
+		display, displayUnique, more, err := stats.List(ctx, now.Add(-1*time.Hour), now.Add(1*time.Hour), goatcounter.Filter{}, nil, 0, false)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -26,7 +26,7 @@ func TestLocationStats(t *testing.T) {
 	}...)
 
 	var stats goatcounter.HitStats
-	err := stats.ListLocations(ctx, now, now, nil, 10, 0)
+	err := stats.ListLocations(ctx, now, now, goatcounter.Filter{}, 10, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -49,7 +49,7 @@ func TestLocationStats(t *testing.T) {
 	}...)
 
 	stats = goatcounter.HitStats{}
-	err = stats.ListLocations(ctx, now, now, nil, 10, 0)
+	err = stats.ListLocations(ctx, now, now, goatcounter.Filter{}, 10, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
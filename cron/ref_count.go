@@ -22,6 +22,7 @@ func updateRefCounts(ctx context.Context, hits []goatcounter.Hit, isReindex bool
 			pathID      int64
 			ref         string
 			refScheme   *string
+			channel     string
 		}
 		grouped := map[string]gt{}
 		for _, h := range hits {
@@ -37,6 +38,9 @@ func updateRefCounts(ctx context.Context, hits []goatcounter.Hit, isReindex bool
 				v.pathID = h.PathID
 				v.ref = h.Ref
 				v.refScheme = h.RefScheme
+				// Always classify again rather than using h.Channel, so that
+				// a reindex picks up changes in the RefChannels setting.
+				v.channel = goatcounter.RefChannel(ctx, h.Ref, h.RefScheme)
 			}
 
 			v.total += 1
@@ -48,7 +52,7 @@ func updateRefCounts(ctx context.Context, hits []goatcounter.Hit, isReindex bool
 
 		siteID := goatcounter.MustGetSite(ctx).ID
 		ins := zdb.NewBulkInsert(ctx, "ref_counts", []string{"site_id", "path_id",
-			"ref", "hour", "total", "total_unique", "ref_scheme"})
+			"ref", "hour", "total", "total_unique", "ref_scheme", "channel"})
 		if zdb.Driver(ctx) == zdb.DriverPostgreSQL {
 			ins.OnConflict(`on conflict on constraint "ref_counts#site_id#path_id#ref#hour" do update set
 				total        = ref_counts.total        + excluded.total,
@@ -65,7 +69,7 @@ func updateRefCounts(ctx context.Context, hits []goatcounter.Hit, isReindex bool
 		}
 
 		for _, v := range grouped {
-			ins.Values(siteID, v.pathID, v.ref, v.hour, v.total, v.totalUnique, v.refScheme)
+			ins.Values(siteID, v.pathID, v.ref, v.hour, v.total, v.totalUnique, v.refScheme, v.channel)
 		}
 		return ins.Finish()
 	})
@@ -29,7 +29,7 @@ func TestSizeStats(t *testing.T) {
 	}...)
 
 	var stats goatcounter.HitStats
-	err := stats.ListSizes(ctx, now, now, nil)
+	err := stats.ListSizes(ctx, now, now, goatcounter.Filter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,7 +56,7 @@ func TestSizeStats(t *testing.T) {
 	}...)
 
 	stats = goatcounter.HitStats{}
-	err = stats.ListSizes(ctx, now, now, nil)
+	err = stats.ListSizes(ctx, now, now, goatcounter.Filter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -153,6 +153,7 @@ func UpdateStats(ctx context.Context, site *goatcounter.Site, siteID int64, hits
 		updateSystemStats,
 		updateLocationStats,
 		updateSizeStats,
+		updateVisitorStats,
 	}
 
 	for _, f := range funs {
@@ -203,6 +204,8 @@ func ReindexStats(ctx context.Context, site goatcounter.Site, hits []goatcounter
 			err = updateLocationStats(ctx, hits, true)
 		case "size_stats":
 			err = updateSizeStats(ctx, hits, true)
+		case "visitor_stats":
+			err = updateVisitorStats(ctx, hits, true)
 		}
 		if err != nil {
 			return err
@@ -259,10 +262,19 @@ func vacuumDeleted(ctx context.Context) error {
 	for _, s := range sites {
 		zlog.Module("vacuum").Printf("vacuum site %s/%d", s.Code, s.ID)
 		err := zdb.TX(ctx, func(ctx context.Context) error {
+			// These don't have a site_id, but reference users.
+			for _, t := range []string{"webauthn_credentials", "totp_recovery_codes", "user_sessions"} {
+				err := zdb.Exec(ctx, fmt.Sprintf(
+					`delete from %s where user_id in (select user_id from users where site_id=%d)`, t, s.ID))
+				if err != nil {
+					return errors.Errorf("%s: %w", t, err)
+				}
+			}
+
 			for _, t := range []string{"hits", "paths", "hit_counts",
 				"ref_counts", "browser_stats", "system_stats", "hit_stats",
-				"location_stats", "size_stats", "exports", "api_tokens", "users",
-				"sites"} {
+				"location_stats", "size_stats", "visitor_stats", "exports", "api_tokens",
+				"share_tokens", "annotations", "audit_log", "users", "sites"} {
 
 				err := zdb.Exec(ctx, fmt.Sprintf(`delete from %s where site_id=%d`, t, s.ID))
 				if err != nil {
@@ -304,3 +316,8 @@ func sessions(ctx context.Context) error {
 	goatcounter.Memstore.RefreshSalt()
 	return nil
 }
+
+// Remove login sessions that haven't been used in a while.
+func userSessions(ctx context.Context) error {
+	return goatcounter.UserSessions{}.DeleteExpired(ctx)
+}
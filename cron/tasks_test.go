@@ -51,7 +51,7 @@ func TestDataRetention(t *testing.T) {
 	}
 
 	var stats goatcounter.HitLists
-	display, displayUnique, more, err := stats.List(ctx, past.Add(-1*24*time.Hour), now, nil, nil, false)
+	display, displayUnique, more, err := stats.List(ctx, past.Add(-1*24*time.Hour), now, goatcounter.Filter{}, nil, 0, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,68 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package cron
+
+import (
+	"context"
+	"strconv"
+
+	"zgo.at/goatcounter"
+	"zgo.at/zdb"
+)
+
+func updateVisitorStats(ctx context.Context, hits []goatcounter.Hit, isReindex bool) error {
+	return zdb.TX(ctx, func(ctx context.Context) error {
+		type gt struct {
+			countNew       int
+			countReturning int
+			day            string
+			pathID         int64
+		}
+		grouped := map[string]gt{}
+		for _, h := range hits {
+			if h.Bot > 0 {
+				continue
+			}
+
+			day := h.CreatedAt.Format("2006-01-02")
+			k := day + strconv.FormatInt(h.PathID, 10)
+			v := grouped[k]
+			if v.day == "" {
+				v.day = day
+				v.pathID = h.PathID
+			}
+
+			if h.FirstVisit {
+				v.countNew += 1
+			} else {
+				v.countReturning += 1
+			}
+			grouped[k] = v
+		}
+
+		siteID := goatcounter.MustGetSite(ctx).ID
+		ins := zdb.NewBulkInsert(ctx, "visitor_stats", []string{"site_id", "day",
+			"path_id", "count_new", "count_returning"})
+		if zdb.Driver(ctx) == zdb.DriverPostgreSQL {
+			ins.OnConflict(`on conflict on constraint "visitor_stats#site_id#path_id#day" do update set
+				count_new       = visitor_stats.count_new       + excluded.count_new,
+				count_returning = visitor_stats.count_returning + excluded.count_returning`)
+
+			err := zdb.Exec(ctx, `lock table visitor_stats in exclusive mode`)
+			if err != nil {
+				return err
+			}
+		} else {
+			ins.OnConflict(`on conflict(site_id, path_id, day) do update set
+				count_new       = visitor_stats.count_new       + excluded.count_new,
+				count_returning = visitor_stats.count_returning + excluded.count_returning`)
+		}
+
+		for _, v := range grouped {
+			ins.Values(siteID, v.day, v.pathID, v.countNew, v.countReturning)
+		}
+		return ins.Finish()
+	})
+}
@@ -0,0 +1,12 @@
+create table annotations (
+	annotation_id  serial         primary key,
+	site_id        integer        not null,
+
+	at             timestamp      not null,
+	path           varchar        not null default '',
+	text           varchar        not null,
+	created_at     timestamp      not null,
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict
+);
+create index "annotations#site_id#at" on annotations(site_id, at);
@@ -0,0 +1,12 @@
+create table annotations (
+	annotation_id  integer        primary key autoincrement,
+	site_id        integer        not null,
+
+	at             timestamp      not null                 check(at = strftime('%Y-%m-%d %H:%M:%S', at)),
+	path           varchar        not null default '',
+	text           varchar        not null,
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict
+);
+create index "annotations#site_id#at" on annotations(site_id, at);
@@ -0,0 +1,17 @@
+create table share_tokens (
+	share_token_id serial         primary key,
+	site_id        integer        not null,
+	user_id        integer        not null,
+
+	name           varchar        not null,
+	token          varchar        not null                 check(length(token) > 10),
+	filter         varchar        not null default '',
+	widgets        varchar        not null default '',
+	expires_at     timestamp,
+	last_used_at   timestamp,
+	created_at     timestamp      not null,
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict,
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "share_tokens#site_id#token" on share_tokens(site_id, token);
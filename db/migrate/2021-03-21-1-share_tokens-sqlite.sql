@@ -0,0 +1,17 @@
+create table share_tokens (
+	share_token_id integer        primary key autoincrement,
+	site_id        integer        not null,
+	user_id        integer        not null,
+
+	name           varchar        not null,
+	token          varchar        not null                 check(length(token) > 10),
+	filter         varchar        not null default '',
+	widgets        varchar        not null default '',
+	expires_at     timestamp                               check(expires_at is null or expires_at = strftime('%Y-%m-%d %H:%M:%S', expires_at)),
+	last_used_at   timestamp                               check(last_used_at is null or last_used_at = strftime('%Y-%m-%d %H:%M:%S', last_used_at)),
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict,
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "share_tokens#site_id#token" on share_tokens(site_id, token);
@@ -0,0 +1,2 @@
+update sites set settings = jsonb_set(settings, '{widgets}', (settings->'widgets') || '[{"name": "heatmap", "on": false}]'::jsonb)
+	where jsonb_typeof(settings->'widgets') = 'array' and not (settings->'widgets' @> '[{"name": "heatmap"}]'::jsonb);
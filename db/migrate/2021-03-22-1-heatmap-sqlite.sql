@@ -0,0 +1,3 @@
+update sites set settings = json_insert(settings,
+		'$.widgets[' || json_array_length(settings, '$.widgets') || ']', json('{"name": "heatmap", "on": false}'))
+	where json_type(settings, '$.widgets') = 'array' and settings not like '%"heatmap"%';
@@ -0,0 +1,22 @@
+create table visitor_stats (
+	site_id         integer        not null,
+	path_id         integer        not null,  -- No FK for performance.
+
+	day             date           not null,
+	count_new       integer        not null,
+	count_returning integer        not null,
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict,
+	constraint "visitor_stats#site_id#path_id#day" unique(site_id, path_id, day)
+);
+create index "visitor_stats#site_id#day" on visitor_stats(site_id, day desc);
+cluster visitor_stats using "visitor_stats#site_id#day";
+alter table visitor_stats replica identity using index "visitor_stats#site_id#path_id#day";
+
+insert into visitor_stats (site_id, path_id, day, count_new, count_returning)
+	select site_id, path_id, cast(hour as date), sum(total_unique), sum(total) - sum(total_unique)
+	from hit_counts
+	group by site_id, path_id, cast(hour as date);
+
+update sites set settings = jsonb_set(settings, '{widgets}', (settings->'widgets') || '[{"name": "visitors", "on": false}]'::jsonb)
+	where jsonb_typeof(settings->'widgets') = 'array' and not (settings->'widgets' @> '[{"name": "visitors"}]'::jsonb);
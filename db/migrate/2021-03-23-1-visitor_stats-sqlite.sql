@@ -0,0 +1,21 @@
+create table visitor_stats (
+	site_id         integer        not null,
+	path_id         integer        not null,  -- No FK for performance.
+
+	day             date           not null                 check(day = strftime('%Y-%m-%d', day)),
+	count_new       integer        not null,
+	count_returning integer        not null,
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict,
+	constraint "visitor_stats#site_id#path_id#day" unique(site_id, path_id, day) on conflict replace
+);
+create index "visitor_stats#site_id#day" on visitor_stats(site_id, day desc);
+
+insert into visitor_stats (site_id, path_id, day, count_new, count_returning)
+	select site_id, path_id, date(hour), sum(total_unique), sum(total) - sum(total_unique)
+	from hit_counts
+	group by site_id, path_id, date(hour);
+
+update sites set settings = json_insert(settings,
+		'$.widgets[' || json_array_length(settings, '$.widgets') || ']', json('{"name": "visitors", "on": false}'))
+	where json_type(settings, '$.widgets') = 'array' and settings not like '%"visitors"%';
@@ -0,0 +1,24 @@
+alter table hits       add column channel varchar not null default '';
+alter table ref_counts add column channel varchar not null default '';
+
+-- Only the groups we can detect easily; run "goatcounter reindex -table ref_counts"
+-- to classify everything.
+update hits set channel = case
+		when ref_scheme = 'c'                     then 'campaign'
+		when ref = ''                             then 'direct'
+		when ref in ('Google', 'Yahoo', 'Baidu')  then 'search'
+		when ref in ('Hacker News', 'www.reddit.com', 'www.facebook.com', 'twitter.com', 'lobste.rs') then 'social'
+		when ref = 'Email'                        then 'email'
+		else 'referral'
+	end;
+update ref_counts set channel = case
+		when ref_scheme = 'c'                     then 'campaign'
+		when ref = ''                             then 'direct'
+		when ref in ('Google', 'Yahoo', 'Baidu')  then 'search'
+		when ref in ('Hacker News', 'www.reddit.com', 'www.facebook.com', 'twitter.com', 'lobste.rs') then 'social'
+		when ref = 'Email'                        then 'email'
+		else 'referral'
+	end;
+
+update sites set settings = jsonb_set(settings, '{widgets}', (settings->'widgets') || '[{"name": "channels", "on": false}]'::jsonb)
+	where jsonb_typeof(settings->'widgets') = 'array' and not (settings->'widgets' @> '[{"name": "channels"}]'::jsonb);
@@ -0,0 +1,3 @@
+alter table users add column access varchar not null default 'r' check(access in ('r', 's', 'a', 'o'));
+update users set access='a';
+update users set access='o' where user_id in (select min(user_id) from users group by site_id);
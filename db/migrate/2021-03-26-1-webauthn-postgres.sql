@@ -0,0 +1,15 @@
+create table webauthn_credentials (
+	webauthn_credential_id serial primary key,
+	user_id        integer        not null,
+
+	name           varchar        not null,
+	raw_id         bytea          not null,
+	public_key     bytea          not null,
+	sign_count     bigint         not null default 0,
+	last_used_at   timestamp,
+	created_at     timestamp      not null,
+
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "webauthn_credentials#raw_id" on webauthn_credentials(raw_id);
+create        index "webauthn_credentials#user_id" on webauthn_credentials(user_id);
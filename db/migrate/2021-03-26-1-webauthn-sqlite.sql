@@ -0,0 +1,15 @@
+create table webauthn_credentials (
+	webauthn_credential_id integer primary key autoincrement,
+	user_id        integer        not null,
+
+	name           varchar        not null,
+	raw_id         blob           not null,
+	public_key     blob           not null,
+	sign_count     bigint         not null default 0,
+	last_used_at   timestamp                               check(last_used_at is null or last_used_at = strftime('%Y-%m-%d %H:%M:%S', last_used_at)),
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "webauthn_credentials#raw_id" on webauthn_credentials(raw_id);
+create        index "webauthn_credentials#user_id" on webauthn_credentials(user_id);
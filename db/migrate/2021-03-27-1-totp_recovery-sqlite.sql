@@ -0,0 +1,8 @@
+create table totp_recovery_codes (
+	user_id        integer        not null,
+	hash           varchar        not null,
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "totp_recovery_codes#user_id#hash" on totp_recovery_codes(user_id, hash);
@@ -0,0 +1,12 @@
+create table audit_log (
+	audit_log_id   serial         primary key,
+	site_id        integer        not null,
+	user_id        integer,
+
+	email          varchar        not null,
+	action         varchar        not null,
+	data           jsonb          not null,
+	ip             varchar        not null,
+	created_at     timestamp      not null
+);
+create index "audit_log#site_id#created_at" on audit_log(site_id, created_at desc);
@@ -0,0 +1,12 @@
+create table audit_log (
+	audit_log_id   integer        primary key autoincrement,
+	site_id        integer        not null,
+	user_id        integer,
+
+	email          varchar        not null,
+	action         varchar        not null,
+	data           varchar        not null,
+	ip             varchar        not null,
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at))
+);
+create index "audit_log#site_id#created_at" on audit_log(site_id, created_at desc);
@@ -0,0 +1,23 @@
+create table user_sessions (
+	user_session_id integer       primary key autoincrement,
+	user_id        integer        not null,
+
+	token          varchar        not null                 check(length(token) > 10),
+	csrf_token     varchar        not null,
+	user_agent     varchar        not null,
+	ip             varchar        not null,
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+	last_seen_at   timestamp      not null                 check(last_seen_at = strftime('%Y-%m-%d %H:%M:%S', last_seen_at)),
+
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+create unique index "user_sessions#token"   on user_sessions(token);
+create        index "user_sessions#user_id" on user_sessions(user_id);
+
+-- Keep everyone logged in; existing sessions keep the CSRF token that was
+-- shared between all devices. The login_token column is kept as SQLite can't
+-- drop columns.
+insert into user_sessions (user_id, token, csrf_token, user_agent, ip, created_at, last_seen_at)
+	select user_id, login_token, csrf_token, '', '', datetime(), datetime() from users
+	where login_token is not null and length(login_token) > 10 and csrf_token is not null;
+update users set login_token=null;
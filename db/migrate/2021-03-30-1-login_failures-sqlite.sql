@@ -0,0 +1,2 @@
+alter table users add column login_failures integer not null default 0;
+alter table users add column locked_until timestamp null check(locked_until = strftime('%Y-%m-%d %H:%M:%S', locked_until));
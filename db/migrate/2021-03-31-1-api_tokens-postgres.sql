@@ -0,0 +1,15 @@
+alter table api_tokens alter column permissions type integer using
+	(case when permissions->>'count'       = 'true' then 1   else 0 end) |
+	(case when permissions->>'export'      = 'true' then 2   else 0 end) |
+	(case when permissions->>'site_read'   = 'true' then 4   else 0 end) |
+	(case when permissions->>'site_create' = 'true' then 8   else 0 end) |
+	(case when permissions->>'site_update' = 'true' then 16  else 0 end) |
+	(case when permissions->>'annotations' = 'true' then 32  else 0 end) |
+	(case when permissions->>'stats'       = 'true' then 64  else 0 end) |
+	(case when permissions->>'audit'       = 'true' then 128 else 0 end);
+
+alter table api_tokens add column sites        varchar   not null default '';
+alter table api_tokens add column ip_ranges    varchar   not null default '';
+alter table api_tokens add column expires_at   timestamp;
+alter table api_tokens add column last_used_at timestamp;
+alter table api_tokens add column last_used_ip varchar   not null default '';
@@ -0,0 +1,33 @@
+create table api_tokens2 (
+	api_token_id   integer        primary key autoincrement,
+	site_id        integer        not null,
+	user_id        integer        not null,
+
+	name           varchar        not null,
+	token          varchar        not null                 check(length(token) > 10),
+	permissions    integer        not null,
+	sites          varchar        not null default '',
+	ip_ranges      varchar        not null default '',
+	expires_at     timestamp                               check(expires_at is null or expires_at = strftime('%Y-%m-%d %H:%M:%S', expires_at)),
+	last_used_at   timestamp                               check(last_used_at is null or last_used_at = strftime('%Y-%m-%d %H:%M:%S', last_used_at)),
+	last_used_ip   varchar        not null default '',
+	created_at     timestamp      not null                 check(created_at = strftime('%Y-%m-%d %H:%M:%S', created_at)),
+
+	foreign key (site_id) references sites(site_id) on delete restrict on update restrict,
+	foreign key (user_id) references users(user_id) on delete restrict on update restrict
+);
+insert into api_tokens2 (api_token_id, site_id, user_id, name, token, permissions, created_at)
+	select api_token_id, site_id, user_id, name, token,
+		(case when json_extract(permissions, '$.count')       then 1   else 0 end) |
+		(case when json_extract(permissions, '$.export')      then 2   else 0 end) |
+		(case when json_extract(permissions, '$.site_read')   then 4   else 0 end) |
+		(case when json_extract(permissions, '$.site_create') then 8   else 0 end) |
+		(case when json_extract(permissions, '$.site_update') then 16  else 0 end) |
+		(case when json_extract(permissions, '$.annotations') then 32  else 0 end) |
+		(case when json_extract(permissions, '$.stats')       then 64  else 0 end) |
+		(case when json_extract(permissions, '$.audit')       then 128 else 0 end),
+		created_at
+	from api_tokens;
+drop table api_tokens;
+alter table api_tokens2 rename to api_tokens;
+create unique index "api_tokens#site_id#token" on api_tokens(site_id, token);
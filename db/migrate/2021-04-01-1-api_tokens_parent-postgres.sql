@@ -0,0 +1,6 @@
+-- API tokens are stored for the parent site now; tokens created on a child site
+-- are limited to that site, so they can't be used for more than before.
+update api_tokens set
+	sites   = case when sites = '' then cast(site_id as varchar) else sites end,
+	site_id = (select parent from sites where sites.site_id = api_tokens.site_id)
+where site_id in (select site_id from sites where parent is not null);
@@ -0,0 +1,3 @@
+-- Rate limits for the token, as group:limit/period; the server defaults are
+-- used for groups that aren't set.
+alter table api_tokens add column ratelimits varchar;
@@ -0,0 +1,6 @@
+select hour, sum(total) as total, sum(total_unique) as total_unique
+from hit_counts
+where
+	hit_counts.site_id = :site and hour >= :start and hour <= :end
+	{{:filter and path_id in (:filter)}}
+group by hour
@@ -0,0 +1,12 @@
+select
+	paths.path                   as name,
+	sum(hit_counts.total)        as count,
+	sum(hit_counts.total_unique) as count_unique
+from hit_counts
+join paths using (path_id)
+where
+	hit_counts.site_id in (:sites) and hour >= :start and hour <= :end and
+	paths.event = 0
+group by paths.path
+order by count_unique desc, name
+limit 10
@@ -0,0 +1,11 @@
+select
+	ref                            as name,
+	max(ref_scheme)                as ref_scheme,
+	coalesce(sum(total), 0)        as count,
+	coalesce(sum(total_unique), 0) as count_unique
+from ref_counts
+where
+	site_id in (:sites) and hour >= :start and hour <= :end
+group by ref
+order by count_unique desc, ref
+limit 10
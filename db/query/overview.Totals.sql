@@ -0,0 +1,12 @@
+select
+	hit_counts.site_id,
+	hour,
+	sum(total)        as total,
+	sum(total_unique) as total_unique
+from hit_counts
+join paths using (path_id)
+where
+	hit_counts.site_id in (:sites) and hour >= :prev_start and hour <= :end and
+	paths.event = 0
+group by hit_counts.site_id, hour
+order by hour asc
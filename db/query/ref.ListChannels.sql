@@ -0,0 +1,11 @@
+select
+	coalesce(sum(total), 0)        as count,
+	coalesce(sum(total_unique), 0) as count_unique,
+	channel                        as id
+from ref_counts
+where
+	site_id = :site and hour >= :start and hour <= :end
+	{{:filter     and path_id in (:filter)}}
+	{{:has_domain and ref not like :ref}}
+group by channel
+order by count_unique desc, channel
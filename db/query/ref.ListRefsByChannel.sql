@@ -0,0 +1,14 @@
+select
+	coalesce(sum(total), 0)        as count,
+	coalesce(sum(total_unique), 0) as count_unique,
+	max(ref_scheme)                as ref_scheme,
+	ref                            as name
+from ref_counts
+where
+	site_id = :site and hour >= :start and hour <= :end and
+	{{:filter     path_id in (:filter) and}}
+	{{:has_domain ref not like :ref and}}
+	channel = :channel
+group by ref
+order by count_unique desc, ref
+limit :limit
@@ -0,0 +1,10 @@
+select
+	day,
+	sum(count_new)       as count_new,
+	sum(count_returning) as count_returning
+from visitor_stats
+where
+	site_id = :site and day >= :start and day <= :end
+	{{:filter and path_id in (:filter)}}
+group by day
+order by day asc
@@ -0,0 +1,9 @@
+select
+	visitor_stats.path_id,
+	sum(count_new)       as count_new,
+	sum(count_returning) as count_returning
+from visitor_stats
+join top using (path_id)
+where
+	site_id = :site and day >= :start and day <= :end
+group by visitor_stats.path_id
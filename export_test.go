@@ -16,6 +16,7 @@ import (
 	"zgo.at/goatcounter/cron"
 	"zgo.at/goatcounter/gctest"
 	"zgo.at/zdb"
+	"zgo.at/zstd/zcrypto"
 	"zgo.at/zstd/zjson"
 	"zgo.at/zstd/ztest"
 )
@@ -98,7 +99,7 @@ func TestExport(t *testing.T) {
 			"finished_at": null,
 			"num_rows": 5,
 			"size": "0.1",
-			"hash": "sha256-8a34b87e1ae93292e61984f723fbe6cda8a95dbefc0762ac2864bc98a1a13bc1",
+			"hash": "sha256-%(ANY)",
 			"error": null
 		}`, "\t", "")
 		got := string(zjson.MustMarshalIndent(export, "", ""))
@@ -106,6 +107,16 @@ func TestExport(t *testing.T) {
 			t.Fatal(d)
 		}
 
+		// The gzip output differs between Go versions, so check the hash
+		// against the file rather than a fixed value.
+		hash, err := zcrypto.HashFile(export.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if export.Hash == nil || *export.Hash != hash {
+			t.Errorf("wrong hash: %v; want %s", export.Hash, hash)
+		}
+
 		var exports goatcounter.Exports
 		err = exports.List(ctx)
 		if err != nil {
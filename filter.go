@@ -0,0 +1,327 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/goatcounter/filter"
+	"zgo.at/zdb"
+)
+
+// Filter for the dashboard.
+//
+// The path and title are filtered with the path_id column in the stats tables;
+// none of the other fields are stored in those, so the stats are calculated
+// from the hits table if the filter contains any of them.
+type Filter struct {
+	// Path IDs to filter on; nil means no filter.
+	Paths []int64
+
+	// Conditions for the hits table.
+	where  string
+	params zdb.P
+}
+
+// Keys for the filter expression, e.g. "ref:example.com".
+var filterKeys = []string{"path", "title", "ref", "campaign", "browser", "system", "location", "size", "visitor"}
+
+// Size groups; same as ListSizes().
+var filterSizes = map[string][2]int{
+	"unknown":     {0, 0},
+	"phones":      {1, 384},
+	"largephones": {385, 1024},
+	"tablets":     {1025, 1440},
+	"desktop":     {1441, 1920},
+	"desktophd":   {1921, 99999},
+}
+
+var reLocationCode = regexp.MustCompile(`^[a-zA-Z]{2}(-[a-zA-Z0-9]{1,3})?$`)
+
+// NewFilter parses the filter expression from the dashboard; see the filter
+// package for the syntax.
+//
+// Syntax errors are returned as a *filter.Error.
+func NewFilter(ctx context.Context, text string) (Filter, error) {
+	return NewFilterAnd(ctx, text)
+}
+
+// NewFilterAnd is like NewFilter, but combines several filter expressions with
+// AND; empty expressions are skipped.
+//
+// Every expression is parsed on its own, so one can't change the meaning of
+// the others; this is used to narrow down the fixed filter of share links.
+func NewFilterAnd(ctx context.Context, text ...string) (Filter, error) {
+	var e filter.Expr
+	for _, t := range text {
+		if strings.TrimSpace(t) == "" {
+			continue
+		}
+		p, err := filter.Parse(t, filterKeys...)
+		if err != nil {
+			return Filter{}, err
+		}
+		if e == nil {
+			e = p
+		} else {
+			e = filter.And{Left: e, Right: p}
+		}
+	}
+	if e == nil {
+		return Filter{}, nil
+	}
+
+	where, params, err := filter.SQL(e, "filter_", filterTerm)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	for _, k := range filter.Keys(e) {
+		if k != "" && k != "path" && k != "title" {
+			return Filter{where: where, params: params}, nil
+		}
+	}
+
+	// Only need the path IDs.
+	var paths []int64
+	params["site"] = MustGetSite(ctx).ID
+	// The limit is here because that's the limit in SQL parameters; the
+	// returned IDs are passed as parameters later on.
+	err = zdb.Select(ctx, &paths, `/* NewFilter */
+		select path_id from paths
+		where site_id = :site and `+where+`
+		limit 65500`, params)
+	if err != nil {
+		return Filter{}, errors.Wrap(err, "NewFilter")
+	}
+	if len(paths) == 0 {
+		paths = []int64{-1}
+	}
+	return Filter{Paths: paths}, nil
+}
+
+func filterTerm(p *filter.Params, t filter.Term) (string, error) {
+	switch t.Key {
+	default:
+		return p.Like(t, "paths.path", "paths.title"), nil
+	case "path":
+		return p.Like(t, "paths.path"), nil
+	case "title":
+		return p.Like(t, "paths.title"), nil
+	case "ref":
+		return p.Like(t, "hits.ref"), nil
+	case "campaign":
+		return `coalesce(hits.ref_scheme, '') = 'c' and ` + p.Like(t, "hits.ref"), nil
+	case "browser":
+		return p.Like(t, "browsers.name || ' ' || coalesce(browsers.version, '')"), nil
+	case "system":
+		return p.Like(t, "systems.name || ' ' || coalesce(systems.version, '')"), nil
+	case "location":
+		switch {
+		case t.Glob():
+			return p.Like(t, "hits.location"), nil
+		case reLocationCode.MatchString(t.Value):
+			return `hits.location = ` + p.Add(strings.ToUpper(t.Value)) +
+				` or hits.location like ` + p.Add(strings.ToUpper(t.Value)+"-%"), nil
+		default:
+			return p.Like(t, "locations.country_name", "locations.region_name"), nil
+		}
+	case "size":
+		r, ok := filterSizes[strings.ToLower(t.Value)]
+		if !ok {
+			n, err := strconv.Atoi(t.Value)
+			if err != nil || n < 0 {
+				return "", fmt.Errorf("unknown size %q; must be a number or one of: "+
+					"unknown, phones, largephones, tablets, desktop, desktophd", t.Value)
+			}
+			r = [2]int{n, n}
+		}
+		return filterWidth + ` >= ` + p.Add(r[0]) + ` and ` + filterWidth + ` <= ` + p.Add(r[1]), nil
+	case "visitor":
+		switch strings.ToLower(t.Value) {
+		case "new":
+			return `hits.first_visit = 1`, nil
+		case "returning":
+			return `hits.first_visit = 0`, nil
+		}
+		return "", fmt.Errorf("unknown visitor %q; must be new or returning", t.Value)
+	}
+}
+
+// raw reports if this filter needs to read from the hits table.
+func (f Filter) raw() bool { return f.where != "" }
+
+// Width from the size column.
+const filterWidth = `{{:sqlite cast(hits.size as integer)}}` +
+	`{{:pgsql cast(floor(cast(coalesce(nullif(split_part(hits.size, ',', 1), ''), '0') as float)) as integer)}}`
+
+// Replace the stats tables with CTEs that read from the hits table; the
+// columns and the grouping are identical to the stats tables, so the same
+// queries can be used.
+var filterCTE = `hit_filter as (
+		select
+			hits.site_id, hits.path_id, hits.ref, hits.ref_scheme, hits.channel, hits.location,
+			hits.first_visit,
+			user_agents.browser_id, user_agents.system_id,
+			{{:sqlite strftime('%Y-%m-%d %H::00::00', hits.created_at)}}{{:pgsql date_trunc('hour', hits.created_at)}} as hour,
+			{{:sqlite cast(strftime('%H', hits.created_at) as integer)}}{{:pgsql cast(extract(hour from hits.created_at) as integer)}} as hour_of_day,
+			{{:sqlite date(hits.created_at)}}{{:pgsql cast(hits.created_at as date)}} as day,
+			` + filterWidth + ` as width
+		from hits
+		left join user_agents on user_agents.user_agent_id = hits.user_agent_id
+		left join browsers    on browsers.browser_id       = user_agents.browser_id
+		left join systems     on systems.system_id         = user_agents.system_id
+		join      paths       on paths.path_id             = hits.path_id
+		left join locations   on locations.iso_3166_2      = hits.location
+		where
+			hits.site_id = :site and hits.bot = 0 and
+			hits.created_at >= :filter_start and hits.created_at <= :filter_end and
+			{{where}}
+	),
+	hit_counts as (
+		select site_id, path_id, hour, count(*) as total, sum(first_visit) as total_unique
+		from hit_filter group by site_id, path_id, hour
+	),
+	ref_counts as (
+		select site_id, path_id, ref, ref_scheme, channel, hour, count(*) as total, sum(first_visit) as total_unique
+		from hit_filter group by site_id, path_id, ref, ref_scheme, channel, hour
+	),
+	hit_stats as (
+		select site_id, path_id, day,
+			` + filterHourly("1") + ` as stats,
+			` + filterHourly("first_visit") + ` as stats_unique
+		from hit_filter group by site_id, path_id, day
+	),
+	browser_stats as (
+		select site_id, path_id, browser_id, day, count(*) as count, sum(first_visit) as count_unique
+		from hit_filter where browser_id is not null group by site_id, path_id, browser_id, day
+	),
+	system_stats as (
+		select site_id, path_id, system_id, day, count(*) as count, sum(first_visit) as count_unique
+		from hit_filter where system_id is not null group by site_id, path_id, system_id, day
+	),
+	location_stats as (
+		select site_id, path_id, location, day, count(*) as count, sum(first_visit) as count_unique
+		from hit_filter group by site_id, path_id, location, day
+	),
+	size_stats as (
+		select site_id, path_id, width, day, count(*) as count, sum(first_visit) as count_unique
+		from hit_filter group by site_id, path_id, width, day
+	),
+	visitor_stats as (
+		select site_id, path_id, day, sum(first_visit) as count_new, sum(1 - first_visit) as count_returning
+		from hit_filter group by site_id, path_id, day
+	)`
+
+// filterHourly builds the JSON array with the sum of col for every hour of the
+// day, as stored in hit_stats.
+func filterHourly(col string) string {
+	var b strings.Builder
+	b.WriteString(`'['`)
+	for h := 0; h < 24; h++ {
+		if h > 0 {
+			b.WriteString(` || ','`)
+		}
+		fmt.Fprintf(&b, ` || cast(sum(case when hour_of_day = %d then %s else 0 end) as varchar)`, h, col)
+	}
+	b.WriteString(` || ']'`)
+	return b.String()
+}
+
+// filterTime scans the hour and day columns of the stats tables; with SQLite
+// these are read as a string rather than a time.Time if they're calculated in
+// filterCTE.
+type filterTime struct{ time.Time }
+
+func (t *filterTime) Scan(v interface{}) error {
+	switch vv := v.(type) {
+	case time.Time:
+		t.Time = vv
+		return nil
+	case []byte:
+		v = string(vv)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("filterTime.Scan: unsupported type: %T", v)
+	}
+
+	var err error
+	if len(s) == 10 {
+		t.Time, err = time.Parse("2006-01-02", s)
+	} else {
+		t.Time, err = time.Parse("2006-01-02 15:04:05", s)
+	}
+	return errors.Wrap(err, "filterTime.Scan")
+}
+
+var reWith = regexp.MustCompile(`(?is)^\s*(/\*.*?\*/)?\s*with\s`)
+
+// query gets the query and parameters to run for this filter.
+//
+// This returns the query unmodified if there's nothing to filter in the hits
+// table. Otherwise the stats tables (hit_counts, ref_counts, hit_stats,
+// *_stats) are replaced by a CTE with the same name which reads from the hits
+// table, so the same queries can be used for both.
+//
+// The start and end are the range of hits to read; the query should still
+// filter on the exact range.
+func (f Filter) query(ctx context.Context, query string, params zdb.P, start, end time.Time) (string, zdb.P, error) {
+	if !f.raw() {
+		return query, params, nil
+	}
+
+	if strings.HasPrefix(query, "load:") {
+		q, err := DB.ReadFile("db/query/" + strings.TrimSuffix(query[5:], ".sql") + ".sql")
+		if err != nil {
+			return "", nil, errors.Wrap(err, "Filter.query")
+		}
+		query = string(q)
+	}
+
+	p := make(zdb.P, len(f.params)+len(params)+5)
+	for k, v := range f.params {
+		p[k] = v
+	}
+	for k, v := range params {
+		p[k] = v
+	}
+	p["site"] = MustGetSite(ctx).ID
+	// The stats tables are stored per day in UTC, so add some margin.
+	p["filter_start"] = start.Add(-24 * time.Hour)
+	p["filter_end"] = end.Add(24 * time.Hour)
+	p["sqlite"] = zdb.Driver(ctx) == zdb.DriverSQLite
+	p["pgsql"] = zdb.Driver(ctx) == zdb.DriverPostgreSQL
+
+	cte := strings.Replace(filterCTE, "{{where}}", f.where, 1)
+	if loc := reWith.FindStringSubmatchIndex(query); loc != nil {
+		return query[:loc[1]] + cte + ",\n" + query[loc[1]:], p, nil
+	}
+	return "with " + cte + "\n" + query, p, nil
+}
+
+// sel runs zdb.Select() with the filter applied.
+func (f Filter) sel(ctx context.Context, dest interface{}, start, end time.Time, query string, params zdb.P) error {
+	query, params, err := f.query(ctx, query, params, start, end)
+	if err != nil {
+		return err
+	}
+	return zdb.Select(ctx, dest, query, params)
+}
+
+// get runs zdb.Get() with the filter applied.
+func (f Filter) get(ctx context.Context, dest interface{}, start, end time.Time, query string, params zdb.P) error {
+	query, params, err := f.query(ctx, query, params, start, end)
+	if err != nil {
+		return err
+	}
+	return zdb.Get(ctx, dest, query, params)
+}
@@ -0,0 +1,345 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package filter parses filter expressions for the dashboard.
+//
+// The syntax is a list of terms, optionally prefixed with a key:
+//
+//   /blog/*                    Match /blog/ and everything below it.
+//   path:/blog/* ref:google    Terms are combined with AND.
+//   browser:"Chrome Mobile"    Quote values with spaces.
+//   NOT ref:google             Negate a term.
+//   (a OR b) AND NOT c         Group with parenthesis.
+//
+// The operators must be in upper case. A value matches as a substring, unless
+// it contains a *, in which case it must match the entire value.
+//
+// Keys are only recognized if they're in the list passed to Parse(); "foo:bar"
+// is just the value "foo:bar" if "foo" isn't a known key.
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Expr is a parsed filter expression; this is one of And, Or, Not, or Term.
+	Expr interface {
+		String() string
+		expr()
+	}
+
+	And  struct{ Left, Right Expr }
+	Or   struct{ Left, Right Expr }
+	Not  struct{ Expr Expr }
+	Term struct {
+		Key   string // Empty if there is no key.
+		Value string
+		Pos   int // Position in the input, for errors.
+	}
+)
+
+func (And) expr()  {}
+func (Or) expr()   {}
+func (Not) expr()  {}
+func (Term) expr() {}
+
+func (e And) String() string { return "(" + e.Left.String() + " AND " + e.Right.String() + ")" }
+func (e Or) String() string  { return "(" + e.Left.String() + " OR " + e.Right.String() + ")" }
+func (e Not) String() string { return "NOT " + e.Expr.String() }
+func (e Term) String() string {
+	v := e.Value
+	if v == "" || strings.ContainsAny(v, ` "()`) {
+		v = `"` + strings.ReplaceAll(v, `"`, `\"`) + `"`
+	}
+	if e.Key == "" {
+		return v
+	}
+	return e.Key + ":" + v
+}
+
+// Glob reports if this term has a * wildcard.
+func (e Term) Glob() bool { return strings.Contains(e.Value, "*") }
+
+// Error is a syntax error.
+type Error struct {
+	Pos int // Position of the error, as a character offset.
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("filter: %s at position %d", e.Msg, e.Pos+1)
+}
+
+// Keys gets a list of all unique keys in the expression, in the order they
+// appear. Terms without a key are reported as "".
+func Keys(e Expr) []string {
+	var (
+		keys []string
+		seen = make(map[string]struct{})
+		walk func(Expr)
+	)
+	walk = func(e Expr) {
+		switch ee := e.(type) {
+		case And:
+			walk(ee.Left)
+			walk(ee.Right)
+		case Or:
+			walk(ee.Left)
+			walk(ee.Right)
+		case Not:
+			walk(ee.Expr)
+		case Term:
+			if _, ok := seen[ee.Key]; !ok {
+				seen[ee.Key] = struct{}{}
+				keys = append(keys, ee.Key)
+			}
+		}
+	}
+	walk(e)
+	return keys
+}
+
+// Parse a filter expression; keys is the list of allowed keys.
+func Parse(s string, keys ...string) (Expr, error) {
+	toks, err := lex(s, keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 1 { // Only EOF.
+		return nil, &Error{Pos: 0, Msg: "empty filter"}
+	}
+
+	p := parser{toks: toks}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+	}
+	return e, nil
+}
+
+type tokKind uint8
+
+const (
+	tokEOF tokKind = iota
+	tokTerm
+	tokAnd
+	tokOr
+	tokNot
+	tokOpen
+	tokClose
+)
+
+type token struct {
+	kind       tokKind
+	key, value string
+	pos        int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokAnd:
+		return "AND"
+	case tokOr:
+		return "OR"
+	case tokNot:
+		return "NOT"
+	case tokOpen:
+		return `"("`
+	case tokClose:
+		return `")"`
+	default:
+		return fmt.Sprintf("%q", Term{Key: t.key, Value: t.value}.String())
+	}
+}
+
+func lex(s string, keys []string) ([]token, error) {
+	var (
+		toks []token
+		rs   = []rune(s)
+	)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokOpen, pos: i})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokClose, pos: i})
+			i++
+		case c == '"':
+			v, n, err := quoted(rs, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokTerm, value: v, pos: i})
+			i = n
+		default:
+			start := i
+			for i < len(rs) && !unicode.IsSpace(rs[i]) && rs[i] != '(' && rs[i] != ')' && rs[i] != '"' {
+				i++
+			}
+			word := string(rs[start:i])
+
+			switch word {
+			case "AND":
+				toks = append(toks, token{kind: tokAnd, pos: start})
+				continue
+			case "OR":
+				toks = append(toks, token{kind: tokOr, pos: start})
+				continue
+			case "NOT":
+				toks = append(toks, token{kind: tokNot, pos: start})
+				continue
+			}
+
+			t := token{kind: tokTerm, value: word, pos: start}
+			if k := strings.IndexByte(word, ':'); k > 0 && hasKey(keys, word[:k]) {
+				t.key, t.value = strings.ToLower(word[:k]), word[k+1:]
+				// key:"quoted value"
+				if t.value == "" && i < len(rs) && rs[i] == '"' {
+					v, n, err := quoted(rs, i)
+					if err != nil {
+						return nil, err
+					}
+					t.value, i = v, n
+				}
+				if t.value == "" {
+					return nil, &Error{Pos: i, Msg: fmt.Sprintf("no value for %q", t.key)}
+				}
+			} else if i < len(rs) && rs[i] == '"' {
+				// Quote in the middle of a word, such as foo"bar.
+				return nil, &Error{Pos: i, Msg: `unexpected '"'; quote the entire value`}
+			}
+			toks = append(toks, t)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(rs)}), nil
+}
+
+// Read a quoted string starting at rs[i]; returns the value and the position
+// after the closing quote.
+func quoted(rs []rune, i int) (string, int, error) {
+	var (
+		b     strings.Builder
+		start = i
+	)
+	for i++; i < len(rs); i++ {
+		switch rs[i] {
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+			}
+			b.WriteRune(rs[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(rs[i])
+		}
+	}
+	return "", 0, &Error{Pos: start, Msg: "unterminated quote"}
+}
+
+func hasKey(keys []string, k string) bool {
+	for _, kk := range keys {
+		if strings.EqualFold(k, kk) {
+			return true
+		}
+	}
+	return false
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// or = and { "OR" and }
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{left, right}
+	}
+	return left, nil
+}
+
+// and = not { ["AND"] not }
+func (p *parser) and() (Expr, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokEOF, tokOr, tokClose:
+			return left, nil
+		case tokAnd:
+			p.next()
+		}
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = And{left, right}
+	}
+}
+
+// not = "NOT" not | primary
+func (p *parser) not() (Expr, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		e, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return Not{e}, nil
+	}
+	return p.primary()
+}
+
+// primary = "(" or ")" | term
+func (p *parser) primary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokTerm:
+		return Term{Key: t.key, Value: t.value, Pos: t.pos}, nil
+	case tokOpen:
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokClose {
+			return nil, &Error{Pos: c.pos, Msg: fmt.Sprintf(`expected ")" but got %s`, c)}
+		}
+		return e, nil
+	default:
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("expected a value but got %s", t)}
+	}
+}
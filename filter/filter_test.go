@@ -0,0 +1,129 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`/foo`, `/foo`},
+		{`  /foo  `, `/foo`},
+		{`foo bar`, `(foo AND bar)`},
+		{`foo AND bar`, `(foo AND bar)`},
+		{`foo and bar`, `((foo AND and) AND bar)`},
+		{`foo OR bar baz`, `(foo OR (bar AND baz))`},
+		{`foo OR bar OR baz`, `((foo OR bar) OR baz)`},
+		{`NOT foo`, `NOT foo`},
+		{`NOT NOT foo`, `NOT NOT foo`},
+		{`NOT foo bar`, `(NOT foo AND bar)`},
+		{`NOT (foo OR bar)`, `NOT (foo OR bar)`},
+		{`(foo OR bar) baz`, `((foo OR bar) AND baz)`},
+		{`((foo))`, `foo`},
+		{`path:/blog/* AND NOT ref:google AND location:NL`, `((path:/blog/* AND NOT ref:google) AND location:NL)`},
+		{`PATH:/x`, `path:/x`},
+		{`browser:"Chrome Mobile"`, `browser:"Chrome Mobile"`},
+		{`"a \"b\" c"`, `"a \"b\" c"`},
+		{`unknown:x`, `unknown:x`},
+		{`/page:1`, `/page:1`},
+
+		{``, `filter: empty filter at position 1`},
+		{`foo (`, `filter: expected a value but got end of filter at position 6`},
+		{`(foo`, `filter: expected ")" but got end of filter at position 5`},
+		{`foo)`, `filter: unexpected ")" at position 4`},
+		{`foo AND`, `filter: expected a value but got end of filter at position 8`},
+		{`OR foo`, `filter: expected a value but got OR at position 1`},
+		{`"foo`, `filter: unterminated quote at position 1`},
+		{`ref:`, `filter: no value for "ref" at position 5`},
+		{`foo"bar"`, `filter: unexpected '"'; quote the entire value at position 4`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := Parse(tt.in, "path", "ref", "location", "browser")
+			var got string
+			if err != nil {
+				got = err.Error()
+			} else {
+				got = e.String()
+			}
+			if got != tt.want {
+				t.Errorf("\ngot:  %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	e, err := Parse(`a path:b OR (NOT ref:c path:d) e`, "path", "ref")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Keys(e)
+	want := []string{"", "path", "ref"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	term := func(p *Params, t Term) (string, error) {
+		switch t.Key {
+		case "":
+			return p.Like(t, "path", "title"), nil
+		case "ref":
+			return p.Like(t, "ref"), nil
+		case "size":
+			if t.Value != "10" {
+				return "", fmt.Errorf("invalid size %q", t.Value)
+			}
+			return "size = " + p.Add(10), nil
+		}
+		return "", fmt.Errorf("unexpected key %q", t.Key)
+	}
+
+	tests := []struct {
+		in, want string
+		params   map[string]interface{}
+	}{
+		{`/x`,
+			`((lower(coalesce(path, '')) like lower(:f1) escape '\' or lower(coalesce(title, '')) like lower(:f1) escape '\'))`,
+			map[string]interface{}{"f1": "%/x%"}},
+		{`NOT ref:a_b% OR size:10`,
+			`(not ((lower(coalesce(ref, '')) like lower(:f1) escape '\')) or (size = :f2))`,
+			map[string]interface{}{"f1": `%a\_b\%%`, "f2": 10}},
+		{`ref:/blog/* ref:x`,
+			`((lower(coalesce(ref, '')) like lower(:f1) escape '\') and (lower(coalesce(ref, '')) like lower(:f2) escape '\'))`,
+			map[string]interface{}{"f1": "/blog/%", "f2": "%x%"}},
+		{`ref:x size:5`,
+			`filter: invalid size "5" at position 7`,
+			nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := Parse(tt.in, "ref", "size")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, params, err := SQL(e, "f", term)
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("\ngot:  %s\nwant: %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("\ngot:  %#v\nwant: %#v", params, tt.params)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// TermFunc compiles a single term to SQL; use the Params to add parameters.
+type TermFunc func(p *Params, t Term) (string, error)
+
+// Params is a list of named parameters for the SQL query.
+type Params struct {
+	prefix string
+	params map[string]interface{}
+}
+
+// Add a new parameter, returning the placeholder (e.g. ":filter_1").
+func (p *Params) Add(v interface{}) string {
+	k := p.prefix + strconv.Itoa(len(p.params)+1)
+	p.params[k] = v
+	return ":" + k
+}
+
+// Like matches the term against one or more columns with "like".
+//
+// Multiple columns are combined with "or". NULL values are treated as an empty
+// string, so negation works as expected.
+func (p *Params) Like(t Term, columns ...string) string {
+	param := p.Add(Pattern(t.Value))
+	cond := make([]string, 0, len(columns))
+	for _, c := range columns {
+		cond = append(cond, `lower(coalesce(`+c+`, '')) like lower(`+param+`) escape '\'`)
+	}
+	if len(cond) == 1 {
+		return cond[0]
+	}
+	return "(" + strings.Join(cond, " or ") + ")"
+}
+
+// Pattern converts a value to a pattern for "like".
+//
+// This escapes any % and _ and replaces * with %; values without a * are
+// matched as a substring.
+func Pattern(v string) string {
+	v = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(v)
+	if !strings.Contains(v, "*") {
+		return "%" + v + "%"
+	}
+	return strings.ReplaceAll(v, "*", "%")
+}
+
+// SQL compiles the expression to a SQL condition with named parameters, which
+// can be used in a "where".
+//
+// The term function is called for every term. The parameters are named
+// ":prefix1", ":prefix2", etc.
+//
+// The SQL generated for the boolean operators works for both PostgreSQL and
+// SQLite; the term function is responsible for the SQL of the terms.
+func SQL(e Expr, prefix string, term TermFunc) (string, map[string]interface{}, error) {
+	p := &Params{prefix: prefix, params: make(map[string]interface{})}
+	sql, err := compile(e, p, term)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, p.params, nil
+}
+
+func compile(e Expr, p *Params, term TermFunc) (string, error) {
+	switch ee := e.(type) {
+	case And:
+		return compileOp(ee.Left, ee.Right, " and ", p, term)
+	case Or:
+		return compileOp(ee.Left, ee.Right, " or ", p, term)
+	case Not:
+		s, err := compile(ee.Expr, p, term)
+		if err != nil {
+			return "", err
+		}
+		return "not (" + s + ")", nil
+	case Term:
+		s, err := term(p, ee)
+		if err != nil {
+			var fErr *Error
+			if !errors.As(err, &fErr) {
+				err = &Error{Pos: ee.Pos, Msg: err.Error()}
+			}
+			return "", err
+		}
+		return "(" + s + ")", nil
+	default:
+		return "", &Error{Msg: "unknown expression"}
+	}
+}
+
+func compileOp(left, right Expr, op string, p *Params, term TermFunc) (string, error) {
+	l, err := compile(left, p, term)
+	if err != nil {
+		return "", err
+	}
+	r, err := compile(right, p, term)
+	if err != nil {
+		return "", err
+	}
+	return "(" + l + op + r + ")", nil
+}
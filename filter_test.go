@@ -0,0 +1,160 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/filter"
+	"zgo.at/goatcounter/gctest"
+)
+
+func TestFilter(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	var (
+		firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:81.0) Gecko/20100101 Firefox/81.0"
+		chrome  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/88.0.4324.150 Safari/537.36"
+	)
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/a", Ref: "example.com", Location: "NL-NB", Size: []float64{1920, 1080, 1}, UserAgentHeader: firefox, FirstVisit: true},
+		Hit{Path: "/a", Ref: "example.org", Location: "ID-BA", Size: []float64{800, 600, 2}, UserAgentHeader: chrome, FirstVisit: true},
+		Hit{Path: "/b", Ref: "example.com", Location: "ID-BA", Size: []float64{800, 600, 2}, UserAgentHeader: firefox},
+	)
+
+	start, end := Now().Add(-1*time.Hour), Now().Add(1*time.Hour)
+
+	tests := []struct {
+		filter, want string
+	}{
+		{"", "3 2 [/a /b] Chrome Firefox"},
+		{"/a", "2 2 [/a] Chrome Firefox"},
+		{"ref:example.com", "2 1 [/a /b] Firefox"},
+		{"b ref:example.com", "1 0 [/b] Firefox"},
+		{"path:/b", "1 0 [/b] Firefox"},
+		{"browser:chrome", "1 1 [/a] Chrome"},
+		{`browser:"firefox 81"`, "2 1 [/a /b] Firefox"},
+		{"location:nl", "1 1 [/a] Firefox"},
+		{"location:ID-BA", "2 1 [/a /b] Chrome Firefox"},
+		{"size:largephones", "2 1 [/a /b] Chrome Firefox"},
+		{"size:1920", "1 1 [/a] Firefox"},
+		{"/a OR /b", "3 2 [/a /b] Chrome Firefox"},
+		{"NOT /a", "1 0 [/b] Firefox"},
+		{"title:xxx", "0 0 [] "},
+		{"NOT ref:example.org", "2 1 [/a /b] Firefox"},
+		{"ref:example.org OR browser:firefox", "3 2 [/a /b] Chrome Firefox"},
+		{"path:/a AND NOT (browser:chrome OR location:NL)", "0 0 [] "},
+		{"location:ID* size:800", "2 1 [/a /b] Chrome Firefox"},
+		{"NOT campaign:x", "3 2 [/a /b] Chrome Firefox"},
+		{"visitor:new", "2 2 [/a] Chrome Firefox"},
+		{"visitor:returning", "1 0 [/b] Firefox"},
+		{"NOT visitor:new", "1 0 [/b] Firefox"},
+
+		{"size:xxx", "error"},
+		{"visitor:xxx", "error"},
+		{"(ref:x", "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := NewFilter(ctx, tt.filter)
+			if err != nil {
+				var fErr *filter.Error
+				if tt.want != "error" || !errors.As(err, &fErr) {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			tc, err := GetTotalCount(ctx, start, end, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pages HitLists
+			_, _, _, err = pages.List(ctx, start, end, f, nil, 0, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var browsers HitStats
+			err = browsers.ListBrowsers(ctx, start, end, f, 5, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			paths := make([]string, 0, len(pages))
+			for _, p := range pages {
+				paths = append(paths, p.Path)
+			}
+			got := fmt.Sprintf("%d %d %v", tc.Total, tc.TotalUnique, paths)
+			names := make([]string, 0, len(browsers.Stats))
+			for _, b := range browsers.Stats {
+				names = append(names, b.Name)
+			}
+			sort.Strings(names)
+			got += " " + strings.Join(names, " ")
+			if got != tt.want {
+				t.Errorf("\ngot:  %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Filters on the hits table should give the same results as the stats tables.
+func TestFilterStats(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	hits := []Hit{{Path: "/b"}}
+	for i := 0; i < 12; i++ {
+		hits = append(hits, Hit{Path: "/a", FirstVisit: i%4 == 0})
+	}
+	gctest.StoreHits(ctx, t, false, hits...)
+	start, end := Now().Add(-1*time.Hour), Now().Add(1*time.Hour)
+
+	stats := func(text string) string {
+		f, err := NewFilter(ctx, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var pages HitLists
+		_, _, _, err = pages.List(ctx, start, end, f, nil, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var total HitList
+		_, err = total.Totals(ctx, start, end, f, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		max, err := GetMax(ctx, start, end, f, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hm, err := GetHeatmap(ctx, start, end, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := fmt.Sprintf("total: %d %d; max: %d; heatmap: %d %d", total.Count, total.CountUnique, max, hm.Max, hm.MaxUnique)
+		for _, p := range pages {
+			s += fmt.Sprintf("; %s: %d %d %v", p.Path, p.Count, p.CountUnique, p.Stats)
+		}
+		return s
+	}
+
+	want := stats("")
+	if !strings.Contains(want, "total: 13 3; max: 12; heatmap: 13 3; /a: 12 3") {
+		t.Fatalf("wrong stats: %s", want)
+	}
+	if got := stats("NOT campaign:x"); got != want {
+		t.Errorf("\ngot:  %s\nwant: %s", got, want)
+	}
+}
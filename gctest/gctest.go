@@ -108,8 +108,8 @@ func initData(ctx context.Context, db zdb.DB, t testing.TB) context.Context {
 	}
 	ctx = goatcounter.WithSite(ctx, &site)
 
-	user := goatcounter.User{Site: site.ID, Email: "test@gctest.localhost", Password: []byte("coconuts")}
-	err = user.Insert(ctx)
+	user := goatcounter.User{Site: site.ID, Email: "test@gctest.localhost", Password: []byte("coconuts"), Access: goatcounter.AccessOwner}
+	err = user.Insert(ctx, false)
 	if err != nil {
 		t.Fatalf("create user: %s", err)
 	}
@@ -144,13 +144,13 @@ func StoreHits(ctx context.Context, t *testing.T, wantFail bool, hits ...goatcou
 		t.Fatal("gc.StoreHits: no error while wantError is true")
 	}
 
-	sites := make(map[int64]struct{})
+	sites := make(map[int64][]goatcounter.Hit)
 	for _, h := range hits {
-		sites[h.Site] = struct{}{}
+		sites[h.Site] = append(sites[h.Site], h)
 	}
 
-	for s := range sites {
-		err = cron.UpdateStats(ctx, nil, s, hits, false)
+	for s, h := range sites {
+		err = cron.UpdateStats(ctx, nil, s, h, false)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -180,8 +180,9 @@ func Site(ctx context.Context, t *testing.T, site goatcounter.Site) (context.Con
 		Site:     site.ID,
 		Email:    "test@example.com",
 		Password: []byte("coconuts"),
+		Access:   goatcounter.AccessOwner,
 	}
-	err = user.Insert(ctx)
+	err = user.Insert(ctx, false)
 	if err != nil {
 		t.Fatalf("get/create user: %s", err)
 	}
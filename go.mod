@@ -14,7 +14,6 @@ replace github.com/oschwald/maxminddb-golang => github.com/zgoat/maxminddb-golan
 replace github.com/oschwald/geoip2-golang => github.com/zgoat/geoip2-golang v1.4.1-0.20201227124715-9eb17ed0da06
 
 require (
-	code.soquee.net/otp v0.0.1
 	github.com/PuerkitoBio/goquery v1.6.1
 	github.com/bmatcuk/doublestar/v3 v3.0.0
 	github.com/boombuler/barcode v1.0.1
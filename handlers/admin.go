@@ -19,7 +19,6 @@ import (
 	"zgo.at/guru"
 	"zgo.at/zdb"
 	"zgo.at/zhttp"
-	"zgo.at/zhttp/auth"
 	"zgo.at/zhttp/mware"
 	"zgo.at/zlog"
 	"zgo.at/zstd/znet"
@@ -244,9 +243,13 @@ func (h admin) login(w http.ResponseWriter, r *http.Request) error {
 	if !site.Settings.AllowAdmin {
 		return guru.New(403, "AllowAdmin not enabled")
 	}
+	audit(r, &site, "admin.login", nil)
 
+	err = setLogin(w, r, &user, &site)
+	if err != nil {
+		return err
+	}
 	domain := cookieDomain(&site, r)
-	auth.SetCookie(w, *user.LoginToken, domain)
 	http.SetCookie(w, &http.Cookie{
 		Domain:   znet.RemovePort(domain),
 		Name:     "is_admin",
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,7 +21,9 @@ import (
 	"zgo.at/errors"
 	"zgo.at/goatcounter"
 	"zgo.at/goatcounter/bgrun"
+	"zgo.at/goatcounter/filter"
 	"zgo.at/guru"
+	"zgo.at/zcache"
 	"zgo.at/zdb"
 	"zgo.at/zhttp"
 	"zgo.at/zhttp/header"
@@ -44,11 +48,14 @@ const respOK = `{"status":"ok"}`
 type api struct{}
 
 func (h api) mount(r chi.Router, db zdb.DB) {
+	store := newAPIRatelimitStore()
 	a := r.With(
 		middleware.AllowContentType("application/json"),
+		apiLoadToken,
+		apiRatelimitHeaders(store),
 		mware.Ratelimit(mware.RatelimitOptions{
-			Client: mware.RatelimitIP,
-			Store:  mware.NewRatelimitMemory(),
+			Client: apiRatelimitKey,
+			Store:  store,
 			Limit: func(r *http.Request) (int, int64) {
 				// Up batch size for imports; otherwize 500k requests takes
 				// about 35 minutes, most of which is waiting for the rate
@@ -62,7 +69,14 @@ func (h api) mount(r chi.Router, db zdb.DB) {
 				if r.URL.Path == "/api/v0/count" && r.Header.Get("X-Goatcounter-Import") != "" {
 					return 4, 1
 				}
-				return 60, 120
+				group := apiRatelimitGroup(r)
+				if t := goatcounter.GetAPIToken(r.Context()); t != nil {
+					if l, ok := t.Ratelimits[group]; ok {
+						return l.Limit, l.Period
+					}
+				}
+				l := goatcounter.Config(r.Context()).APIRatelimits.Get(group)
+				return l.Limit, l.Period
 			},
 		}))
 
@@ -77,6 +91,14 @@ func (h api) mount(r chi.Router, db zdb.DB) {
 
 	a.Post("/api/v0/count", zhttp.Wrap(h.count))
 
+	a.Post("/api/v0/annotations", zhttp.Wrap(h.annotationCreate))
+
+	a.Get("/api/v0/stats/heatmap", zhttp.Wrap(h.statsHeatmap))
+
+	a.Get("/api/v0/audit", zhttp.Wrap(h.auditList))
+
+	a.Get("/api/v0/users", zhttp.Wrap(h.userList))
+
 	// Note: DELETE not supported for sites and users intentionally, since it's
 	// such a dangerous operation.
 	a.Get("/api/v0/sites", zhttp.Wrap(h.siteList))
@@ -84,6 +106,11 @@ func (h api) mount(r chi.Router, db zdb.DB) {
 	a.Get("/api/v0/sites/{id}", zhttp.Wrap(h.siteGet))
 	a.Post("/api/v0/sites/{id}", zhttp.Wrap(h.siteUpdate))  // Update all
 	a.Patch("/api/v0/sites/{id}", zhttp.Wrap(h.siteUpdate)) // Update just fields given
+
+	a.Get("/api/v0/sites/{id}/views", zhttp.Wrap(h.viewList))
+	a.Get("/api/v0/sites/{id}/views/{name}", zhttp.Wrap(h.viewGet))
+	a.Put("/api/v0/sites/{id}/views/{name}", zhttp.Wrap(h.viewUpdate))
+	a.Delete("/api/v0/sites/{id}/views/{name}", zhttp.Wrap(h.viewDelete))
 }
 
 func tokenFromHeader(r *http.Request) (string, error) {
@@ -98,12 +125,263 @@ func tokenFromHeader(r *http.Request) (string, error) {
 	return b[1], nil
 }
 
+// apiRatelimitGroup gets the rate limit group for this request.
+func apiRatelimitGroup(r *http.Request) string {
+	switch p := r.URL.Path; {
+	case p == "/api/v0/count":
+		return "count"
+	case p == "/api/v0/export" && r.Method == http.MethodPost:
+		return "export"
+	case strings.HasPrefix(p, "/api/v0/sites") && r.Method != http.MethodGet:
+		return "sites"
+	default:
+		return "default"
+	}
+}
+
+// Number of lookups for unknown tokens by IP address in the last minute.
+var (
+	apiUnknownTokens     = zcache.New(time.Minute, time.Minute)
+	apiUnknownTokenLimit = 20
+)
+
+// apiLoadToken loads the API token from the Authorization header, if it
+// exists, so that the rate limit can be applied per token. The token is
+// validated in api.auth().
+//
+// Clients that send too many unknown tokens are rate limited by IP before
+// looking up the token, so that they can't keep hitting the database.
+func apiLoadToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, err := tokenFromHeader(r); err == nil {
+			if n, exp, ok := apiUnknownTokens.GetWithExpiration(r.RemoteAddr); ok && n.(int) >= apiUnknownTokenLimit {
+				w.Header().Set("Retry-After", apiSeconds(time.Until(exp)))
+				zhttp.ErrPage(w, r, guru.New(http.StatusTooManyRequests, "too many requests with an unknown token"))
+				return
+			}
+
+			var token goatcounter.APIToken
+			err := token.ByToken(r.Context(), key)
+			if err != nil && !zdb.ErrNoRows(err) {
+				zhttp.ErrPage(w, r, err)
+				return
+			}
+			if err != nil {
+				if _, err := apiUnknownTokens.IncrementInt(r.RemoteAddr, 1); err != nil {
+					apiUnknownTokens.SetDefault(r.RemoteAddr, 1)
+				}
+			} else {
+				r = r.WithContext(goatcounter.WithAPIToken(r.Context(), &token))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiRatelimitKey gets the key to rate limit on: every token gets its own
+// limit for every group, and requests without a known token are limited by IP.
+func apiRatelimitKey(r *http.Request) string {
+	k := "ip:" + r.RemoteAddr
+	if t := goatcounter.GetAPIToken(r.Context()); t != nil {
+		k = "token:" + strconv.FormatInt(t.ID, 10)
+	}
+	return k + ":" + apiRatelimitGroup(r)
+}
+
+// apiRatelimitStore stores the number of requests in a fixed window for every
+// key, so that the time until the limit is reset can be reported.
+//
+// mware.RatelimitMemory uses a sliding window, which doesn't have a reset time.
+type apiRatelimitStore struct {
+	sync.Mutex
+	windows   map[string]apiRatelimitWindow
+	lastPrune time.Time
+}
+
+type apiRatelimitWindow struct {
+	n     int
+	reset time.Time
+}
+
+func newAPIRatelimitStore() *apiRatelimitStore {
+	return &apiRatelimitStore{windows: make(map[string]apiRatelimitWindow)}
+}
+
+func (s *apiRatelimitStore) Grant(key string, n int, period int64) (bool, int) {
+	now := goatcounter.Now()
+
+	s.Lock()
+	defer s.Unlock()
+	if now.Sub(s.lastPrune) > time.Minute {
+		for k, w := range s.windows {
+			if !w.reset.After(now) {
+				delete(s.windows, k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	w := s.windows[key]
+	if !w.reset.After(now) {
+		w = apiRatelimitWindow{reset: now.Add(time.Duration(period) * time.Second)}
+	}
+	w.n++
+	s.windows[key] = w
+	return n >= w.n, n - w.n
+}
+
+// Reset gets the time the window for key is reset.
+func (s *apiRatelimitStore) Reset(key string) time.Time {
+	s.Lock()
+	defer s.Unlock()
+	return s.windows[key].reset
+}
+
+// apiSeconds formats d as whole seconds, rounded up.
+func apiSeconds(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+type (
+	// apiRatelimitUsage is the rate limit usage for a group.
+	apiRatelimitUsage struct {
+		Group     string
+		Limit     int
+		Remaining int
+		Reset     time.Time
+	}
+	apiRatelimitWriter struct {
+		http.ResponseWriter
+		r           *http.Request
+		store       *apiRatelimitStore
+		wroteHeader bool
+	}
+)
+
+// Rate limit usage by token ID and group, for display on the settings page.
+var apiUsage = struct {
+	sync.Mutex
+	m map[int64]map[string]apiRatelimitUsage
+}{m: make(map[int64]map[string]apiRatelimitUsage)}
+
+// apiTokenUsage gets the rate limit usage for a token, sorted by group. Groups
+// for which the period has expired are omitted.
+func apiTokenUsage(tokenID int64) []apiRatelimitUsage {
+	apiUsage.Lock()
+	defer apiUsage.Unlock()
+
+	now := goatcounter.Now()
+	u := make([]apiRatelimitUsage, 0, len(apiUsage.m[tokenID]))
+	for _, g := range apiUsage.m[tokenID] {
+		if g.Reset.After(now) {
+			u = append(u, g)
+		}
+	}
+	sort.Slice(u, func(i, j int) bool { return u[i].Group < u[j].Group })
+	return u
+}
+
+// Remove usage for which the period has expired; apiUsage must be locked.
+func apiUsagePrune(now time.Time) {
+	for id, groups := range apiUsage.m {
+		for g, u := range groups {
+			if !u.Reset.After(now) {
+				delete(groups, g)
+			}
+		}
+		if len(groups) == 0 {
+			delete(apiUsage.m, id)
+		}
+	}
+}
+
+// Used returns the number of requests used this period.
+func (u apiRatelimitUsage) Used() int { return u.Limit - u.Remaining }
+
+func (w *apiRatelimitWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.setHeaders(code)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *apiRatelimitWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// setHeaders copies the rate limit headers set by mware.Ratelimit to the
+// standard X-RateLimit-* names, sets Retry-After if the request was rate
+// limited, and records the usage for the token.
+//
+// The X-Rate-Limit-Reset header from mware.Ratelimit is always the full period,
+// so the reset time is taken from the store instead.
+func (w *apiRatelimitWriter) setHeaders(code int) {
+	h := w.Header()
+	limit, remaining := h.Get("X-Rate-Limit-Limit"), h.Get("X-Rate-Limit-Remaining")
+	if limit == "" {
+		return
+	}
+	now := goatcounter.Now()
+	resetAt := w.store.Reset(apiRatelimitKey(w.r))
+	reset := apiSeconds(resetAt.Sub(now))
+
+	// mware.Ratelimit goes below 0 for requests that are rate limited.
+	rem, _ := strconv.Atoi(remaining)
+	if rem < 0 {
+		rem, remaining = 0, "0"
+	}
+
+	h.Set("X-RateLimit-Limit", limit)
+	h.Set("X-RateLimit-Remaining", remaining)
+	h.Set("X-RateLimit-Reset", reset)
+	if code == http.StatusTooManyRequests {
+		h.Set("Retry-After", reset)
+	}
+
+	token := goatcounter.GetAPIToken(w.r.Context())
+	if token == nil {
+		return
+	}
+	l, _ := strconv.Atoi(limit)
+	group := apiRatelimitGroup(w.r)
+
+	apiUsage.Lock()
+	defer apiUsage.Unlock()
+	apiUsagePrune(now)
+	if apiUsage.m[token.ID] == nil {
+		apiUsage.m[token.ID] = make(map[string]apiRatelimitUsage)
+	}
+	apiUsage.m[token.ID][group] = apiRatelimitUsage{
+		Group:     group,
+		Limit:     l,
+		Remaining: rem,
+		Reset:     resetAt,
+	}
+}
+
+// apiRatelimitHeaders sets the standard rate limit headers; this needs to be
+// added before mware.Ratelimit.
+func apiRatelimitHeaders(store *apiRatelimitStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&apiRatelimitWriter{ResponseWriter: w, r: r, store: store}, r)
+		})
+	}
+}
+
 var (
 	bufferKeyOnce sync.Once
 	bufferKey     []byte
 )
 
-func (h api) auth(r *http.Request, perm goatcounter.APITokenPermissions) error {
+func (h api) auth(r *http.Request, perm goatcounter.APIPermissions) error {
 	key, err := tokenFromHeader(r)
 	if err != nil {
 		return err
@@ -123,35 +401,53 @@ func (h api) auth(r *http.Request, perm goatcounter.APITokenPermissions) error {
 		return nil
 	}
 
-	// Regular API token.
-	var token goatcounter.APIToken
-	err = token.ByToken(r.Context(), key)
-	if zdb.ErrNoRows(err) {
+	// Regular API token; this is loaded in apiLoadToken.
+	token := goatcounter.GetAPIToken(r.Context())
+	if token == nil || token.Token != key {
 		return guru.New(http.StatusForbidden, "unknown token")
 	}
-	if err != nil {
-		return err
+	if token.Expired() {
+		return guru.New(http.StatusForbidden, "token has expired")
+	}
+	if !token.AllowIP(r.RemoteAddr) {
+		return guru.New(http.StatusForbidden, "token can't be used from this IP address")
 	}
 
 	var user goatcounter.User
-	err = user.BySite(r.Context(), token.SiteID)
+	err = user.ByID(r.Context(), token.UserID)
 	if err != nil {
 		return err
 	}
 
 	*r = *r.WithContext(goatcounter.WithUser(r.Context(), &user))
 
-	var need []string
-	if perm.Count && !token.Permissions.Count {
-		need = append(need, "count")
+	// Tokens can't do more than the user who created them.
+	access := goatcounter.AccessReadOnly
+	switch {
+	case perm&(goatcounter.APIPermSiteCreate|goatcounter.APIPermSiteUpdate|goatcounter.APIPermAudit|goatcounter.APIPermUsers) != 0:
+		access = goatcounter.AccessAdmin
+	case perm&(goatcounter.APIPermExport|goatcounter.APIPermAnnotations) != 0:
+		access = goatcounter.AccessSettings
 	}
-	if perm.Export && !token.Permissions.Export {
-		need = append(need, "export")
+	if !user.HasAccess(access) {
+		return guru.Errorf(http.StatusForbidden, "user %q needs %q access", user.Email, access.Label())
 	}
-	if len(need) > 0 {
+
+	if need := token.Permissions.Missing(perm); len(need) > 0 {
 		return guru.Errorf(http.StatusForbidden, "requires %s permissions", need)
 	}
 
+	// These work on the site the request is made for; the sites endpoints
+	// check this in siteFind.
+	if perm&(goatcounter.APIPermCount|goatcounter.APIPermExport|goatcounter.APIPermAnnotations|goatcounter.APIPermStats) != 0 &&
+		!token.AllowSite(Site(r.Context()).ID) {
+		return guru.New(http.StatusForbidden, "token can't be used for this site")
+	}
+
+	err = token.UpdateLastUsed(r.Context(), r.RemoteAddr)
+	if err != nil {
+		zlog.FieldsRequest(r).Error(err)
+	}
 	return nil
 }
 
@@ -163,11 +459,11 @@ type apiExportRequest struct {
 // For testing various generic properties about the API.
 func (h api) test(w http.ResponseWriter, r *http.Request) error {
 	var args struct {
-		Perm     goatcounter.APITokenPermissions `json:"perm"`
-		Status   int                             `json:"status"`
-		Panic    bool                            `json:"panic"`
-		Validate zvalidate.Validator             `json:"validate"`
-		Context  bool                            `json:"context"`
+		Perm     goatcounter.APIPermissions `json:"perm"`
+		Status   int                        `json:"status"`
+		Panic    bool                       `json:"panic"`
+		Validate zvalidate.Validator        `json:"validate"`
+		Context  bool                       `json:"context"`
 	}
 
 	_, err := zhttp.Decode(r, &args)
@@ -218,7 +514,7 @@ type meResponse struct {
 //
 // Response 200: meResponse
 func (h api) me(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{})
+	err := h.auth(r, 0)
 	if err != nil {
 		return err
 	}
@@ -245,9 +541,7 @@ func (h api) me(w http.ResponseWriter, r *http.Request) error {
 // Request body: apiExportRequest
 // Response 202: zgo.at/goatcounter.Export
 func (h api) export(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		Export: true,
-	})
+	err := h.auth(r, goatcounter.APIPermExport)
 	if err != nil {
 		return err
 	}
@@ -276,9 +570,7 @@ func (h api) export(w http.ResponseWriter, r *http.Request) error {
 //
 // Response 200: zgo.at/goatcounter.Export
 func (h api) exportGet(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		Export: true,
-	})
+	err := h.auth(r, goatcounter.APIPermExport)
 	if err != nil {
 		return err
 	}
@@ -311,9 +603,7 @@ func (h api) exportGet(w http.ResponseWriter, r *http.Request) error {
 // Response 202: zgo.at/goatcounter/handlers.apiError
 // Response 400: zgo.at/goatcounter/handlers.apiError
 func (h api) exportDownload(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		Export: true,
-	})
+	err := h.auth(r, goatcounter.APIPermExport)
 	if err != nil {
 		return err
 	}
@@ -445,9 +735,7 @@ func (h APICountRequestHit) String() string {
 // Request body: APICountRequest
 // Response 202: {empty}
 func (h api) count(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		Count: true,
-	})
+	err := h.auth(r, goatcounter.APIPermCount)
 	if err != nil {
 		return err
 	}
@@ -539,6 +827,195 @@ func (h api) count(w http.ResponseWriter, r *http.Request) error {
 	return zhttp.JSON(w, respOK)
 }
 
+// POST /api/v0/annotations annotations
+// Add an annotation.
+//
+// Annotations are displayed on the charts; this is useful for marking deploys
+// from CI and the like.
+//
+// Request body: zgo.at/goatcounter.Annotation
+// Response 200: zgo.at/goatcounter.Annotation
+func (h api) annotationCreate(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermAnnotations)
+	if err != nil {
+		return err
+	}
+
+	var a goatcounter.Annotation
+	_, err = zhttp.Decode(r, &a)
+	if err != nil {
+		return err
+	}
+	a.ID = 0
+
+	err = a.Insert(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "annotation.create", goatcounter.AuditData{"id": a.ID, "path": a.Path, "text": a.Text})
+
+	return zhttp.JSON(w, a)
+}
+
+type apiStatsRequest struct {
+	// Start of the date range as a date ("2020-06-18") or RFC 3339 time;
+	// defaults to 7 days ago.
+	Start string `json:"start"`
+
+	// End of the date range as a date ("2020-06-18") or RFC 3339 time; a date
+	// includes the entire day. Defaults to now.
+	End string `json:"end"`
+
+	// Filter, using the same syntax as the dashboard; for example "path:/docs/*".
+	Filter string `json:"filter"`
+}
+
+// Get the start, end, and filter from the request.
+func (h api) statsArgs(r *http.Request) (time.Time, time.Time, goatcounter.Filter, error) {
+	var args apiStatsRequest
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return time.Time{}, time.Time{}, goatcounter.Filter{}, err
+	}
+
+	end := goatcounter.Now()
+	if args.End != "" {
+		end, err = parseStatsTime(args.End, true)
+		if err != nil {
+			return time.Time{}, time.Time{}, goatcounter.Filter{}, guru.Errorf(400, "end: %w", err)
+		}
+	}
+	start := end.Add(-7 * day)
+	if args.Start != "" {
+		start, err = parseStatsTime(args.Start, false)
+		if err != nil {
+			return time.Time{}, time.Time{}, goatcounter.Filter{}, guru.Errorf(400, "start: %w", err)
+		}
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, goatcounter.Filter{}, guru.New(400, "start is after end")
+	}
+
+	f, err := goatcounter.NewFilter(r.Context(), args.Filter)
+	var fErr *filter.Error
+	if errors.As(err, &fErr) {
+		return time.Time{}, time.Time{}, goatcounter.Filter{}, guru.New(400, fErr.Error())
+	}
+	return start.UTC(), end.UTC(), f, err
+}
+
+// Parse a date or RFC 3339 time; a date for the end of the range is the last
+// second of that day.
+func parseStatsTime(v string, isEnd bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date or time %q", v)
+	}
+	if isEnd {
+		t = t.Add(day - time.Second)
+	}
+	return t, nil
+}
+
+// GET /api/v0/stats/heatmap stats
+// Get the pageviews per day of the week and hour of the day.
+//
+// This uses the site's timezone. The first index of the arrays is the day of
+// the week (0 is Sunday), the second the hour.
+//
+// Query: apiStatsRequest
+// Response 200: zgo.at/goatcounter.Heatmap
+func (h api) statsHeatmap(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermStats)
+	if err != nil {
+		return err
+	}
+
+	start, end, f, err := h.statsArgs(r)
+	if err != nil {
+		return err
+	}
+
+	hm, err := goatcounter.GetHeatmap(r.Context(), start, end, f)
+	if err != nil {
+		return err
+	}
+	return zhttp.JSON(w, hm)
+}
+
+type apiAuditRequest struct {
+	// Only list events with an ID lower than this; for pagination.
+	Before int64 `json:"before"`
+
+	// Maximum number of events to return; the default and maximum is 100.
+	Limit int `json:"limit"`
+}
+
+type apiAuditResponse struct {
+	Events goatcounter.AuditLog `json:"events"`
+
+	// More events are available; use the ID of the last event as "before" to
+	// get them.
+	More bool `json:"more"`
+}
+
+// GET /api/v0/audit audit
+// List the audit log.
+//
+// This lists changes to the settings, users, API tokens, etc. for all sites in
+// this account, newest first.
+//
+// Query: apiAuditRequest
+// Response 200: apiAuditResponse
+func (h api) auditList(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermAudit)
+	if err != nil {
+		return err
+	}
+
+	var args apiAuditRequest
+	_, err = zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+	if args.Limit <= 0 || args.Limit > 100 {
+		args.Limit = 100
+	}
+
+	var l goatcounter.AuditLog
+	more, err := l.List(r.Context(), args.Before, args.Limit)
+	if err != nil {
+		return err
+	}
+	return zhttp.JSON(w, apiAuditResponse{l, more})
+}
+
+type apiUsersResponse struct {
+	Users goatcounter.Users `json:"users"`
+}
+
+// GET /api/v0/users users
+// List all users.
+//
+// Response 200: apiUsersResponse
+func (h api) userList(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermUsers)
+	if err != nil {
+		return err
+	}
+
+	var users goatcounter.Users
+	err = users.List(r.Context())
+	if err != nil {
+		return err
+	}
+	return zhttp.JSON(w, apiUsersResponse{users})
+}
+
 type apiSitesResponse struct {
 	Sites goatcounter.Sites `json:"sites"`
 }
@@ -548,9 +1025,7 @@ type apiSitesResponse struct {
 //
 // Response 200: apiSitesResponse
 func (h api) siteList(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		SiteRead: true,
-	})
+	err := h.auth(r, goatcounter.APIPermSiteRead)
 	if err != nil {
 		return err
 	}
@@ -561,7 +1036,15 @@ func (h api) siteList(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	return zhttp.JSON(w, apiSitesResponse{sites})
+	token := goatcounter.GetAPIToken(r.Context())
+	allowed := make(goatcounter.Sites, 0, len(sites))
+	for _, s := range sites {
+		if token == nil || token.AllowSite(s.ID) {
+			allowed = append(allowed, s)
+		}
+	}
+
+	return zhttp.JSON(w, apiSitesResponse{allowed})
 }
 
 func (h api) siteFind(r *http.Request) (*goatcounter.Site, error) {
@@ -581,6 +1064,9 @@ func (h api) siteFind(r *http.Request) (*goatcounter.Site, error) {
 	if !(site.ID == siteID || (site.Parent != nil && *site.Parent == siteID)) {
 		return nil, guru.New(404, "")
 	}
+	if t := goatcounter.GetAPIToken(r.Context()); t != nil && !t.AllowSite(site.ID) {
+		return nil, guru.Errorf(http.StatusForbidden, "token can't be used for site %d", site.ID)
+	}
 
 	return &site, nil
 }
@@ -592,9 +1078,7 @@ func (h api) siteFind(r *http.Request) (*goatcounter.Site, error) {
 //
 // Response 200: goatcounter.Site
 func (h api) siteGet(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		SiteRead: true,
-	})
+	err := h.auth(r, goatcounter.APIPermSiteRead)
 	if err != nil {
 		return err
 	}
@@ -612,9 +1096,7 @@ func (h api) siteGet(w http.ResponseWriter, r *http.Request) error {
 // Request body: goatcounter.Site
 // Response 200: goatcounter.Site
 func (h api) siteCreate(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		SiteCreate: true,
-	})
+	err := h.auth(r, goatcounter.APIPermSiteCreate)
 	if err != nil {
 		return err
 	}
@@ -631,6 +1113,7 @@ func (h api) siteCreate(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, &site, "site.create", nil)
 
 	return zhttp.JSON(w, site)
 }
@@ -652,9 +1135,7 @@ type apiSiteUpdateRequest struct {
 // Request body: apiSiteUpdateRequest
 // Response 200: goatcounter.Site
 func (h api) siteUpdate(w http.ResponseWriter, r *http.Request) error {
-	err := h.auth(r, goatcounter.APITokenPermissions{
-		SiteUpdate: true,
-	})
+	err := h.auth(r, goatcounter.APIPermSiteUpdate)
 	if err != nil {
 		return err
 	}
@@ -676,6 +1157,7 @@ func (h api) siteUpdate(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	before := auditSite(site)
 	site.LinkDomain = args.LinkDomain
 	site.Cname = args.Cname
 	site.Settings = args.Settings
@@ -683,6 +1165,129 @@ func (h api) siteUpdate(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	auditSettings(r, site, before)
 
 	return zhttp.JSON(w, site)
 }
+
+type apiViewsResponse struct {
+	Views goatcounter.Views `json:"views"`
+}
+
+// GET /api/v0/sites/{id}/views views
+// List all dashboard views for a site.
+//
+// Response 200: apiViewsResponse
+func (h api) viewList(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermSiteRead)
+	if err != nil {
+		return err
+	}
+
+	site, err := h.siteFind(r)
+	if err != nil {
+		return err
+	}
+	return zhttp.JSON(w, apiViewsResponse{site.Settings.Views})
+}
+
+// GET /api/v0/sites/{id}/views/{name} views
+// Get a dashboard view.
+//
+// Response 200: goatcounter.View
+func (h api) viewGet(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermSiteRead)
+	if err != nil {
+		return err
+	}
+
+	site, err := h.siteFind(r)
+	if err != nil {
+		return err
+	}
+	v, i := site.Settings.Views.Get(chi.URLParam(r, "name"))
+	if i == -1 {
+		return guru.New(404, "no such view")
+	}
+	return zhttp.JSON(w, v)
+}
+
+// PUT /api/v0/sites/{id}/views/{name} views
+// Create or replace a dashboard view.
+//
+// The name in the request body is ignored. The view will use the site's
+// widgets if widgets is empty.
+//
+// Request body: goatcounter.View
+// Response 200: goatcounter.View
+func (h api) viewUpdate(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermSiteUpdate)
+	if err != nil {
+		return err
+	}
+
+	site, err := h.siteFind(r)
+	if err != nil {
+		return err
+	}
+
+	var v goatcounter.View
+	_, err = zhttp.Decode(r, &v)
+	if err != nil {
+		return err
+	}
+
+	before := auditSite(site)
+	v.Name = chi.URLParam(r, "name")
+	if _, i := site.Settings.Views.Get(v.Name); i == -1 {
+		site.Settings.Views = append(site.Settings.Views, v)
+	} else {
+		site.Settings.Views[i] = v
+	}
+	err = site.Update(r.Context())
+	if err != nil {
+		return err
+	}
+	auditSettings(r, site, before)
+
+	v, _ = site.Settings.Views.Get(v.Name)
+	return zhttp.JSON(w, v)
+}
+
+// DELETE /api/v0/sites/{id}/views/{name} views
+// Remove a dashboard view.
+//
+// The default view can't be removed.
+//
+// Response 202: {empty}
+func (h api) viewDelete(w http.ResponseWriter, r *http.Request) error {
+	err := h.auth(r, goatcounter.APIPermSiteUpdate)
+	if err != nil {
+		return err
+	}
+
+	site, err := h.siteFind(r)
+	if err != nil {
+		return err
+	}
+
+	name := chi.URLParam(r, "name")
+	_, i := site.Settings.Views.Get(name)
+	if i == -1 {
+		return guru.New(404, "no such view")
+	}
+	if strings.EqualFold(name, "default") {
+		return guru.New(400, "can't remove the default view")
+	}
+
+	before := auditSite(site)
+	site.Settings.Views = append(site.Settings.Views[:i], site.Settings.Views[i+1:]...)
+	err = site.Update(r.Context())
+	if err != nil {
+		return err
+	}
+	auditSettings(r, site, before)
+
+	w.WriteHeader(http.StatusAccepted)
+	return zhttp.JSON(w, respOK)
+}
@@ -52,7 +52,7 @@ func jsonCmp(a, b string) bool {
 
 func newAPITest(ctx context.Context, t *testing.T,
 	method, path string, body io.Reader,
-	perm goatcounter.APITokenPermissions,
+	perm goatcounter.APIPermissions,
 ) (*http.Request, *httptest.ResponseRecorder) {
 
 	token := goatcounter.APIToken{
@@ -75,7 +75,7 @@ func TestAPIBasics(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		t.Run("no-auth", func(t *testing.T) {
 			ctx := gctest.DB(t)
-			r, rr := newAPITest(ctx, t, "GET", "/api/v0/test", nil, goatcounter.APITokenPermissions{})
+			r, rr := newAPITest(ctx, t, "GET", "/api/v0/test", nil, 0)
 
 			delete(r.Header, "Authorization")
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
@@ -89,7 +89,7 @@ func TestAPIBasics(t *testing.T) {
 
 		t.Run("wrong-auth", func(t *testing.T) {
 			ctx := gctest.DB(t)
-			r, rr := newAPITest(ctx, t, "GET", "/api/v0/test", nil, goatcounter.APITokenPermissions{})
+			r, rr := newAPITest(ctx, t, "GET", "/api/v0/test", nil, 0)
 
 			r.Header.Set("Authorization", r.Header.Get("Authorization")+"x")
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
@@ -103,10 +103,10 @@ func TestAPIBasics(t *testing.T) {
 
 		t.Run("no-perm", func(t *testing.T) {
 			body := bytes.NewReader(zjson.MustMarshal(map[string]interface{}{
-				"perm": goatcounter.APITokenPermissions{Export: true, Count: true},
+				"perm": goatcounter.APIPermExport | goatcounter.APIPermCount,
 			}))
 			ctx := gctest.DB(t)
-			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", body, goatcounter.APITokenPermissions{})
+			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", body, 0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 403)
@@ -119,7 +119,7 @@ func TestAPIBasics(t *testing.T) {
 
 		t.Run("404", func(t *testing.T) {
 			ctx := gctest.DB(t)
-			r, rr := newAPITest(ctx, t, "POST", "/api/v0/doesnt-exist", nil, goatcounter.APITokenPermissions{})
+			r, rr := newAPITest(ctx, t, "POST", "/api/v0/doesnt-exist", nil, 0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 404)
@@ -134,7 +134,7 @@ func TestAPIBasics(t *testing.T) {
 			ctx := gctest.DB(t)
 			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test",
 				strings.NewReader(`{"status":500}`),
-				goatcounter.APITokenPermissions{})
+				0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 500)
@@ -149,7 +149,7 @@ func TestAPIBasics(t *testing.T) {
 			ctx := gctest.DB(t)
 			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test",
 				strings.NewReader(`{{{{`),
-				goatcounter.APITokenPermissions{})
+				0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 400)
@@ -164,7 +164,7 @@ func TestAPIBasics(t *testing.T) {
 			ctx := gctest.DB(t)
 			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test",
 				strings.NewReader(`{"panic":true}`),
-				goatcounter.APITokenPermissions{})
+				0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 500)
@@ -177,7 +177,7 @@ func TestAPIBasics(t *testing.T) {
 
 		t.Run("ct", func(t *testing.T) {
 			ctx := gctest.DB(t)
-			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", nil, goatcounter.APITokenPermissions{})
+			r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", nil, 0)
 
 			r.Header.Set("Content-Type", "text/html")
 
@@ -200,7 +200,7 @@ func TestAPIBasics(t *testing.T) {
 				bytes.NewReader(zjson.MustMarshal(map[string]interface{}{
 					"validate": v,
 				})),
-				goatcounter.APITokenPermissions{})
+				0)
 
 			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 			ztest.Code(t, rr, 400)
@@ -218,7 +218,7 @@ func TestAPIBasics(t *testing.T) {
 			bytes.NewReader(zjson.MustMarshal(map[string]interface{}{
 				"context": true,
 			})),
-			goatcounter.APITokenPermissions{})
+			0)
 
 		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 		ztest.Code(t, rr, 200)
@@ -226,7 +226,7 @@ func TestAPIBasics(t *testing.T) {
 
 	t.Run("no-perm", func(t *testing.T) {
 		ctx := gctest.DB(t)
-		r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", nil, goatcounter.APITokenPermissions{})
+		r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", nil, 0)
 
 		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 		ztest.Code(t, rr, 200)
@@ -236,11 +236,9 @@ func TestAPIBasics(t *testing.T) {
 		ctx := gctest.DB(t)
 
 		body := bytes.NewReader(zjson.MustMarshal(map[string]interface{}{
-			"perm": goatcounter.APITokenPermissions{Export: true, Count: true},
+			"perm": goatcounter.APIPermExport | goatcounter.APIPermCount,
 		}))
-		r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", body, goatcounter.APITokenPermissions{
-			Export: true, Count: true,
-		})
+		r, rr := newAPITest(ctx, t, "POST", "/api/v0/test", body, goatcounter.APIPermExport|goatcounter.APIPermCount)
 
 		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
 		ztest.Code(t, rr, 200)
@@ -340,7 +338,7 @@ func TestAPICount(t *testing.T) {
 	}
 
 	gctest.SetNow(t, "2020-06-18 14:42:00")
-	perm := goatcounter.APITokenPermissions{Count: true}
+	perm := goatcounter.APIPermCount
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -432,7 +430,7 @@ func TestAPISitesCreate(t *testing.T) {
 		}},
 	}
 
-	perm := goatcounter.APITokenPermissions{SiteCreate: true, SiteRead: true, SiteUpdate: true}
+	perm := goatcounter.APIPermSiteCreate | goatcounter.APIPermSiteRead | goatcounter.APIPermSiteUpdate
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			ctx := gctest.DB(t)
@@ -495,7 +493,7 @@ func TestAPISitesUpdate(t *testing.T) {
 
 	_ = now
 
-	perm := goatcounter.APITokenPermissions{SiteCreate: true, SiteRead: true, SiteUpdate: true}
+	perm := goatcounter.APIPermSiteCreate | goatcounter.APIPermSiteRead | goatcounter.APIPermSiteUpdate
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			ctx := gctest.DB(t)
@@ -534,3 +532,453 @@ func TestAPISitesUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIAnnotations(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:13:14")
+
+	tests := []struct {
+		perm     goatcounter.APIPermissions
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{goatcounter.APIPermCount, `{"text":"x"}`, 403,
+			`{"error":"requires [annotations] permissions"}`},
+		{goatcounter.APIPermAnnotations, `{}`, 400,
+			`{"errors":{"text":["must be set"]}}`},
+		{goatcounter.APIPermAnnotations,
+			`{"text":"deploy v1.2","path":"/blog/*","at":"2020-06-18T10:00:00Z"}`, 200,
+			`{"id":1,"at":"2020-06-18T10:00:00Z","path":"/blog/*","text":"deploy v1.2","created_at":"2020-06-18T12:13:14Z"}`},
+		{goatcounter.APIPermAnnotations, `{"text":"now"}`, 200,
+			`{"id":1,"at":"2020-06-18T12:13:14Z","path":"","text":"now","created_at":"2020-06-18T12:13:14Z"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			ctx := gctest.DB(t)
+
+			r, rr := newAPITest(ctx, t, "POST", "/api/v0/annotations",
+				strings.NewReader(tt.body), tt.perm)
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			ztest.Code(t, rr, tt.wantCode)
+
+			if !jsonCmp(rr.Body.String(), tt.wantBody) {
+				t.Errorf("\ngot:  %s\nwant: %s", rr.Body.String(), tt.wantBody)
+			}
+
+			if tt.wantCode == 200 {
+				var l goatcounter.AuditLog
+				_, err := l.List(ctx, 0, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(l) != 1 || l[0].Action != "annotation.create" {
+					t.Errorf("wrong audit log: %#v", l)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIStatsHeatmap(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:13:14") // Thursday
+
+	t.Run("permission", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		r, rr := newAPITest(ctx, t, "GET", "/api/v0/stats/heatmap", nil,
+			goatcounter.APIPermCount)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 403)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		r, rr := newAPITest(ctx, t, "GET", "/api/v0/stats/heatmap?filter=(", nil,
+			goatcounter.APIPermStats)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 400)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		gctest.StoreHits(ctx, t, false,
+			goatcounter.Hit{Path: "/a", FirstVisit: true},
+			goatcounter.Hit{Path: "/a"})
+
+		for _, q := range []string{
+			"start=2020-06-18T00:00:00Z&end=2020-06-18T23:59:59Z",
+			"start=2020-06-18&end=2020-06-18",
+		} {
+			r, rr := newAPITest(ctx, t, "GET", "/api/v0/stats/heatmap?"+q, nil,
+				goatcounter.APIPermStats)
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			ztest.Code(t, rr, 200)
+
+			var hm goatcounter.Heatmap
+			err := json.Unmarshal(rr.Body.Bytes(), &hm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hm.Total[time.Thursday][12] != 2 || hm.TotalUnique[time.Thursday][12] != 1 {
+				t.Errorf("wrong heatmap for %q: %s", q, rr.Body.String())
+			}
+		}
+	})
+}
+
+func TestAPIViews(t *testing.T) {
+	ctx := gctest.DB(t)
+	site := Site(ctx)
+	perm := goatcounter.APIPermSiteRead | goatcounter.APIPermSiteUpdate
+
+	tests := []struct {
+		method, path, body string
+		wantCode           int
+		wantBody           string
+	}{
+		{"PUT", "/views/docs", `{"period":"month","filter":"path:/docs/*"}`, 200,
+			`{"name":"docs","filter":"path:/docs/*","daily":false,"as-text":false,"period":"month"}`},
+		{"GET", "/views/DOCS", ``, 200,
+			`{"name":"docs","filter":"path:/docs/*","daily":false,"as-text":false,"period":"month"}`},
+		{"GET", "/views", ``, 200,
+			`{"views":[{"name":"default","filter":"","daily":false,"as-text":false,"period":"week"},` +
+				`{"name":"docs","filter":"path:/docs/*","daily":false,"as-text":false,"period":"month"}]}`},
+		{"DELETE", "/views/default", ``, 400, `{"error":"can't remove the default view"}`},
+		{"DELETE", "/views/docs", ``, 202, `{"status":"ok"}`},
+		{"GET", "/views/docs", ``, 404, `{"error":"not found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r, rr := newAPITest(ctx, t, tt.method, fmt.Sprintf("/api/v0/sites/%d%s", site.ID, tt.path),
+				strings.NewReader(tt.body), perm)
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			ztest.Code(t, rr, tt.wantCode)
+
+			if !jsonCmp(rr.Body.String(), tt.wantBody) {
+				t.Errorf("\ngot:  %s\nwant: %s", rr.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestAPIAudit(t *testing.T) {
+	ctx := gctest.DB(t)
+	site := Site(ctx)
+
+	t.Run("permission", func(t *testing.T) {
+		r, rr := newAPITest(ctx, t, "GET", "/api/v0/audit", nil,
+			goatcounter.APIPermSiteRead)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 403)
+	})
+
+	r, rr := newAPITest(ctx, t, "PUT", fmt.Sprintf("/api/v0/sites/%d/views/docs", site.ID),
+		strings.NewReader(`{"period":"month"}`), goatcounter.APIPermSiteUpdate)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	ztest.Code(t, rr, 200)
+
+	r, rr = newAPITest(ctx, t, "GET", "/api/v0/audit?limit=1", nil,
+		goatcounter.APIPermAudit)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	ztest.Code(t, rr, 200)
+
+	var got struct {
+		Events goatcounter.AuditLog `json:"events"`
+		More   bool                 `json:"more"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.More || len(got.Events) != 1 {
+		t.Fatalf("wrong response: %s", rr.Body.String())
+	}
+	if e := got.Events[0]; e.Action != "site.settings" || e.Email != "test@gctest.localhost" ||
+		!strings.Contains(e.Data.Display("views"), `"period":"month"`) {
+		t.Errorf("wrong event: %#v", e)
+	}
+}
+
+func TestAPITokenRestrictions(t *testing.T) {
+	ctx := gctest.DB(t)
+	site := Site(ctx)
+
+	child := goatcounter.Site{Code: "child", Parent: &site.ID, Plan: goatcounter.PlanChild}
+	err := child.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	insert := func(t *testing.T, token goatcounter.APIToken) string {
+		t.Helper()
+		token.Name = "test"
+		token.Permissions = goatcounter.APIPermSiteRead | goatcounter.APIPermStats
+		err := token.Insert(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token.Token
+	}
+	req := func(t *testing.T, key, path string, wantCode int, wantBody string) *httptest.ResponseRecorder {
+		t.Helper()
+		r, rr := newTest(ctx, "GET", path, nil)
+		r.Header.Set("Authorization", "Bearer "+key)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, wantCode)
+		if wantBody != "" && rr.Body.String() != wantBody {
+			t.Errorf("\nwant: %s\ngot:  %s\n", wantBody, rr.Body.String())
+		}
+		return rr
+	}
+
+	t.Run("ip", func(t *testing.T) {
+		key := insert(t, goatcounter.APIToken{IPRanges: goatcounter.Strings{"192.0.3.0/24"}})
+		req(t, key, "/api/v0/me", 403, `{"error":"token can't be used from this IP address"}`)
+		key = insert(t, goatcounter.APIToken{IPRanges: goatcounter.Strings{"192.0.3.0/24", "192.0.2.1"}})
+		req(t, key, "/api/v0/me", 200, "")
+	})
+
+	t.Run("sites", func(t *testing.T) {
+		key := insert(t, goatcounter.APIToken{Sites: goatcounter.Ints{child.ID}})
+		req(t, key, "/api/v0/stats/heatmap", 403, `{"error":"token can't be used for this site"}`)
+		req(t, key, fmt.Sprintf("/api/v0/sites/%d", site.ID), 403,
+			fmt.Sprintf(`{"error":"token can't be used for site %d"}`, site.ID))
+		req(t, key, fmt.Sprintf("/api/v0/sites/%d", child.ID), 200, "")
+
+		rr := req(t, key, "/api/v0/sites", 200, "")
+		var got struct {
+			Sites goatcounter.Sites `json:"sites"`
+		}
+		d := json.NewDecoder(rr.Body)
+		d.AllowReadonlyFields()
+		err := d.Decode(&got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Sites) != 1 || got.Sites[0].ID != child.ID {
+			t.Errorf("wrong sites: %v", got.Sites)
+		}
+
+		// Tokens are for the entire account, and can be used on the domain of
+		// the child site.
+		onChild := func(t *testing.T, key string, wantCode int) {
+			t.Helper()
+			r, rr := newTest(ctx, "GET", "/api/v0/stats/heatmap", nil)
+			r.Host = child.Code + "." + goatcounter.Config(ctx).Domain
+			r.Header.Set("Authorization", "Bearer "+key)
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			ztest.Code(t, rr, wantCode)
+		}
+		onChild(t, key, 200)
+		onChild(t, insert(t, goatcounter.APIToken{}), 200)
+		onChild(t, insert(t, goatcounter.APIToken{Sites: goatcounter.Ints{site.ID}}), 403)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		exp := goatcounter.Now().Add(time.Hour)
+		key := insert(t, goatcounter.APIToken{ExpiresAt: &exp})
+		req(t, key, "/api/v0/me", 200, "")
+
+		gctest.SetNow(t, exp.Add(time.Minute))
+		req(t, key, "/api/v0/me", 403, `{"error":"token has expired"}`)
+	})
+
+	t.Run("last used", func(t *testing.T) {
+		key := insert(t, goatcounter.APIToken{})
+		req(t, key, "/api/v0/me", 200, "")
+		got := zdb.DumpString(ctx, `select last_used_ip from api_tokens order by api_token_id desc limit 1`)
+		if d := zdb.Diff(got, "last_used_ip\n192.0.2.1"); d != "" {
+			t.Error(d)
+		}
+	})
+}
+
+func TestAPIUsers(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	r, rr := newAPITest(ctx, t, "GET", "/api/v0/users", nil, goatcounter.APIPermSiteRead)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	ztest.Code(t, rr, 403)
+
+	r, rr = newAPITest(ctx, t, "GET", "/api/v0/users", nil, goatcounter.APIPermUsers)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	ztest.Code(t, rr, 200)
+
+	var users struct {
+		Users goatcounter.Users `json:"users"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users.Users) != 1 || users.Users[0].Email != "test@gctest.localhost" {
+		t.Errorf("wrong body: %s", rr.Body.String())
+	}
+}
+
+func TestAPIRatelimit(t *testing.T) {
+	ctx := gctest.DB(t)
+	goatcounter.Config(ctx).APIRatelimits = goatcounter.APIRatelimits{"default": {Limit: 2, Period: 60}}
+	handler := newBackend(zdb.MustGetDB(ctx))
+
+	// Token IDs are re-used between tests.
+	apiUsage.Lock()
+	apiUsage.m = make(map[int64]map[string]apiRatelimitUsage)
+	apiUsage.Unlock()
+	apiUnknownTokens.Flush()
+
+	r, _ := newAPITest(ctx, t, "GET", "/api/v0/me", nil, 0)
+	key := r.Header.Get("Authorization")
+	for i, want := range []int{200, 200, 429} {
+		r, rr := newTest(ctx, "GET", "/api/v0/me", nil)
+		r.Header.Set("Authorization", key)
+		handler.ServeHTTP(rr, r)
+		ztest.Code(t, rr, want)
+
+		h := rr.Header()
+		if h.Get("X-RateLimit-Limit") != "2" {
+			t.Errorf("%d: wrong headers: %v", i, h)
+		}
+		if want == 200 && h.Get("X-RateLimit-Remaining") != fmt.Sprintf("%d", 1-i) {
+			t.Errorf("%d: wrong remaining: %v", i, h)
+		}
+		if want == 429 && h.Get("Retry-After") == "" {
+			t.Errorf("%d: no Retry-After: %v", i, h)
+		}
+	}
+
+	// Other tokens have their own limit.
+	r, rr := newAPITest(ctx, t, "GET", "/api/v0/me", nil, 0)
+	handler.ServeHTTP(rr, r)
+	ztest.Code(t, rr, 200)
+
+	var id int64
+	err := zdb.Get(ctx, &id, `select api_token_id from api_tokens where token=$1`, strings.TrimPrefix(key, "Bearer "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := apiTokenUsage(id)
+	if len(u) != 1 || u[0].Group != "default" || u[0].Used() != 2 || u[0].Limit != 2 {
+		t.Errorf("wrong usage: %#v", u)
+	}
+
+	// Unknown tokens are limited by IP, and their usage isn't recorded.
+	for i, want := range []int{403, 403, 429} {
+		r, rr := newTest(ctx, "GET", "/api/v0/me", nil)
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer nope-%d", i))
+		handler.ServeHTTP(rr, r)
+		ztest.Code(t, rr, want)
+	}
+	apiUsage.Lock()
+	n := len(apiUsage.m)
+	apiUsage.Unlock()
+	if n != 2 {
+		t.Errorf("usage recorded for %d tokens", n)
+	}
+
+	apiUsage.Lock()
+	apiUsagePrune(goatcounter.Now().Add(61 * time.Second))
+	n = len(apiUsage.m)
+	apiUsage.Unlock()
+	if n != 0 {
+		t.Errorf("expired usage not removed for %d tokens", n)
+	}
+
+	// Tokens can override the limit for a group.
+	token := goatcounter.APIToken{
+		Name:       "limit",
+		Ratelimits: goatcounter.APIRatelimits{"default": {Limit: 3, Period: 60}},
+	}
+	err = token.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got goatcounter.APIToken
+	err = got.ByID(ctx, token.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Ratelimits.String() != "default:3/60" {
+		t.Errorf("wrong ratelimits stored: %q", got.Ratelimits)
+	}
+	for i, want := range []int{200, 200, 200, 429} {
+		r, rr := newTest(ctx, "GET", "/api/v0/me", nil)
+		r.Header.Set("Authorization", "Bearer "+token.Token)
+		handler.ServeHTTP(rr, r)
+		ztest.Code(t, rr, want)
+		if h := rr.Header().Get("X-RateLimit-Limit"); h != "3" {
+			t.Errorf("%d: wrong limit: %q", i, h)
+		}
+	}
+
+	// Too many unknown tokens are limited by IP before the token is looked up.
+	goatcounter.Config(ctx).APIRatelimits = goatcounter.APIRatelimits{"default": {Limit: 100, Period: 60}}
+	defer func(l int) { apiUnknownTokenLimit = l }(apiUnknownTokenLimit)
+	apiUnknownTokenLimit = 2
+	apiUnknownTokens.Flush()
+	for i, want := range []int{403, 403, 429, 429} {
+		r, rr := newTest(ctx, "GET", "/api/v0/me", nil)
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer unknown-%d", i))
+		handler.ServeHTTP(rr, r)
+		ztest.Code(t, rr, want)
+		if want == 429 && (rr.Header().Get("Retry-After") != "60" || rr.Header().Get("X-RateLimit-Limit") != "") {
+			t.Errorf("%d: wrong headers: %v", i, rr.Header())
+		}
+	}
+}
+
+func TestAPIRatelimitReset(t *testing.T) {
+	ctx := gctest.DB(t)
+	goatcounter.Config(ctx).APIRatelimits = goatcounter.APIRatelimits{"default": {Limit: 1, Period: 60}}
+	handler := newBackend(zdb.MustGetDB(ctx))
+
+	apiUsage.Lock()
+	apiUsage.m = make(map[int64]map[string]apiRatelimitUsage)
+	apiUsage.Unlock()
+
+	now := time.Date(2020, 6, 18, 12, 0, 0, 0, time.UTC)
+	r, _ := newAPITest(ctx, t, "GET", "/api/v0/me", nil, 0)
+	key := r.Header.Get("Authorization")
+
+	tests := []struct {
+		after      time.Duration
+		wantCode   int
+		wantReset  string
+		wantRetry  string
+		wantRemain string
+	}{
+		{0, 200, "60", "", "0"},
+		{10 * time.Second, 429, "50", "50", "0"},
+		{40 * time.Second, 429, "20", "20", "0"},
+		{59*time.Second + 500*time.Millisecond, 429, "1", "1", "0"},
+		{60 * time.Second, 200, "60", "", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.after.String(), func(t *testing.T) {
+			gctest.SetNow(t, now.Add(tt.after))
+
+			r, rr := newTest(ctx, "GET", "/api/v0/me", nil)
+			r.Header.Set("Authorization", key)
+			handler.ServeHTTP(rr, r)
+			ztest.Code(t, rr, tt.wantCode)
+
+			h := rr.Header()
+			if h.Get("X-RateLimit-Reset") != tt.wantReset || h.Get("Retry-After") != tt.wantRetry ||
+				h.Get("X-RateLimit-Remaining") != tt.wantRemain {
+				t.Errorf("wrong headers: %v", h)
+			}
+		})
+	}
+
+	var id int64
+	err := zdb.Get(ctx, &id, `select api_token_id from api_tokens where token=$1`, strings.TrimPrefix(key, "Bearer "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gctest.SetNow(t, now.Add(70*time.Second))
+	u := apiTokenUsage(id)
+	if len(u) != 1 || !u[0].Reset.Equal(now.Add(120*time.Second)) {
+		t.Errorf("wrong usage: %#v", u)
+	}
+}
@@ -6,6 +6,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -16,6 +17,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/monoculum/formam"
 	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/filter"
 	"zgo.at/guru"
 	"zgo.at/isbot"
 	"zgo.at/zdb"
@@ -58,6 +60,7 @@ func (h backend) Mount(r chi.Router, db zdb.DB, dev bool, domainStatic string) {
 	}
 
 	r.Use(
+		remotePeer,
 		mware.RealIP(),
 		mware.WrapWriter(),
 		mware.Unpanic(),
@@ -75,6 +78,7 @@ func (h backend) Mount(r chi.Router, db zdb.DB, dev bool, domainStatic string) {
 	website{fsys, false, ""}.MountShared(r)
 	api{}.mount(r, db)
 	vcounter{}.mount(r, db)
+	embed{}.mount(r, domainStatic)
 
 	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
 		zhttp.ErrPage(w, r, guru.New(404, "Not Found"))
@@ -145,21 +149,25 @@ func (h backend) Mount(r chi.Router, db zdb.DB, dev bool, domainStatic string) {
 		a := r.With(mware.Headers(headers), keyAuth)
 		user{}.mount(a)
 		{
+			a.Get("/share/{token}", zhttp.Wrap(h.share))
+
 			ap := a.With(loggedInOrPublic)
 			ap.Get("/", zhttp.Wrap(h.dashboard))
 			ap.Get("/pages-more", zhttp.Wrap(h.pagesMore))
 			ap.Get("/hchart-detail", zhttp.Wrap(h.hchartDetail))
 			ap.Get("/hchart-more", zhttp.Wrap(h.hchartMore))
+			ap.Get("/download/{file}", zhttp.Wrap(h.download))
 		}
 		{
 			af := a.With(loggedIn)
 			if zstripe.SecretKey != "" && zstripe.SignSecret != "" && zstripe.PublicKey != "" {
-				billing{}.mount(a, af)
+				billing{}.mount(a, af.With(requireAccess(goatcounter.AccessOwner)))
 			}
 			af.Get("/updates", zhttp.Wrap(h.updates))
+			af.Get("/overview", zhttp.Wrap(h.overview))
 
 			settings{}.mount(af)
-			admin{}.mount(af, db)
+			admin{}.mount(af.With(requireAccess(goatcounter.AccessAdmin)), db)
 		}
 	}
 }
@@ -235,22 +243,24 @@ func (h backend) count(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h backend) pagesMore(w http.ResponseWriter, r *http.Request) error {
+	_, err := getView(r)
+	if err != nil {
+		return err
+	}
 	site := Site(r.Context())
+	err = shareAllowed(r, "pages")
+	if err != nil {
+		return err
+	}
 
 	exclude, err := zint.Split(r.URL.Query().Get("exclude"), ",")
 	if err != nil {
 		return err
 	}
 
-	var (
-		filter     = r.URL.Query().Get("filter")
-		pathFilter []int64
-	)
-	if filter != "" {
-		pathFilter, err = goatcounter.PathFilter(r.Context(), filter, true)
-		if err != nil {
-			return err
-		}
+	filter, err := getFilter(r)
+	if err != nil {
+		return err
 	}
 
 	asText := r.URL.Query().Get("as-text") == "on" || r.URL.Query().Get("as-text") == "true"
@@ -273,7 +283,19 @@ func (h backend) pagesMore(w http.ResponseWriter, r *http.Request) error {
 
 	var pages goatcounter.HitLists
 	totalDisplay, totalUniqueDisplay, more, err := pages.List(
-		r.Context(), start, end, pathFilter, exclude, daily)
+		r.Context(), start, end, filter, exclude, 0, daily)
+	if err != nil {
+		return err
+	}
+
+	var annot goatcounter.Annotations
+	err = annot.ListRange(r.Context(), start, end)
+	if err != nil {
+		return err
+	}
+
+	var visitors goatcounter.VisitorStats
+	err = visitors.ListPaths(r.Context(), start, end, filter, exclude, 0)
 	if err != nil {
 		return err
 	}
@@ -296,8 +318,11 @@ func (h backend) pagesMore(w http.ResponseWriter, r *http.Request) error {
 		// Dummy values so template won't error out.
 		Refs     bool
 		ShowRefs string
+
+		Annotations goatcounter.Annotations
+		Visitors    goatcounter.VisitorStats
 	}{r.Context(), pages, site, start, end, daily, forcedDaily, int(max),
-		offset, false, ""})
+		offset, false, "", annot, visitors})
 	if err != nil {
 		return err
 	}
@@ -328,39 +353,39 @@ func (h backend) hchartDetail(w http.ResponseWriter, r *http.Request) error {
 	name := r.URL.Query().Get("name")
 	kind := r.URL.Query().Get("kind")
 	v.Required("name", name)
-	v.Include("kind", kind, []string{"browser", "system", "size", "topref", "location"})
+	v.Include("kind", kind, []string{"browser", "system", "size", "topref", "location", "channel", "worldmap"})
 	v.Required("kind", kind)
 	total := int(v.Integer("total", r.URL.Query().Get("total")))
 	if v.HasErrors() {
 		return v
 	}
+	err = shareAllowed(r, hchartWidgets[kind])
+	if err != nil {
+		return err
+	}
 
-	var (
-		filter     = r.URL.Query().Get("filter")
-		pathFilter []int64
-	)
-	if filter != "" {
-		pathFilter, err = goatcounter.PathFilter(r.Context(), filter, true)
-		if err != nil {
-			return err
-		}
+	filter, err := getFilter(r)
+	if err != nil {
+		return err
 	}
 
 	var detail goatcounter.HitStats
 	switch kind {
 	case "browser":
-		err = detail.ListBrowser(r.Context(), name, start, end, pathFilter)
+		err = detail.ListBrowser(r.Context(), name, start, end, filter)
 	case "system":
-		err = detail.ListSystem(r.Context(), name, start, end, pathFilter)
+		err = detail.ListSystem(r.Context(), name, start, end, filter)
 	case "size":
-		err = detail.ListSize(r.Context(), name, start, end, pathFilter)
-	case "location":
-		err = detail.ListLocation(r.Context(), name, start, end, pathFilter)
+		err = detail.ListSize(r.Context(), name, start, end, filter)
+	case "location", "worldmap":
+		err = detail.ListLocation(r.Context(), name, start, end, filter)
 	case "topref":
 		if name == "(unknown)" {
 			name = ""
 		}
-		err = detail.ByRef(r.Context(), start, end, pathFilter, name)
+		err = detail.ByRef(r.Context(), start, end, filter, name)
+	case "channel":
+		err = detail.ListRefsByChannel(r.Context(), name, start, end, filter, 10)
 	}
 	if err != nil {
 		return err
@@ -372,6 +397,10 @@ func (h backend) hchartDetail(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h backend) hchartMore(w http.ResponseWriter, r *http.Request) error {
+	_, err := getView(r)
+	if err != nil {
+		return err
+	}
 	site := Site(r.Context())
 
 	start, end, err := getPeriod(w, r, site)
@@ -386,15 +415,9 @@ func (h backend) hchartMore(w http.ResponseWriter, r *http.Request) error {
 	total := int(v.Integer("total", r.URL.Query().Get("total")))
 	offset := int(v.Integer("offset", r.URL.Query().Get("offset")))
 
-	var (
-		filter     = r.URL.Query().Get("filter")
-		pathFilter []int64
-	)
-	if filter != "" {
-		pathFilter, err = goatcounter.PathFilter(r.Context(), filter, true)
-		if err != nil {
-			return err
-		}
+	filter, err := getFilter(r)
+	if err != nil {
+		return err
 	}
 
 	showRefs := ""
@@ -405,6 +428,10 @@ func (h backend) hchartMore(w http.ResponseWriter, r *http.Request) error {
 	if v.HasErrors() {
 		return v
 	}
+	err = shareAllowed(r, hchartWidgets[kind])
+	if err != nil {
+		return err
+	}
 
 	var (
 		page     goatcounter.HitStats
@@ -414,18 +441,18 @@ func (h backend) hchartMore(w http.ResponseWriter, r *http.Request) error {
 	)
 	switch kind {
 	case "browser":
-		err = page.ListBrowsers(r.Context(), start, end, pathFilter, 6, offset)
+		err = page.ListBrowsers(r.Context(), start, end, filter, 6, offset)
 	case "system":
-		err = page.ListSystems(r.Context(), start, end, pathFilter, 6, offset)
+		err = page.ListSystems(r.Context(), start, end, filter, 6, offset)
 	case "location":
-		err = page.ListLocations(r.Context(), start, end, pathFilter, 6, offset)
+		err = page.ListLocations(r.Context(), start, end, filter, 6, offset)
 	case "ref":
-		err = page.ListRefsByPath(r.Context(), showRefs, start, end, offset)
+		err = page.ListRefsByPath(r.Context(), showRefs, start, end, filter, offset)
 		size = site.Settings.LimitRefs()
 		paginate = offset == 0
 		link = false
 	case "topref":
-		err = page.ListTopRefs(r.Context(), start, end, pathFilter, offset)
+		err = page.ListTopRefs(r.Context(), start, end, filter, 6, offset)
 	}
 	if err != nil {
 		return err
@@ -528,3 +555,64 @@ func getDaily(r *http.Request, start, end time.Time) (daily bool, forced bool) {
 	d := strings.ToLower(r.URL.Query().Get("daily"))
 	return d == "on" || d == "true", false
 }
+
+// Widget names for the kind parameter of the hchart endpoints.
+var hchartWidgets = map[string]string{
+	"browser":  "browsers",
+	"system":   "systems",
+	"size":     "sizes",
+	"location": "locations",
+	"topref":   "toprefs",
+	"channel":  "channels",
+	"worldmap": "worldmap",
+	"ref":      "pages",
+}
+
+// shareAllowed checks if the widget can be displayed when viewing the dashboard
+// with a share token.
+func shareAllowed(r *http.Request, widget string) error {
+	if t := goatcounter.GetShareToken(r.Context()); t != nil && !t.HasWidget(widget) {
+		return guru.New(403, "not allowed with this share link")
+	}
+	return nil
+}
+
+// getView gets the view from the "view" parameter, or the default view if it's
+// not set.
+//
+// The site in the request context is replaced with a copy that uses the view's
+// widgets, so that the widget settings are taken from the view.
+func getView(r *http.Request) (goatcounter.View, error) {
+	site := Site(r.Context())
+	name := r.URL.Query().Get("view")
+	if name == "" {
+		name = "default"
+	}
+
+	view, i := site.Settings.Views.Get(name)
+	if i == -1 {
+		return view, guru.Errorf(404, "unknown view: %q", name)
+	}
+	if len(view.Widgets) > 0 {
+		s := *site
+		s.Settings = site.Settings.WithView(view)
+		*r = *r.WithContext(goatcounter.WithSite(r.Context(), &s))
+	}
+	return view, nil
+}
+
+// getFilter parses the filter from the query string, combined with the fixed
+// filter of the share link, if any; syntax errors are returned as a 400 error.
+func getFilter(r *http.Request) (goatcounter.Filter, error) {
+	var fixed string
+	if t := goatcounter.GetShareToken(r.Context()); t != nil {
+		fixed = t.Filter
+	}
+
+	f, err := goatcounter.NewFilterAnd(r.Context(), fixed, r.URL.Query().Get("filter"))
+	var fErr *filter.Error
+	if errors.As(err, &fErr) {
+		return f, guru.New(400, fErr.Error())
+	}
+	return f, err
+}
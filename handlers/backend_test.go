@@ -31,10 +31,20 @@ func TestBackendTpl(t *testing.T) {
 		{"/updates", "Updates"},
 		{"/code", "add the following JavaScript anywhere on the page"},
 
+		// Dashboard
+		{"/hchart-detail?kind=channel&name=direct&total=1", `"html":`},
+		{"/hchart-detail?kind=worldmap&name=NL&total=1", `"html":`},
+		{"/overview", "Top referrers"},
+
 		// Settings
 		{"/settings/main", "Data retention in days"},
 		{"/settings/dashboard", "Paths overview"},
+		{"/settings/ref-groups", "There are no referrer groups"},
 		{"/settings/sites", "Copy all settings from the current site except the domain name"},
+		{"/settings/users", "Invite user"},
+		{"/settings/audit", "Changes to the settings, sites, users, and API tokens"},
+		{"/settings/annotations", "Annotations are displayed on the charts"},
+		{"/settings/share", "Share links give read-only access"},
 		{"/settings/purge", "Remove all instances of a page"},
 		{"/settings/export", "The first line is a header with the field names"},
 		{"/settings/auth", "API documentation"},
@@ -0,0 +1,323 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"zgo.at/goatcounter"
+	"zgo.at/guru"
+	"zgo.at/zhttp/ztpl/tplfunc"
+	"zgo.at/zstd/zfilepath"
+	"zgo.at/zstd/zstring"
+)
+
+// Image sizes for the chart, in pixels.
+const (
+	chartWidth, chartMinWidth, chartMaxWidth    = 600, 100, 2000
+	chartHeight, chartMinHeight, chartMaxHeight = 200, 40, 1000
+
+	// Don't draw the labels if the image is smaller than this.
+	chartLabelHeight = 80
+)
+
+type chartTheme struct{ bg, bar, text color.RGBA }
+
+var chartThemes = map[string]chartTheme{
+	"light": {
+		bg:   color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		bar:  color.RGBA{R: 0x9a, G: 0x15, B: 0xa4, A: 0xff},
+		text: color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff},
+	},
+	"dark": {
+		bg:   color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff},
+		bar:  color.RGBA{R: 0xd3, G: 0x8c, B: 0xd9, A: 0xff},
+		text: color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff},
+	},
+}
+
+// Named ranges for the range parameter, in addition to a number of days.
+var chartRanges = []string{"day", "week", "month", "quarter", "half-year", "year", "week-cur", "month-cur"}
+
+var (
+	chartFontOnce sync.Once
+	chartFont     *opentype.Font
+)
+
+// chart renders the visitors for the totals or a single path as a PNG or SVG
+// image.
+func (h vcounter) chart(w http.ResponseWriter, r *http.Request) error {
+	site := Site(r.Context())
+	if !site.Settings.AllowCounter {
+		return guru.New(http.StatusForbidden, "Need to enable the ‘allow using the visitor counter’ setting")
+	}
+
+	path, ext := zfilepath.SplitExt(r.URL.Path[7:])
+	if ext != "png" && ext != "svg" {
+		return guru.Errorf(400, "unknown extension: %q", ext)
+	}
+
+	q := r.URL.Query()
+	width, err := chartSize(q.Get("width"), chartWidth, chartMinWidth, chartMaxWidth)
+	if err != nil {
+		return err
+	}
+	height, err := chartSize(q.Get("height"), chartHeight, chartMinHeight, chartMaxHeight)
+	if err != nil {
+		return err
+	}
+	themeName := q.Get("theme")
+	if themeName == "" {
+		themeName = "light"
+	}
+	theme, ok := chartThemes[themeName]
+	if !ok {
+		return guru.Errorf(400, "unknown theme: %q", themeName)
+	}
+
+	start, end, err := chartRange(w, r, site)
+	if err != nil {
+		return err
+	}
+	// Hourly bars get too narrow to be readable for longer periods.
+	daily, _ := getDaily(r, start, end)
+	if end.Sub(start).Hours()*2 > float64(width) {
+		daily = true
+	}
+
+	var filter goatcounter.Filter
+	if path != "TOTAL" {
+		filter.Paths, err = goatcounter.PathIDs(r.Context(), path)
+		if err != nil {
+			return err
+		}
+	}
+	var hl goatcounter.HitList
+	_, err = hl.Totals(r.Context(), start, end, filter, daily)
+	if err != nil {
+		return err
+	}
+
+	c := chartImage{
+		width:  width,
+		height: height,
+		theme:  theme,
+		points: chartPoints(hl, daily),
+	}
+	if height >= chartLabelHeight {
+		tz := site.Settings.Timezone.Loc()
+		c.labels = [2]string{
+			start.In(tz).Format(site.Settings.DateFormat) + " – " + end.In(tz).Format(site.Settings.DateFormat),
+			tplfunc.Number(hl.CountUnique, site.Settings.NumberFormat) + " visitors",
+		}
+	}
+
+	if ext == "svg" {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, err := w.Write([]byte(c.svg()))
+		return err
+	}
+	img, err := c.png()
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "image/png")
+	return png.Encode(w, img)
+}
+
+// chartSize parses the width or height parameter; values outside of the
+// allowed range are clamped.
+func chartSize(v string, def, min, max int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, guru.Errorf(400, "invalid size: %q", v)
+	}
+	if n < min {
+		return min, nil
+	}
+	if n > max {
+		return max, nil
+	}
+	return n, nil
+}
+
+// chartRange gets the period from the period-start and period-end parameters,
+// or the range parameter if they're not set. The default is the last week.
+func chartRange(w http.ResponseWriter, r *http.Request, site *goatcounter.Site) (time.Time, time.Time, error) {
+	q := r.URL.Query()
+	if q.Get("period-start") != "" || q.Get("period-end") != "" {
+		start, end, err := getPeriod(w, r, site)
+		if err != nil {
+			return start, end, err
+		}
+		if start.IsZero() || end.IsZero() {
+			return start, end, guru.New(400, "need to set both period-start and period-end")
+		}
+		return start, end, nil
+	}
+
+	rng := q.Get("range")
+	if rng == "" {
+		rng = "week"
+	}
+	if !zstring.Contains(chartRanges, rng) {
+		if n, err := strconv.Atoi(rng); err != nil || n < 1 || n > 3660 {
+			return time.Time{}, time.Time{}, guru.Errorf(400, "invalid range: %q", rng)
+		}
+	}
+	return timeRange(rng, site.Settings.Timezone.Loc(), site.Settings.SundayStartsWeek)
+}
+
+// chartPoints gets the visitors per day or hour.
+func chartPoints(hl goatcounter.HitList, daily bool) []int {
+	if daily {
+		p := make([]int, 0, len(hl.Stats))
+		for _, s := range hl.Stats {
+			p = append(p, s.DailyUnique)
+		}
+		return p
+	}
+
+	p := make([]int, 0, len(hl.Stats)*24)
+	for _, s := range hl.Stats {
+		p = append(p, s.HourlyUnique...)
+	}
+	return p
+}
+
+type chartImage struct {
+	width, height int
+	theme         chartTheme
+	points        []int
+	labels        [2]string // Top-left and top-right; may be blank.
+}
+
+// bars gets the position of every bar; the PNG and SVG are drawn from the same
+// layout so they look identical.
+func (c chartImage) bars() []image.Rectangle {
+	if len(c.points) == 0 {
+		return nil
+	}
+
+	max := 1
+	for _, p := range c.points {
+		if p > max {
+			max = p
+		}
+	}
+
+	top := 2
+	if c.labels != [2]string{} {
+		top = 20
+	}
+	var (
+		n    = len(c.points)
+		gap  = 0
+		area = c.height - top
+	)
+	if c.width/n >= 4 {
+		gap = 1
+	}
+
+	bars := make([]image.Rectangle, 0, n)
+	for i, p := range c.points {
+		if p == 0 {
+			continue
+		}
+		h := p * area / max
+		if h == 0 {
+			h = 1
+		}
+		bars = append(bars, image.Rect(i*c.width/n, c.height-h, (i+1)*c.width/n-gap, c.height))
+	}
+	return bars
+}
+
+func (c chartImage) png() (image.Image, error) {
+	img := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c.theme.bg), image.Point{}, draw.Src)
+
+	bar := image.NewUniform(c.theme.bar)
+	for _, b := range c.bars() {
+		draw.Draw(img, b, bar, image.Point{}, draw.Src)
+	}
+
+	if c.labels == [2]string{} {
+		return img, nil
+	}
+
+	chartFontOnce.Do(func() {
+		var err error
+		chartFont, err = opentype.Parse(goregular.TTF)
+		if err != nil {
+			panic(err)
+		}
+	})
+	// A Face can't be used concurrently, so create a new one every time; this
+	// is fairly cheap.
+	face, err := opentype.NewFace(chartFont, &opentype.FaceOptions{
+		Size:    12,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	drw := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c.theme.text),
+		Face: face,
+		Dot:  fixed.P(4, 14),
+	}
+	drw.DrawString(c.labels[0])
+	drw.Dot = fixed.P(c.width-4-font.MeasureString(face, c.labels[1]).Round(), 14)
+	drw.DrawString(c.labels[1])
+	return img, nil
+}
+
+func (c chartImage) svg() string {
+	hex := func(col color.RGBA) string { return fmt.Sprintf("#%02x%02x%02x", col.R, col.G, col.B) }
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"+
+		`<svg version="1.1" xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d">`+"\n",
+		c.width, c.height)
+	fmt.Fprintf(b, `<rect id="gcchart-bg" width="100%%" height="100%%" fill="%s" />`+"\n", hex(c.theme.bg))
+
+	fmt.Fprintf(b, `<g id="gcchart-bars" fill="%s">`+"\n", hex(c.theme.bar))
+	for _, r := range c.bars() {
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" />`+"\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+	}
+	b.WriteString("</g>\n")
+
+	if c.labels != [2]string{} {
+		fmt.Fprintf(b, `<g id="gcchart-labels" fill="%s" font-family="sans-serif" font-size="12">`+"\n", hex(c.theme.text))
+		fmt.Fprintf(b, `<text x="4" y="14">%s</text>`+"\n", template.HTMLEscapeString(c.labels[0]))
+		fmt.Fprintf(b, `<text x="%d" y="14" text-anchor="end">%s</text>`+"\n", c.width-4, template.HTMLEscapeString(c.labels[1]))
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package handlers
+
+import (
+	"image/color"
+	"image/png"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/zdb"
+	"zgo.at/zstd/ztest"
+)
+
+func TestChart(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 14:00:00")
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		goatcounter.Hit{Path: "/a", FirstVisit: true, CreatedAt: goatcounter.Now().Add(-2 * time.Hour)},
+		goatcounter.Hit{Path: "/b", FirstVisit: true})
+
+	run := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		r, rr := newTest(ctx, "GET", path, nil)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	ztest.Code(t, run(t, "/chart/TOTAL.svg"), 403)
+
+	site := goatcounter.MustGetSite(ctx)
+	site.Settings.AllowCounter = true
+	err := site.Update(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const q = "?period-start=2020-06-18&period-end=2020-06-18&width=240&height=100"
+
+	t.Run("svg", func(t *testing.T) {
+		rr := run(t, "/chart/%2Fa.svg"+q)
+		ztest.Code(t, rr, 200)
+		if h := rr.Header().Get("Cache-Control"); h != "public" {
+			t.Errorf("Cache-Control: %q", h)
+		}
+
+		b := rr.Body.String()
+		for _, want := range []string{
+			`<rect x="120" y="20" width="9" height="80" />`,
+			`<text x="4" y="14">18 Jun ’20 – 18 Jun ’20</text>`,
+			`>1 visitors</text>`,
+		} {
+			if !strings.Contains(b, want) {
+				t.Errorf("%q not in body:\n%s", want, b)
+			}
+		}
+		if strings.Contains(b, `x="140"`) {
+			t.Errorf("has bar for /b:\n%s", b)
+		}
+	})
+
+	t.Run("png", func(t *testing.T) {
+		rr := run(t, "/chart/TOTAL.png"+q+"&theme=dark")
+		ztest.Code(t, rr, 200)
+
+		img, err := png.Decode(rr.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := img.Bounds().Size(); s.X != 240 || s.Y != 100 {
+			t.Fatalf("wrong size: %s", s)
+		}
+
+		theme := chartThemes["dark"]
+		for _, tt := range []struct {
+			x, y int
+			want color.RGBA
+		}{
+			{5, 99, theme.bg},
+			{125, 99, theme.bar},
+			{145, 99, theme.bar},
+		} {
+			if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+				t.Errorf("%d×%d: got %v; want %v", tt.x, tt.y, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ztest.Code(t, run(t, "/chart/TOTAL.gif"), 400)
+		ztest.Code(t, run(t, "/chart/TOTAL.png?theme=pink"), 400)
+		ztest.Code(t, run(t, "/chart/TOTAL.png?width=wide"), 400)
+		ztest.Code(t, run(t, "/chart/TOTAL.png?range=forever"), 400)
+		ztest.Code(t, run(t, "/chart/TOTAL.png?period-start=2020-06-18"), 400)
+	})
+}
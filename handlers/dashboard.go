@@ -6,25 +6,39 @@ package handlers
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"html/template"
 	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	nnow "github.com/jinzhu/now"
 	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/filter"
 	"zgo.at/goatcounter/widgets"
+	"zgo.at/guru"
+	"zgo.at/zdb"
 	"zgo.at/zhttp"
 	"zgo.at/zhttp/ztpl"
 	"zgo.at/zhttp/ztpl/tplfunc"
 	"zgo.at/zlog"
+	"zgo.at/zstd/zfilepath"
+	"zgo.at/zstd/znet"
 	"zgo.at/zstd/zsync"
 )
 
 const day = 24 * time.Hour
 
 func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
+	// Load view, but override this from query.
+	view, err := getView(r)
+	if err != nil {
+		return err
+	}
 	site := Site(r.Context())
 
 	// Cache much more aggressively for public displays. Don't care so much if
@@ -36,9 +50,6 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 
 	q := r.URL.Query()
 
-	// Load view, but override this from query.
-	view, _ := site.Settings.Views.Get("default")
-
 	start, end, err := getPeriod(w, r, site)
 	if err != nil {
 		zhttp.FlashError(w, err.Error())
@@ -67,11 +78,19 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 		view.Daily = true
 	}
 
-	// Get path IDs to filter first, as they're used by the widgets.
+	// Share links can have a fixed filter, which the filter from the dashboard
+	// can only narrow down.
+	share := goatcounter.GetShareToken(r.Context())
+	var filterFixed string
+	if share != nil {
+		filterFixed = share.Filter
+	}
+
+	// Parse the filter first, as it's used by the widgets.
 	var (
-		pathFilter = make(chan (struct {
-			Paths []int64
-			Err   error
+		parsedFilter = make(chan (struct {
+			Filter goatcounter.Filter
+			Err    error
 		}))
 	)
 	go func() {
@@ -80,17 +99,28 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 		l := zlog.Module("dashboard")
 
 		var (
-			f   []int64
+			f   goatcounter.Filter
 			err error
 		)
-		if view.Filter != "" {
-			f, err = goatcounter.PathFilter(r.Context(), view.Filter, true)
+		if view.Filter != "" || filterFixed != "" {
+			f, err = goatcounter.NewFilterAnd(r.Context(), filterFixed, view.Filter)
+
+			// Display the error with just the fixed filter, rather than
+			// without any filter.
+			var fErr *filter.Error
+			if errors.As(err, &fErr) && filterFixed != "" {
+				var fixedErr error
+				f, fixedErr = goatcounter.NewFilter(r.Context(), filterFixed)
+				if fixedErr != nil {
+					err = fixedErr
+				}
+			}
 		}
-		pathFilter <- struct {
-			Paths []int64
-			Err   error
+		parsedFilter <- struct {
+			Filter goatcounter.Filter
+			Err    error
 		}{f, err}
-		l.Since("pathfilter")
+		l.Since("filter")
 	}()
 
 	subs, err := site.ListSubs(r.Context())
@@ -115,10 +145,19 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 		AsText:      view.AsText,
 	}
 
-	f := <-pathFilter
-	args.PathFilter, err = f.Paths, f.Err
+	f := <-parsedFilter
+	args.Filter, err = f.Filter, f.Err
+	var filterErr string
 	if err != nil {
-		return err
+		var fErr *filter.Error
+		if !errors.As(err, &fErr) {
+			return err
+		}
+		// Display the error, and the dashboard without the filter.
+		if q.Get("reload") != "" {
+			return zhttp.JSON(w, map[string]string{"filter_error": fErr.Error()})
+		}
+		filterErr = fErr.Error()
 	}
 
 	// Load widgets data from the database.
@@ -127,6 +166,9 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 		params |= widgets.ShowRefs
 	}
 	wid := widgets.FromSiteWidgets(site.Settings.Widgets, params)
+	if share != nil {
+		wid = shareWidgets(share, wid)
+	}
 
 	func() {
 		var wg sync.WaitGroup
@@ -182,7 +224,7 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 				if tplName == "" { // Some data doesn't have a template.
 					return
 				}
-				tpl, err := ztpl.ExecuteString(tplName, tplData)
+				tpl, err := widgets.ExecuteTemplate(w, tplName, tplData)
 				if err != nil {
 					zlog.Module("dashboard").FieldsRequest(r).Error(err)
 					w.SetHTML(template.HTML("template rendering error: " + template.HTMLEscapeString(err.Error())))
@@ -220,14 +262,139 @@ func (h backend) dashboard(w http.ResponseWriter, r *http.Request) error {
 
 		PeriodStart    time.Time
 		PeriodEnd      time.Time
-		PathFilter     []int64
+		Filter         goatcounter.Filter
+		FilterError    string
+		FilterFixed    string
 		ForcedDaily    bool
 		Widgets        widgets.List
 		View           goatcounter.View
+		Views          []string
 		TotalUnique    int
 		TotalUniqueUTC int
-	}{newGlobals(w, r), cd, subs, showRefs, start, end, args.PathFilter,
-		forcedDaily, wid, view, shared.TotalUnique, shared.TotalUniqueUTC})
+	}{newGlobals(w, r), cd, subs, showRefs, start, end, args.Filter,
+		filterErr, filterFixed, forcedDaily, wid, view, site.Settings.Views.Names(),
+		shared.TotalUnique, shared.TotalUniqueUTC})
+}
+
+// Remove all widgets the share token doesn't allow; the "data-only" widgets are
+// always kept since the others depend on them.
+func shareWidgets(share *goatcounter.ShareToken, wid widgets.List) widgets.List {
+	l := make(widgets.List, 0, len(wid))
+	for _, w := range wid {
+		if w.Type() == "data-only" || share.HasWidget(w.Name()) {
+			l = append(l, w)
+		}
+	}
+	return l
+}
+
+// Maximum number of rows in downloads.
+const downloadLimit = 100_000
+
+// download the data for a widget as CSV or JSON, for the current range and
+// filter.
+func (h backend) download(w http.ResponseWriter, r *http.Request) error {
+	_, err := getView(r)
+	if err != nil {
+		return err
+	}
+	site := Site(r.Context())
+
+	name, ext := zfilepath.SplitExt(chi.URLParam(r, "file"))
+	if ext != "csv" && ext != "json" {
+		return guru.Errorf(400, "unknown extension: %q", ext)
+	}
+	sw := site.Settings.Widgets.Get(name)
+	if sw == nil {
+		return guru.Errorf(404, "unknown widget: %q", name)
+	}
+	err = shareAllowed(r, name)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := getPeriod(w, r, site)
+	if err != nil {
+		return err
+	}
+	if start.IsZero() || end.IsZero() {
+		return guru.New(400, "need to set period-start and period-end")
+	}
+	filter, err := getFilter(r)
+	if err != nil {
+		return err
+	}
+	daily, forcedDaily := getDaily(r, start, end)
+
+	wid := widgets.FromSiteWidgets(goatcounter.Widgets{sw}, widgets.FilterInternal)
+	if len(wid) == 0 {
+		return guru.Errorf(404, "unknown widget: %q", name)
+	}
+	widget := wid[0]
+	err = widget.GetData(r.Context(), widgets.Args{
+		Start:       start,
+		End:         end,
+		Filter:      filter,
+		Daily:       daily,
+		ForcedDaily: forcedDaily,
+		Limit:       downloadLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	tz := site.Settings.Timezone.Loc()
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="goatcounter-%s-%s-%s.%s"`,
+		name, start.In(tz).Format("2006-01-02"), end.In(tz).Format("2006-01-02"), ext))
+
+	if ext == "json" {
+		return zhttp.JSON(w, struct {
+			Widget string         `json:"widget"`
+			Start  time.Time      `json:"start"`
+			End    time.Time      `json:"end"`
+			Data   widgets.Widget `json:"data"`
+		}{name, start, end, widget})
+	}
+
+	t, ok := widget.(widgets.Tabler)
+	if !ok {
+		return guru.Errorf(400, "widget %q can't be downloaded as CSV", name)
+	}
+	header, rows := t.Table()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	c := csv.NewWriter(w)
+	c.Write(header)
+	c.WriteAll(rows)
+	return c.Error()
+}
+
+// Set the cookie for the share token, and redirect to the dashboard.
+func (h backend) share(w http.ResponseWriter, r *http.Request) error {
+	var t goatcounter.ShareToken
+	err := t.ByToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if zdb.ErrNoRows(err) {
+			return guru.New(404, "unknown share link; perhaps it has expired or was revoked?")
+		}
+		return err
+	}
+
+	exp := goatcounter.Now().Add(365 * day)
+	if t.ExpiresAt != nil {
+		exp = *t.ExpiresAt
+	}
+	http.SetCookie(w, &http.Cookie{
+		Domain:   znet.RemovePort(cookieDomain(Site(r.Context()), r)),
+		Name:     cookieShare,
+		Value:    t.Token,
+		Path:     "/",
+		Expires:  exp,
+		HttpOnly: true,
+		Secure:   zhttp.CookieSecure,
+		SameSite: zhttp.CookieSameSite,
+	})
+	return zhttp.SeeOther(w, "/")
 }
 
 // Get a time range; the return value is always in UTC, and is the UTC day range
@@ -5,9 +5,16 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -30,6 +37,33 @@ func TestDashboard(t *testing.T) {
 			wantCode: 200,
 			wantBody: "<strong>No data received</strong>",
 		},
+		{
+			name: "view",
+			setup: func(ctx context.Context, t *testing.T) {
+				site := goatcounter.MustGetSite(ctx)
+				site.Settings.Views = append(site.Settings.Views, goatcounter.View{
+					Name:    "docs",
+					Period:  "month",
+					Widgets: goatcounter.Widgets{{"name": "totalpages", "on": true}},
+				})
+				err := site.Update(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			path:     "/?view=docs",
+			router:   newBackend,
+			auth:     true,
+			wantCode: 200,
+			wantBody: `<option selected value="docs">docs</option>`,
+		},
+		{
+			name:     "unknown-view",
+			path:     "/?view=nope",
+			router:   newBackend,
+			auth:     true,
+			wantCode: 404,
+		},
 	}
 
 	for _, tt := range tests {
@@ -376,6 +410,9 @@ func TestDashboardBarChart(t *testing.T) {
 					t.Fatal(err)
 				}
 
+				// The new/returning split is stored per day in UTC, so it's not
+				// shown for all the timezones here.
+				out = regexp.MustCompile(`\s*<br/><small class="visitors-split".*?</small>`).ReplaceAllString(out, "")
 				out = strings.TrimSpace(regexp.MustCompile(`[ \t]+<`).ReplaceAllString(out, "<"))
 				out = strings.TrimSpace(regexp.MustCompile(`[ \t]+`).ReplaceAllString(out, " "))
 				out = regexp.MustCompile(`(?m)^\s+$`).ReplaceAllString(out, "")
@@ -552,3 +589,187 @@ func TestTimeRange(t *testing.T) {
 		})
 	}
 }
+
+func TestDashboardShare(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	tok := goatcounter.ShareToken{Name: "client", Widgets: goatcounter.Strings{"totalpages"}}
+	err := tok.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := goatcounter.ShareToken{Name: "expired"}
+	err = expired.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zdb.Exec(ctx, `update share_tokens set expires_at=$1 where share_token_id=$2`,
+		goatcounter.Now().Add(-time.Hour).Truncate(time.Second), expired.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not logged in.
+	noUser := goatcounter.WithUser(ctx, &goatcounter.User{})
+	run := func(t *testing.T, path, cookie string) *httptest.ResponseRecorder {
+		r, rr := newTest(noUser, "GET", path, nil)
+		if cookie != "" {
+			r.AddCookie(&http.Cookie{Name: cookieShare, Value: cookie})
+		}
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	t.Run("link", func(t *testing.T) {
+		rr := run(t, "/share/"+tok.Token, "")
+		ztest.Code(t, rr, 303)
+		if c := rr.Header().Get("Set-Cookie"); !strings.Contains(c, "share="+tok.Token) {
+			t.Errorf("wrong cookie: %q", c)
+		}
+
+		rr = run(t, "/share/"+expired.Token, "")
+		ztest.Code(t, rr, 404)
+	})
+
+	t.Run("dashboard", func(t *testing.T) {
+		ztest.Code(t, run(t, "/", ""), 303)
+		ztest.Code(t, run(t, "/", expired.Token), 303)
+
+		rr := run(t, "/", tok.Token)
+		ztest.Code(t, rr, 200)
+		if !strings.Contains(rr.Body.String(), `<div class="totals">`) {
+			t.Error("no totals")
+		}
+		if strings.Contains(rr.Body.String(), `<div class="pages-list`) {
+			t.Error("has pages")
+		}
+
+		ztest.Code(t, run(t, "/pages-more?max=10", tok.Token), 403)
+
+		var got goatcounter.ShareToken
+		err := got.ByID(ctx, tok.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.LastUsedAt == nil {
+			t.Error("LastUsedAt not set")
+		}
+	})
+	t.Run("filter", func(t *testing.T) {
+		gctest.StoreHits(ctx, t, false,
+			goatcounter.Hit{Path: "/a"},
+			goatcounter.Hit{Path: "/ab"},
+			goatcounter.Hit{Path: "/b"})
+
+		tok := goatcounter.ShareToken{Name: "filter", Filter: "path:/a*"}
+		err := tok.Insert(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			filter string
+			want   []string
+		}{
+			{"", []string{"/a", "/ab"}},
+			{"path:/ab", []string{"/ab"}},
+			{"path:/b", nil},
+			{"path:/b OR path:/a*", []string{"/a", "/ab"}},
+			{") OR (path:/b", []string{"/a", "/ab"}}, // Syntax error.
+		}
+		for _, tt := range tests {
+			t.Run(tt.filter, func(t *testing.T) {
+				rr := run(t, "/?filter="+url.QueryEscape(tt.filter), tok.Token)
+				ztest.Code(t, rr, 200)
+
+				var got []string
+				for _, m := range regexp.MustCompile(`rlink" title="(.+?)"`).FindAllStringSubmatch(rr.Body.String(), -1) {
+					got = append(got, m[1])
+				}
+				got = zstring.Uniq(got)
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("\ngot:  %q\nwant: %q", got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestDashboardDownload(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:00:00")
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		goatcounter.Hit{Path: "/a", Ref: "http://example.com", FirstVisit: true},
+		goatcounter.Hit{Path: "/a", Ref: "http://example.com"},
+		goatcounter.Hit{Path: "/b", Ref: "http://example.org", FirstVisit: true})
+
+	run := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		r, rr := newTest(ctx, "GET", path+"?period-start=2020-06-18&period-end=2020-06-18", nil)
+		login(t, r)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		rr := run(t, "/download/toprefs.csv")
+		ztest.Code(t, rr, 200)
+		want := "Referrer,Visitors,Pageviews\nexample.com,1,2\nexample.org,1,1\n"
+		if rr.Body.String() != want {
+			t.Errorf("\ngot:  %q\nwant: %q", rr.Body.String(), want)
+		}
+		if h := rr.Header().Get("Content-Disposition"); h != `attachment; filename="goatcounter-toprefs-2020-06-18-2020-06-18.csv"` {
+			t.Errorf("wrong Content-Disposition: %q", h)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rr := run(t, "/download/pages.json")
+		ztest.Code(t, rr, 200)
+		var got struct {
+			Widget string `json:"widget"`
+		}
+		err := json.Unmarshal(rr.Body.Bytes(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Widget != "pages" {
+			t.Errorf("wrong body: %s", rr.Body.String())
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		rr := run(t, "/download/pages.csv")
+		ztest.Code(t, rr, 200)
+		want := "Path,Title,Event,Visitors,Pageviews\n/b,,false,1,1\n/a,,false,1,2\n"
+		if rr.Body.String() != want {
+			t.Errorf("\ngot:  %q\nwant: %q", rr.Body.String(), want)
+		}
+	})
+
+	t.Run("share", func(t *testing.T) {
+		tok := goatcounter.ShareToken{Name: "client", Widgets: goatcounter.Strings{"totalpages"}}
+		err := tok.Insert(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		share := func(t *testing.T, path string) *httptest.ResponseRecorder {
+			noUser := goatcounter.WithUser(ctx, &goatcounter.User{})
+			r, rr := newTest(noUser, "GET", path+"?period-start=2020-06-18&period-end=2020-06-18", nil)
+			r.AddCookie(&http.Cookie{Name: cookieShare, Value: tok.Token})
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			return rr
+		}
+
+		ztest.Code(t, share(t, "/download/totalpages.json"), 200)
+		ztest.Code(t, share(t, "/download/pages.csv"), 403)
+		ztest.Code(t, share(t, "/download/pages.json"), 403)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ztest.Code(t, run(t, "/download/nope.csv"), 404)
+		ztest.Code(t, run(t, "/download/pages.xml"), 400)
+	})
+}
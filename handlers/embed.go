@@ -0,0 +1,187 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"zgo.at/errors"
+	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/widgets"
+	"zgo.at/guru"
+	"zgo.at/zhttp"
+	"zgo.at/zhttp/header"
+	"zgo.at/zstd/zfilepath"
+)
+
+// embed renders a single widget, for embedding on other sites in a frame or
+// loading with JavaScript.
+type embed struct{ domainStatic string }
+
+func (h embed) mount(r chi.Router, domainStatic string) {
+	h.domainStatic = domainStatic
+	r.With(keyAuth).Get("/embed/*", zhttp.Wrap(h.widget))
+}
+
+// headers sets the frame-ancestors and CORS headers from the site's
+// EmbedOrigins setting.
+func (h embed) headers(w http.ResponseWriter, r *http.Request, site *goatcounter.Site) {
+	ds := []string{header.CSPSourceSelf}
+	if h.domainStatic != "" {
+		ds = append(ds, h.domainStatic)
+	}
+	fa := []string{header.CSPSourceSelf}
+	for _, o := range site.Settings.EmbedOrigins {
+		if o == "*" {
+			fa = []string{"*"}
+			break
+		}
+		fa = append(fa, o)
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	header.SetCSP(w.Header(), header.CSPArgs{
+		header.CSPDefaultSrc:     {header.CSPSourceNone},
+		header.CSPImgSrc:         append(ds, "data:"),
+		header.CSPScriptSrc:      ds,
+		header.CSPStyleSrc:       append(ds, header.CSPSourceUnsafeInline),
+		header.CSPFontSrc:        ds,
+		header.CSPConnectSrc:     {header.CSPSourceSelf},
+		header.CSPFrameAncestors: fa,
+	})
+
+	if o := r.Header.Get("Origin"); o != "" && site.Settings.EmbedAllowed(o) {
+		w.Header().Set("Access-Control-Allow-Origin", o)
+		w.Header().Add("Vary", "Origin")
+	}
+}
+
+// auth checks if this widget can be viewed; this is allowed for logged in
+// users, with a share token in the token parameter or the share cookie, or if
+// the site is public.
+func (h embed) auth(r *http.Request, site *goatcounter.Site) error {
+	if u := goatcounter.GetUser(r.Context()); u != nil && u.ID > 0 {
+		return nil
+	}
+	if tok := r.URL.Query().Get("token"); tok != "" {
+		if !shareAuthToken(r, tok) {
+			return guru.New(403, "unknown token; perhaps it has expired or was revoked?")
+		}
+		return nil
+	}
+	if shareAuth(r) || site.Settings.Public {
+		return nil
+	}
+	return guru.New(403, "need to make the site public or use a share link token to embed widgets")
+}
+
+func (h embed) widget(w http.ResponseWriter, r *http.Request) error {
+	site := Site(r.Context())
+	h.headers(w, r, site)
+	err := h.auth(r, site)
+	if err != nil {
+		return err
+	}
+
+	name, ext := zfilepath.SplitExt(chi.URLParam(r, "*"))
+	if ext != "" && ext != "html" && ext != "json" {
+		return guru.Errorf(400, "unknown extension: %q", ext)
+	}
+	view, err := getView(r)
+	if err != nil {
+		return err
+	}
+	site = Site(r.Context())
+	sw := site.Settings.Widgets.Get(name)
+	if sw == nil {
+		return guru.Errorf(404, "unknown widget: %q", name)
+	}
+	err = shareAllowed(r, name)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := getPeriod(w, r, site)
+	if err != nil {
+		return err
+	}
+	if start.IsZero() || end.IsZero() {
+		start, end, err = timeRange(view.Period, site.Settings.Timezone.Loc(), site.Settings.SundayStartsWeek)
+		if err != nil {
+			return err
+		}
+	}
+	filter, err := getFilter(r)
+	if err != nil {
+		return err
+	}
+	daily, forcedDaily := getDaily(r, start, end)
+	asText := r.URL.Query().Get("as-text")
+
+	args := widgets.Args{
+		Start:       start,
+		End:         end,
+		Filter:      filter,
+		Daily:       daily,
+		ForcedDaily: forcedDaily,
+		AsText:      asText == "on" || asText == "true",
+	}
+
+	wid := widgets.FromSiteWidgets(goatcounter.Widgets{sw}, 0)
+	for _, ww := range wid {
+		err := ww.GetData(r.Context(), args)
+		if err != nil {
+			return err
+		}
+	}
+
+	tc, ok := wid.Get("totalcount").(*widgets.TotalCount)
+	if !ok {
+		return errors.Errorf("embed.widget: no totalcount widget for %q", name)
+	}
+	shared := widgets.SharedData{Args: args, Site: site,
+		Total: tc.Total, TotalUnique: tc.TotalUnique, TotalUniqueUTC: tc.TotalUniqueUTC,
+		TotalEvents: tc.TotalEvents, TotalEventsUnique: tc.TotalEventsUnique}
+	if p, ok := wid.Get("pages").(*widgets.Pages); ok {
+		m, ok := wid.Get("max").(*widgets.Max)
+		if !ok {
+			return errors.Errorf("embed.widget: no max widget for %q", name)
+		}
+		p.Max = m.Max
+	}
+	widget := wid.Get(name)
+	if widget == nil {
+		return guru.Errorf(404, "unknown widget: %q", name)
+	}
+
+	if ext == "json" {
+		return zhttp.JSON(w, struct {
+			Widget            string         `json:"widget"`
+			Start             time.Time      `json:"start"`
+			End               time.Time      `json:"end"`
+			Total             int            `json:"total"`
+			TotalUnique       int            `json:"total_unique"`
+			TotalEvents       int            `json:"total_events"`
+			TotalEventsUnique int            `json:"total_events_unique"`
+			Data              widgets.Widget `json:"data"`
+		}{name, start, end, shared.Total, shared.TotalUnique, shared.TotalEvents,
+			shared.TotalEventsUnique, widget})
+	}
+
+	tplName, tplData := widget.RenderHTML(r.Context(), shared)
+	tpl, err := widgets.ExecuteTemplate(widget, tplName, tplData)
+	if err != nil {
+		return err
+	}
+	widget.SetHTML(template.HTML(tpl))
+
+	return zhttp.Template(w, "embed.gohtml", struct {
+		Globals
+		Widget widgets.Widget
+	}{newGlobals(w, r), widget})
+}
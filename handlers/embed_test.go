@@ -0,0 +1,102 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/zdb"
+	"zgo.at/zstd/ztest"
+)
+
+func TestEmbed(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	site := goatcounter.MustGetSite(ctx)
+	site.Settings.EmbedOrigins = goatcounter.Strings{"https://example.com"}
+	err := site.Update(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok := goatcounter.ShareToken{Name: "embed", Widgets: goatcounter.Strings{"totalpages"}}
+	err = tok.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(t *testing.T, loggedIn bool, path string) *httptest.ResponseRecorder {
+		r, rr := newTest(ctx, "GET", path, nil)
+		r.Header.Set("Origin", "https://example.com")
+		if loggedIn {
+			login(t, r)
+		}
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	t.Run("html", func(t *testing.T) {
+		rr := run(t, true, "/embed/totalpages")
+		ztest.Code(t, rr, 200)
+		if !strings.Contains(rr.Body.String(), `<div class="totals">`) {
+			t.Error("no totals")
+		}
+		if h := rr.Header().Get("Content-Security-Policy"); !strings.Contains(h, "frame-ancestors 'self' https://example.com") {
+			t.Errorf("wrong CSP: %q", h)
+		}
+		if h := rr.Header().Get("X-Frame-Options"); h != "" {
+			t.Errorf("X-Frame-Options set: %q", h)
+		}
+		if h := rr.Header().Get("Access-Control-Allow-Origin"); h != "https://example.com" {
+			t.Errorf("wrong Access-Control-Allow-Origin: %q", h)
+		}
+	})
+
+	t.Run("worldmap", func(t *testing.T) {
+		gctest.StoreHits(ctx, t, false, goatcounter.Hit{Location: "NL-NB", FirstVisit: true})
+
+		rr := run(t, true, "/embed/worldmap")
+		ztest.Code(t, rr, 200)
+		if !strings.Contains(rr.Body.String(), `<path class="country" data-name="NL"`) {
+			t.Errorf("no country in map: %s", rr.Body.String())
+		}
+		if n := strings.Count(rr.Body.String(), `<path class="empty"`); n < 100 {
+			t.Errorf("only %d countries without visitors in map", n)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rr := run(t, true, "/embed/browsers.json")
+		ztest.Code(t, rr, 200)
+		var got struct {
+			Widget string `json:"widget"`
+		}
+		err := json.Unmarshal(rr.Body.Bytes(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Widget != "browsers" {
+			t.Errorf("wrong body: %s", rr.Body.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ztest.Code(t, run(t, true, "/embed/nope"), 404)
+		ztest.Code(t, run(t, true, "/embed/totalcount"), 404)
+		ztest.Code(t, run(t, true, "/embed/pages.xml"), 400)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		ztest.Code(t, run(t, false, "/embed/totalpages"), 403)
+		ztest.Code(t, run(t, false, "/embed/totalpages?token=nope"), 403)
+		ztest.Code(t, run(t, false, "/embed/totalpages?token="+tok.Token), 200)
+		ztest.Code(t, run(t, false, "/embed/browsers?token="+tok.Token), 403)
+	})
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"zgo.at/goatcounter"
+	"zgo.at/json"
 	"zgo.at/zhttp"
 	"zgo.at/zlog"
 	"zgo.at/zstd/zfs"
+	"zgo.at/zstd/zjson"
 	"zgo.at/zstripe"
 )
 
@@ -45,6 +47,8 @@ type Globals struct {
 	GoatcounterCom bool
 	Dev            bool
 	Port           string
+	SSO            bool
+	PasswordLogin  bool
 }
 
 func newGlobals(w http.ResponseWriter, r *http.Request) Globals {
@@ -61,6 +65,8 @@ func newGlobals(w http.ResponseWriter, r *http.Request) Globals {
 		GoatcounterCom: goatcounter.Config(r.Context()).GoatcounterCom,
 		Dev:            goatcounter.Config(r.Context()).Dev,
 		Port:           goatcounter.Config(r.Context()).Port,
+		SSO:            goatcounter.Config(r.Context()).OIDC != nil,
+		PasswordLogin:  passwordLogin(r.Context()),
 	}
 	if g.User == nil {
 		g.User = &goatcounter.User{}
@@ -80,6 +86,34 @@ func newGlobals(w http.ResponseWriter, r *http.Request) Globals {
 	return g
 }
 
+// audit records an event in the audit log for the site. Errors are logged but
+// otherwise ignored, as failing to record the event shouldn't fail the action.
+func audit(r *http.Request, site *goatcounter.Site, action string, data goatcounter.AuditData) {
+	e := goatcounter.AuditEvent{SiteID: site.ID, Action: action, Data: data, IP: r.RemoteAddr}
+	err := e.Insert(r.Context())
+	if err != nil {
+		zlog.FieldsRequest(r).Error(err)
+	}
+}
+
+// auditSite gets the site's settings and domains to record changes in the
+// audit log with auditSettings. This creates a copy, as the settings contain
+// slices that may get modified in-place.
+func auditSite(site *goatcounter.Site) json.RawMessage {
+	var m map[string]interface{}
+	zjson.MustUnmarshal(zjson.MustMarshal(site.Settings), &m)
+	m["cname"], m["link_domain"] = site.Cname, site.LinkDomain
+	return zjson.MustMarshal(m)
+}
+
+// auditSettings records the changes since before, as created by auditSite.
+func auditSettings(r *http.Request, site *goatcounter.Site, before json.RawMessage) {
+	diff := goatcounter.AuditDiff(before, auditSite(site))
+	if len(diff) > 0 {
+		audit(r, site, "site.settings", diff)
+	}
+}
+
 func NewStatic(r chi.Router, dev bool) chi.Router {
 	var cache map[string]int
 	if !dev {
@@ -27,6 +27,7 @@ import (
 	"zgo.at/zlog"
 	"zgo.at/zstd/zgo"
 	"zgo.at/zstd/zjson"
+	"zgo.at/zstd/znet"
 	"zgo.at/zstd/zruntime"
 	"zgo.at/zstd/ztest"
 )
@@ -70,10 +71,10 @@ func TestMain(m *testing.M) {
 		// Tested in tpl_test.go
 		"email_export_done.gotxt", "email_forgot_site.gotxt", "email_import_done.gotxt",
 		"email_import_error.gotxt", "email_password_reset.gotxt", "email_verify.gotxt",
+		"email_invite.gotxt", "email_recovery_code.gotxt", "email_login_locked.gotxt",
 
 		"billing.gohtml",                             // TODO: hard to test; requires a browser.
 		"user_forgot_pw.gohtml", "user_reset.gohtml", // TODO: only works if not logged in.
-		"totp.gohtml", // TODO: part of TOTP flow; kinda tricky to test.
 	))
 }
 
@@ -160,16 +161,12 @@ func login(t *testing.T, r *http.Request) {
 
 	u := goatcounter.GetUser(r.Context())
 
-	// Login user
-	err := u.Login(r.Context())
+	// Login user; the RealIP middleware would normally remove the port.
+	s, err := u.Login(r.Context(), r.UserAgent(), znet.RemovePort(r.RemoteAddr))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if u.LoginToken == nil {
-		t.Fatal("u.LoginToken is nil? Should never happen!")
-	}
-
 	// Set CSRF token.
 	// TODO: only works for form requests, which is okay as zhttp csrf checking
 	// only works for forms for now.
@@ -179,7 +176,7 @@ func login(t *testing.T, r *http.Request) {
 	}
 	r.Form.Set("csrf", *u.Token)
 
-	r.Header.Set("Cookie", "key="+*u.LoginToken)
+	r.Header.Set("Cookie", "key="+s.Token)
 }
 
 func newTest(ctx context.Context, method, path string, body io.Reader) (*http.Request, *httptest.ResponseRecorder) {
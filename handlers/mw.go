@@ -32,17 +32,14 @@ var (
 		return guru.Errorf(303, "/user/new")
 	}
 
-	loggedIn = auth.Filter(func(w http.ResponseWriter, r *http.Request) error {
+	loggedIn = requireAccess(goatcounter.AccessReadOnly)
+
+	loggedInOrPublic = auth.Filter(func(w http.ResponseWriter, r *http.Request) error {
 		u := goatcounter.GetUser(r.Context())
 		if u != nil && u.ID > 0 {
 			return nil
 		}
-		return redirect(w, r)
-	})
-
-	loggedInOrPublic = auth.Filter(func(w http.ResponseWriter, r *http.Request) error {
-		u := goatcounter.GetUser(r.Context())
-		if (u != nil && u.ID > 0) || Site(r.Context()).Settings.Public {
+		if shareAuth(r) || Site(r.Context()).Settings.Public {
 			return nil
 		}
 		return redirect(w, r)
@@ -55,13 +52,152 @@ var (
 		return guru.Errorf(404, "")
 	})
 
-	keyAuth = auth.Add(func(ctx context.Context, key string) (auth.User, error) {
+	cookieAuth = auth.Add(func(ctx context.Context, key string) (auth.User, error) {
 		u := &goatcounter.User{}
 		err := u.ByTokenAndSite(ctx, key)
 		return u, err
 	})
 )
 
+// keyAuth loads the user from the header set by the authentication proxy if
+// it's configured and the request comes from the proxy, or from the login
+// cookie otherwise.
+func keyAuth(next http.Handler) http.Handler {
+	cookie := cookieAuth(sessionSeen(next))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p := goatcounter.Config(r.Context()).AuthProxy; p != nil {
+			ok, err := proxyAuth(r, p)
+			if err != nil {
+				zhttp.ErrPage(w, r, err)
+				return
+			}
+			if ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		cookie.ServeHTTP(w, r)
+	})
+}
+
+// sessionSeen records that the login session from the cookie was used.
+func sessionSeen(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u := goatcounter.GetUser(r.Context()); u != nil && u.ID > 0 {
+			if c, err := r.Cookie("key"); err == nil {
+				s := goatcounter.UserSession{Token: c.Value}
+				err := s.Seen(r.Context(), r.UserAgent(), r.RemoteAddr)
+				if err != nil {
+					zlog.FieldsRequest(r).Error(err)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// proxyAuth adds the user from the authentication proxy's header to the
+// request context; it returns false if the request didn't come from the proxy
+// or if the header isn't set.
+func proxyAuth(r *http.Request, p *goatcounter.AuthProxy) (bool, error) {
+	peer, _ := r.Context().Value(keyPeer).(string)
+	if !p.Trusted(peer) {
+		return false, nil
+	}
+	email := strings.TrimSpace(r.Header.Get(p.Header))
+	if email == "" {
+		return false, nil
+	}
+
+	ctx := r.Context()
+	u := &goatcounter.User{}
+	err := u.ByEmail(ctx, email)
+	if err != nil {
+		if !zdb.ErrNoRows(err) {
+			return false, err
+		}
+		if !p.Create {
+			return false, guru.Errorf(http.StatusForbidden, "%q doesn't have access to this site", email)
+		}
+		u = &goatcounter.User{Email: email, EmailVerified: true}
+		err = u.Insert(ctx, true)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Forms need a CSRF token, which is normally created on login.
+	if u.Token == nil {
+		err := u.NewCSRFToken(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	*r = *r.WithContext(goatcounter.WithUser(ctx, u))
+	return true, nil
+}
+
+var keyPeer = &struct{ n string }{""}
+
+// remotePeer stores the address of the connecting peer in the context, as
+// RealIP() replaces RemoteAddr with the X-Forwarded-For header, which can't be
+// trusted to check if the request came from the authentication proxy.
+func remotePeer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), keyPeer, r.RemoteAddr)))
+	})
+}
+
+// requireAccess allows only logged in users with at least the access level a.
+func requireAccess(a goatcounter.UserAccess) func(http.Handler) http.Handler {
+	return auth.Filter(func(w http.ResponseWriter, r *http.Request) error {
+		u := goatcounter.GetUser(r.Context())
+		if u == nil || u.ID == 0 {
+			return redirect(w, r)
+		}
+		if !u.HasAccess(a) {
+			return guru.Errorf(http.StatusForbidden, "you need %q access to do this", a.Label())
+		}
+		return nil
+	})
+}
+
+const cookieShare = "share"
+
+// shareAuth loads the share token from the cookie, and adds it to the request
+// context.
+//
+// Returns false if there is no cookie or if the token is invalid or expired.
+func shareAuth(r *http.Request) bool {
+	c, err := r.Cookie(cookieShare)
+	if err != nil || c.Value == "" {
+		return false
+	}
+	return shareAuthToken(r, c.Value)
+}
+
+// shareAuthToken adds the share token to the request context; returns false if
+// the token is invalid or expired.
+func shareAuthToken(r *http.Request, token string) bool {
+	var t goatcounter.ShareToken
+	err := t.ByToken(r.Context(), token)
+	if err != nil {
+		if !zdb.ErrNoRows(err) {
+			zlog.FieldsRequest(r).Error(err)
+		}
+		return false
+	}
+
+	err = t.UpdateLastUsed(r.Context())
+	if err != nil {
+		zlog.FieldsRequest(r).Error(err)
+	}
+
+	*r = *r.WithContext(goatcounter.WithShareToken(r.Context(), &t))
+	return true
+}
+
 type statusWriter interface{ Status() int }
 
 func addctx(db zdb.DB, loadSite bool) func(http.Handler) http.Handler {
@@ -0,0 +1,107 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"zgo.at/goatcounter"
+	"zgo.at/zhttp"
+	"zgo.at/zstd/znet"
+	"zgo.at/zstd/zstring"
+)
+
+const cookieOverview = "overview-sites"
+
+var overviewPeriods = []string{"week", "month", "quarter", "half-year", "year"}
+
+// overview shows the totals for all sites in this account.
+func (h backend) overview(w http.ResponseWriter, r *http.Request) error {
+	site := Site(r.Context())
+
+	var sites goatcounter.Sites
+	err := sites.ForThisAccount(r.Context(), false)
+	if err != nil {
+		return err
+	}
+
+	period := r.URL.Query().Get("period")
+	if !zstring.Contains(overviewPeriods, period) {
+		period = "week"
+	}
+	start, end, err := timeRange(period, site.Settings.Timezone.Loc(), site.Settings.SundayStartsWeek)
+	if err != nil {
+		return err
+	}
+
+	selected := overviewSelected(w, r, sites)
+	include := make(goatcounter.Sites, 0, len(sites))
+	for _, s := range sites {
+		if selected[s.ID] {
+			include = append(include, s)
+		}
+	}
+
+	var o goatcounter.Overview
+	err = o.Get(r.Context(), include, start, end)
+	if err != nil {
+		return err
+	}
+
+	return zhttp.Template(w, "overview.gohtml", struct {
+		Globals
+		Sites    goatcounter.Sites
+		Selected map[int64]bool
+		Period   string
+		Periods  []string
+		Start    time.Time
+		End      time.Time
+		Overview goatcounter.Overview
+	}{newGlobals(w, r), sites, selected, period, overviewPeriods, start, end, o})
+}
+
+// overviewSelected gets the IDs of the sites to include in the overview.
+//
+// The selection is read from the "sites" parameter if the form was submitted,
+// and remembered in a cookie. IDs that aren't in sites are ignored, and all
+// sites are included if nothing is selected.
+func overviewSelected(w http.ResponseWriter, r *http.Request, sites goatcounter.Sites) map[int64]bool {
+	var ids []string
+	if _, ok := r.URL.Query()["select"]; ok {
+		ids = r.URL.Query()["sites"]
+		http.SetCookie(w, &http.Cookie{
+			Domain:   znet.RemovePort(cookieDomain(Site(r.Context()), r)),
+			Name:     cookieOverview,
+			Value:    strings.Join(ids, "-"),
+			Path:     "/overview",
+			Expires:  goatcounter.Now().Add(365 * day),
+			HttpOnly: true,
+			Secure:   zhttp.CookieSecure,
+			SameSite: zhttp.CookieSameSite,
+		})
+	} else if c, err := r.Cookie(cookieOverview); err == nil && c.Value != "" {
+		ids = strings.Split(c.Value, "-")
+	}
+
+	account := make(map[int64]bool, len(sites))
+	for _, s := range sites {
+		account[s.ID] = true
+	}
+
+	selected := make(map[int64]bool)
+	for _, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err == nil && account[n] {
+			selected[n] = true
+		}
+	}
+	if len(selected) == 0 {
+		return account
+	}
+	return selected
+}
@@ -41,44 +41,72 @@ type settings struct{}
 
 func (h settings) mount(r chi.Router) {
 	r.Get("/settings", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !goatcounter.GetUser(r.Context()).HasAccess(goatcounter.AccessSettings) {
+			zhttp.SeeOther(w, "/settings/auth")
+			return
+		}
 		zhttp.SeeOther(w, "/settings/main")
 	}))
 
-	r.Get("/settings/main", zhttp.Wrap(h.main(nil)))
-	r.Post("/settings/main", zhttp.Wrap(h.mainSave))
-	r.Get("/settings/main/ip", zhttp.Wrap(h.ip))
-	r.Get("/settings/change-code", zhttp.Wrap(h.changeCode))
-	r.Post("/settings/change-code", zhttp.Wrap(h.changeCode))
-
-	r.Get("/settings/dashboard", zhttp.Wrap(h.dashboard(nil)))
-	r.Post("/settings/dashboard", zhttp.Wrap(h.dashboardSave))
-
-	r.Get("/settings/sites", zhttp.Wrap(h.sites(nil)))
-	r.Post("/settings/sites/add", zhttp.Wrap(h.sitesAdd))
-	r.Get("/settings/sites/remove/{id}", zhttp.Wrap(h.sitesRemoveConfirm))
-	r.Post("/settings/sites/remove/{id}", zhttp.Wrap(h.sitesRemove))
-	r.Post("/settings/sites/copySettings", zhttp.Wrap(h.sitesCopySettings))
-
-	r.Get("/settings/purge", zhttp.Wrap(h.purge(nil)))
-	r.Get("/settings/purge/confirm", zhttp.Wrap(h.purgeConfirm))
-	r.Post("/settings/purge", zhttp.Wrap(h.purgeDo))
-
-	r.Get("/settings/export", zhttp.Wrap(h.export(nil)))
-	r.Get("/settings/export/{id}", zhttp.Wrap(h.exportDownload))
-	r.Post("/settings/export/import", zhttp.Wrap(h.exportImport))
-	r.With(mware.Ratelimit(mware.RatelimitOptions{
+	// Everyone can change their own password, MFA, and API tokens.
+	r.Get("/settings/auth", zhttp.Wrap(h.auth(nil)))
+
+	set := r.With(requireAccess(goatcounter.AccessSettings))
+	set.Get("/settings/main", zhttp.Wrap(h.main(nil)))
+	set.Post("/settings/main", zhttp.Wrap(h.mainSave))
+	set.Get("/settings/main/ip", zhttp.Wrap(h.ip))
+	set.Get("/settings/ref-groups", zhttp.Wrap(h.refGroups))
+	set.Post("/settings/ref-groups", zhttp.Wrap(h.refGroupsApply))
+
+	set.Get("/settings/dashboard", zhttp.Wrap(h.dashboard(nil)))
+	set.Post("/settings/dashboard", zhttp.Wrap(h.dashboardSave))
+	set.Post("/settings/view", zhttp.Wrap(h.viewSave))
+	set.Post("/settings/view/remove", zhttp.Wrap(h.viewRemove))
+
+	set.Get("/settings/annotations", zhttp.Wrap(h.annotations(nil)))
+	set.Post("/settings/annotations/add", zhttp.Wrap(h.annotationsAdd))
+	set.Post("/settings/annotations/remove/{id}", zhttp.Wrap(h.annotationsRemove))
+
+	set.Get("/settings/share", zhttp.Wrap(h.share(nil)))
+	set.Post("/settings/share/add", zhttp.Wrap(h.shareAdd))
+	set.Post("/settings/share/remove/{id}", zhttp.Wrap(h.shareRemove))
+
+	set.Get("/settings/export", zhttp.Wrap(h.export(nil)))
+	set.Get("/settings/export/{id}", zhttp.Wrap(h.exportDownload))
+	set.Post("/settings/export/import", zhttp.Wrap(h.exportImport))
+	set.With(mware.Ratelimit(mware.RatelimitOptions{
 		Client:  mware.RatelimitIP,
 		Store:   mware.NewRatelimitMemory(),
 		Limit:   mware.RatelimitLimit(1, 3600),
 		Message: "you can request only one export per hour",
 	})).Post("/settings/export", zhttp.Wrap(h.exportStart))
 
-	r.Get("/settings/auth", zhttp.Wrap(h.auth(nil)))
+	adm := r.With(requireAccess(goatcounter.AccessAdmin))
+	adm.Get("/settings/change-code", zhttp.Wrap(h.changeCode))
+	adm.Post("/settings/change-code", zhttp.Wrap(h.changeCode))
+
+	adm.Get("/settings/sites", zhttp.Wrap(h.sites(nil)))
+	adm.Post("/settings/sites/add", zhttp.Wrap(h.sitesAdd))
+	adm.Get("/settings/sites/remove/{id}", zhttp.Wrap(h.sitesRemoveConfirm))
+	adm.Post("/settings/sites/remove/{id}", zhttp.Wrap(h.sitesRemove))
+	adm.Post("/settings/sites/copySettings", zhttp.Wrap(h.sitesCopySettings))
 
-	r.Get("/settings/delete", zhttp.Wrap(h.delete(nil)))
-	r.Post("/settings/delete", zhttp.Wrap(h.deleteDo))
+	adm.Get("/settings/purge", zhttp.Wrap(h.purge(nil)))
+	adm.Get("/settings/purge/confirm", zhttp.Wrap(h.purgeConfirm))
+	adm.Post("/settings/purge", zhttp.Wrap(h.purgeDo))
 
-	r.Post("/settings/view", zhttp.Wrap(h.viewSave))
+	adm.Get("/settings/users", zhttp.Wrap(h.users(nil)))
+	adm.Post("/settings/users/add", zhttp.Wrap(h.usersAdd))
+	adm.Post("/settings/users/access/{id}", zhttp.Wrap(h.usersAccess))
+	adm.Post("/settings/users/remove/{id}", zhttp.Wrap(h.usersRemove))
+	adm.Post("/settings/users/unlock/{id}", zhttp.Wrap(h.usersUnlock))
+
+	adm.Get("/settings/audit", zhttp.Wrap(h.audit))
+
+	own := r.With(requireAccess(goatcounter.AccessOwner))
+	own.Post("/settings/users/transfer/{id}", zhttp.Wrap(h.usersTransfer))
+	own.Get("/settings/delete", zhttp.Wrap(h.delete(nil)))
+	own.Post("/settings/delete", zhttp.Wrap(h.deleteDo))
 }
 
 func (h settings) main(verr *zvalidate.Validator) zhttp.HandlerFunc {
@@ -98,7 +126,6 @@ func (h settings) mainSave(w http.ResponseWriter, r *http.Request) error {
 		Cname      string                   `json:"cname"`
 		LinkDomain string                   `json:"link_domain"`
 		Settings   goatcounter.SiteSettings `json:"settings"`
-		User       goatcounter.User         `json:"user"`
 	}{}
 	_, err := zhttp.Decode(r, &args)
 	if err != nil {
@@ -120,24 +147,13 @@ func (h settings) mainSave(w http.ResponseWriter, r *http.Request) error {
 	}
 	defer tx.Rollback()
 
-	user := goatcounter.GetUser(txctx)
-
-	emailChanged := false
-	if goatcounter.Config(r.Context()).GoatcounterCom && args.User.Email != user.Email {
-		emailChanged = true
-	}
-
-	user.Email = args.User.Email
-	err = user.Update(txctx, emailChanged)
-	if err != nil {
-		var vErr *zvalidate.Validator
-		if !errors.As(err, &vErr) {
-			return err
-		}
-		v.Sub("user", "", err)
-	}
-
 	site := Site(txctx)
+	before := auditSite(site)
+	groupsChanged := site.Settings.RefGroups.String() != args.Settings.RefGroups.String()
+	// The widgets and views are set from the dashboard and /settings/view, and
+	// aren't in this form.
+	args.Settings.Widgets = site.Settings.Widgets
+	args.Settings.Views = site.Settings.Views
 	site.Settings = args.Settings
 	site.LinkDomain = args.LinkDomain
 	if args.Cname != "" && !site.PlanCustomDomain(txctx) {
@@ -172,9 +188,7 @@ func (h settings) mainSave(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	if emailChanged {
-		sendEmailVerify(r.Context(), site, user, goatcounter.Config(r.Context()).EmailFrom)
-	}
+	auditSettings(r, site, before)
 
 	if makecert {
 		ctx := goatcounter.CopyContextValues(r.Context())
@@ -192,10 +206,51 @@ func (h settings) mainSave(w http.ResponseWriter, r *http.Request) error {
 		})
 	}
 
+	if groupsChanged && len(site.Settings.RefGroups) > 0 {
+		zhttp.Flash(w, "Saved! The referrer groups are applied to new pageviews; you can also apply them to existing pageviews below.")
+		return zhttp.SeeOther(w, "/settings/ref-groups")
+	}
+
 	zhttp.Flash(w, "Saved!")
 	return zhttp.SeeOther(w, "/settings")
 }
 
+func (h settings) refGroups(w http.ResponseWriter, r *http.Request) error {
+	var rw goatcounter.RefRewrites
+	err := rw.List(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return zhttp.Template(w, "settings_ref_groups.gohtml", struct {
+		Globals
+		Rewrites goatcounter.RefRewrites
+	}{newGlobals(w, r), rw})
+}
+
+func (h settings) refGroupsApply(w http.ResponseWriter, r *http.Request) error {
+	var rw goatcounter.RefRewrites
+	err := rw.List(r.Context())
+	if err != nil {
+		return err
+	}
+	if len(rw) == 0 {
+		zhttp.Flash(w, "Nothing to rewrite")
+		return zhttp.SeeOther(w, "/settings/main#section-tracking")
+	}
+
+	ctx := goatcounter.CopyContextValues(r.Context())
+	bgrun.Run(fmt.Sprintf("refGroups:%d", Site(ctx).ID), func() {
+		err := rw.Apply(ctx)
+		if err != nil {
+			zlog.Error(err)
+		}
+	})
+
+	zhttp.Flash(w, "Started in the background; may take about 10-20 seconds to fully process.")
+	return zhttp.SeeOther(w, "/settings/main")
+}
+
 func (h settings) changeCode(w http.ResponseWriter, r *http.Request) error {
 	if r.Method == "GET" {
 		return zhttp.Template(w, "settings_changecode.gohtml", struct {
@@ -212,10 +267,12 @@ func (h settings) changeCode(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	site := Site(r.Context())
+	old := site.Code
 	err = site.UpdateCode(r.Context(), args.Code)
 	if err != nil {
 		return err
 	}
+	audit(r, site, "site.code", goatcounter.AuditData{"old": old, "new": site.Code})
 
 	zhttp.Flash(w, "Saved!")
 	return zhttp.SeeOther(w, site.URL(r.Context())+"/settings/main")
@@ -227,12 +284,22 @@ func (h settings) ip(w http.ResponseWriter, r *http.Request) error {
 
 func (h settings) dashboard(verr *zvalidate.Validator) zhttp.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
+		site := Site(r.Context())
+		view, err := getView(r)
+		if err != nil {
+			return err
+		}
+		ss := site.Settings.WithView(view)
+
 		return zhttp.Template(w, "settings_dashboard.gohtml", struct {
 			Globals
 			Validate *zvalidate.Validator
+			View     goatcounter.View
+			Views    []string
+			Settings goatcounter.SiteSettings
 			Widgets  widgets.List
-		}{newGlobals(w, r), verr,
-			widgets.FromSiteWidgets(Site(r.Context()).Settings.Widgets, widgets.FilterInternal),
+		}{newGlobals(w, r), verr, view, site.Settings.Views.Names(), ss,
+			widgets.FromSiteWidgets(ss.Widgets, widgets.FilterInternal),
 		})
 	}
 }
@@ -244,17 +311,39 @@ func (h settings) dashboardSave(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	site := Site(r.Context())
+	before := auditSite(site)
+
+	// Widgets for named views are stored on the view; the default view uses
+	// the site's widgets.
+	viewName := r.Form.Get("view")
+	if viewName == "" {
+		viewName = "default"
+	}
+	view, vi := site.Settings.Views.Get(viewName)
+	if vi == -1 {
+		return guru.Errorf(404, "unknown view: %q", viewName)
+	}
+	isDefault := strings.EqualFold(view.Name, "default")
+	redir := "/settings/dashboard"
+	if !isDefault {
+		redir += "?view=" + url.QueryEscape(view.Name)
+	}
 
 	if r.Form.Get("reset") != "" {
-		site.Settings.Widgets = nil
+		if isDefault {
+			site.Settings.Widgets = nil
+		} else {
+			site.Settings.Views[vi].Widgets = nil
+		}
 		site.Defaults(r.Context())
 		err = site.Update(r.Context())
 		if err != nil {
 			return err
 		}
+		auditSettings(r, site, before)
 
 		zhttp.Flash(w, "Reset to defaults!")
-		return zhttp.SeeOther(w, "/settings/dashboard")
+		return zhttp.SeeOther(w, redir)
 	}
 
 	parse := make(map[string]map[string]string)
@@ -271,7 +360,7 @@ func (h settings) dashboardSave(w http.ResponseWriter, r *http.Request) error {
 		parse[name][key] = v[0]
 	}
 
-	site.Settings.Widgets = make(goatcounter.Widgets, len(parse))
+	wid := make(goatcounter.Widgets, len(parse))
 	for k, v := range parse {
 		pos, err := strconv.Atoi(v["index"])
 		if err != nil {
@@ -287,7 +376,12 @@ func (h settings) dashboardSave(w http.ResponseWriter, r *http.Request) error {
 				}
 			}
 		}
-		site.Settings.Widgets[pos] = w
+		wid[pos] = w
+	}
+	if isDefault {
+		site.Settings.Widgets = wid
+	} else {
+		site.Settings.Views[vi].Widgets = wid
 	}
 
 	err = site.Update(r.Context())
@@ -298,9 +392,10 @@ func (h settings) dashboardSave(w http.ResponseWriter, r *http.Request) error {
 		}
 		return err
 	}
+	auditSettings(r, site, before)
 
 	zhttp.Flash(w, "Saved!")
-	return zhttp.SeeOther(w, "/settings/dashboard")
+	return zhttp.SeeOther(w, redir)
 }
 
 func (h settings) sites(verr *zvalidate.Validator) zhttp.HandlerFunc {
@@ -366,6 +461,7 @@ func (h settings) sitesAdd(w http.ResponseWriter, r *http.Request) error {
 		if err != nil {
 			return err
 		}
+		audit(r, &newSite, "site.undelete", nil)
 
 		zhttp.Flash(w, "Site ‘%s’ was previously deleted; restored site with all data.", newSite.URL(r.Context()))
 		return zhttp.SeeOther(w, "/settings/sites")
@@ -389,6 +485,7 @@ func (h settings) sitesAdd(w http.ResponseWriter, r *http.Request) error {
 		zhttp.FlashError(w, err.Error())
 		return zhttp.SeeOther(w, "/settings/sites")
 	}
+	audit(r, &newSite, "site.create", nil)
 
 	zhttp.Flash(w, "Site ‘%s’ added.", newSite.URL(r.Context()))
 	return zhttp.SeeOther(w, "/settings/sites")
@@ -436,6 +533,7 @@ func (h settings) sitesRemove(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, &s, "site.delete", nil)
 
 	zhttp.Flash(w, "Site ‘%s’ removed.", s.URL(r.Context()))
 
@@ -485,17 +583,363 @@ func (h settings) sitesCopySettings(w http.ResponseWriter, r *http.Request) erro
 	}
 
 	for _, c := range copies {
+		c := c
+		before := auditSite(&c)
 		c.Settings = master.Settings
 		err := c.Update(r.Context())
 		if err != nil {
 			return err
 		}
+		auditSettings(r, &c, before)
 	}
 
 	zhttp.Flash(w, "Settings copied to the selected sites.")
 	return zhttp.SeeOther(w, "/settings/sites")
 }
 
+func (h settings) annotations(verr *zvalidate.Validator) zhttp.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		var annot goatcounter.Annotations
+		err := annot.List(r.Context())
+		if err != nil {
+			return err
+		}
+
+		return zhttp.Template(w, "settings_annotations.gohtml", struct {
+			Globals
+			Annotations goatcounter.Annotations
+			Validate    *zvalidate.Validator
+		}{newGlobals(w, r), annot, verr})
+	}
+}
+
+func (h settings) annotationsAdd(w http.ResponseWriter, r *http.Request) error {
+	var args struct {
+		At   string `json:"at"`
+		Path string `json:"path"`
+		Text string `json:"text"`
+	}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	v := zvalidate.New()
+	a := goatcounter.Annotation{Path: args.Path, Text: args.Text}
+	if args.At != "" {
+		// From <input type="datetime-local">, in the site's timezone.
+		a.At, err = time.ParseInLocation("2006-01-02T15:04", args.At,
+			Site(r.Context()).Settings.Timezone.Loc())
+		if err != nil {
+			v.Append("at", "invalid date and time")
+			return h.annotations(&v)(w, r)
+		}
+	}
+
+	err = a.Insert(r.Context())
+	if err != nil {
+		var vErr *zvalidate.Validator
+		if !errors.As(err, &vErr) {
+			return err
+		}
+		return h.annotations(vErr)(w, r)
+	}
+	audit(r, Site(r.Context()), "annotation.create", goatcounter.AuditData{"id": a.ID, "path": a.Path, "text": a.Text})
+
+	zhttp.Flash(w, "Annotation added.")
+	return zhttp.SeeOther(w, "/settings/annotations")
+}
+
+func (h settings) annotationsRemove(w http.ResponseWriter, r *http.Request) error {
+	v := zvalidate.New()
+	id := v.Integer("id", chi.URLParam(r, "id"))
+	if v.HasErrors() {
+		return v
+	}
+
+	var a goatcounter.Annotation
+	err := a.ByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	err = a.Delete(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "annotation.delete", goatcounter.AuditData{"id": a.ID, "path": a.Path, "text": a.Text})
+
+	zhttp.Flash(w, "Annotation removed.")
+	return zhttp.SeeOther(w, "/settings/annotations")
+}
+
+func (h settings) share(verr *zvalidate.Validator) zhttp.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		var tokens goatcounter.ShareTokens
+		err := tokens.List(r.Context())
+		if err != nil {
+			return err
+		}
+
+		return zhttp.Template(w, "settings_share.gohtml", struct {
+			Globals
+			ShareTokens goatcounter.ShareTokens
+			Widgets     widgets.List
+			Validate    *zvalidate.Validator
+		}{newGlobals(w, r), tokens,
+			widgets.FromSiteWidgets(Site(r.Context()).Settings.Widgets, widgets.FilterInternal),
+			verr})
+	}
+}
+
+func (h settings) shareAdd(w http.ResponseWriter, r *http.Request) error {
+	var args struct {
+		Name      string   `json:"name"`
+		Filter    string   `json:"filter"`
+		Widgets   []string `json:"widgets"`
+		ExpiresAt string   `json:"expires_at"`
+	}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	t := goatcounter.ShareToken{
+		Name:    args.Name,
+		Filter:  args.Filter,
+		Widgets: args.Widgets,
+	}
+	if args.ExpiresAt != "" {
+		// Valid until the end of the day, in the site's timezone.
+		exp, err := time.ParseInLocation("2006-01-02", args.ExpiresAt,
+			Site(r.Context()).Settings.Timezone.Loc())
+		if err != nil {
+			v := zvalidate.New()
+			v.Append("expires_at", "invalid date")
+			return h.share(&v)(w, r)
+		}
+		exp = exp.Add(24*time.Hour - time.Second)
+		t.ExpiresAt = &exp
+	}
+
+	err = t.Insert(r.Context())
+	if err != nil {
+		var vErr *zvalidate.Validator
+		if !errors.As(err, &vErr) {
+			return err
+		}
+		return h.share(vErr)(w, r)
+	}
+	audit(r, Site(r.Context()), "share.create", goatcounter.AuditData{"id": t.ID, "name": t.Name, "filter": t.Filter})
+
+	zhttp.Flash(w, "Share link created.")
+	return zhttp.SeeOther(w, "/settings/share")
+}
+
+func (h settings) shareRemove(w http.ResponseWriter, r *http.Request) error {
+	v := zvalidate.New()
+	id := v.Integer("id", chi.URLParam(r, "id"))
+	if v.HasErrors() {
+		return v
+	}
+
+	var t goatcounter.ShareToken
+	err := t.ByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	err = t.Delete(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "share.delete", goatcounter.AuditData{"id": t.ID, "name": t.Name})
+
+	zhttp.Flash(w, "Share link revoked.")
+	return zhttp.SeeOther(w, "/settings/share")
+}
+
+func (h settings) users(verr *zvalidate.Validator) zhttp.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		var users goatcounter.Users
+		err := users.List(r.Context())
+		if err != nil {
+			return err
+		}
+
+		return zhttp.Template(w, "settings_users.gohtml", struct {
+			Globals
+			Users    goatcounter.Users
+			Accesses []goatcounter.UserAccess
+			Validate *zvalidate.Validator
+		}{newGlobals(w, r), users, goatcounter.UserAccesses[:3], verr})
+	}
+}
+
+func (h settings) usersAdd(w http.ResponseWriter, r *http.Request) error {
+	var args struct {
+		Email  string                 `json:"email"`
+		Access goatcounter.UserAccess `json:"access"`
+	}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	if args.Access == goatcounter.AccessOwner {
+		v := zvalidate.New()
+		v.Append("access", "can't invite a new owner; transfer the ownership after the invitation is accepted")
+		return h.users(&v)(w, r)
+	}
+
+	site := Site(r.Context())
+	u := goatcounter.User{Site: site.IDOrParent(), Email: args.Email, Access: args.Access}
+	err = u.Insert(r.Context(), true)
+	if err != nil {
+		var vErr *zvalidate.Validator
+		if !errors.As(err, &vErr) {
+			return err
+		}
+		return h.users(vErr)(w, r)
+	}
+
+	audit(r, site, "user.invite", goatcounter.AuditData{"user": u.Email, "access": u.Access})
+	sendEmailInvite(r.Context(), site, &u, goatcounter.GetUser(r.Context()))
+	zhttp.Flash(w, "Invitation sent to %q.", u.Email)
+	return zhttp.SeeOther(w, "/settings/users")
+}
+
+// userFromParam gets the user from the id URL parameter; users can't use this
+// to change themselves.
+func userFromParam(r *http.Request) (*goatcounter.User, error) {
+	v := zvalidate.New()
+	id := v.Integer("id", chi.URLParam(r, "id"))
+	if v.HasErrors() {
+		return nil, v
+	}
+	if id == goatcounter.GetUser(r.Context()).ID {
+		return nil, guru.New(400, "can't change your own account here")
+	}
+
+	var u goatcounter.User
+	err := u.ByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (h settings) usersAccess(w http.ResponseWriter, r *http.Request) error {
+	u, err := userFromParam(r)
+	if err != nil {
+		return err
+	}
+
+	var args struct {
+		Access goatcounter.UserAccess `json:"access"`
+	}
+	_, err = zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	old := u.Access
+	err = u.UpdateAccess(r.Context(), args.Access)
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.access", goatcounter.AuditData{"user": u.Email, "old": old, "new": u.Access})
+
+	zhttp.Flash(w, "%q is now %s.", u.Email, strings.ToLower(u.Access.Label()))
+	return zhttp.SeeOther(w, "/settings/users")
+}
+
+func (h settings) usersRemove(w http.ResponseWriter, r *http.Request) error {
+	u, err := userFromParam(r)
+	if err != nil {
+		return err
+	}
+
+	err = u.Delete(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.remove", goatcounter.AuditData{"user": u.Email})
+
+	zhttp.Flash(w, "User %q removed.", u.Email)
+	return zhttp.SeeOther(w, "/settings/users")
+}
+
+func (h settings) usersUnlock(w http.ResponseWriter, r *http.Request) error {
+	u, err := userFromParam(r)
+	if err != nil {
+		return err
+	}
+
+	err = u.Unlock(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.unlock", goatcounter.AuditData{"user": u.Email})
+
+	zhttp.Flash(w, "User %q unlocked.", u.Email)
+	return zhttp.SeeOther(w, "/settings/users")
+}
+
+func (h settings) usersTransfer(w http.ResponseWriter, r *http.Request) error {
+	u, err := userFromParam(r)
+	if err != nil {
+		return err
+	}
+
+	err = goatcounter.GetUser(r.Context()).TransferOwnership(r.Context(), u)
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.transfer", goatcounter.AuditData{"user": u.Email})
+
+	zhttp.Flash(w, "%q is now the owner.", u.Email)
+	return zhttp.SeeOther(w, "/settings/users")
+}
+
+func (h settings) audit(w http.ResponseWriter, r *http.Request) error {
+	var before int64
+	if b := r.URL.Query().Get("before"); b != "" {
+		v := zvalidate.New()
+		before = v.Integer("before", b)
+		if v.HasErrors() {
+			return v
+		}
+	}
+
+	var events goatcounter.AuditLog
+	more, err := events.List(r.Context(), before, 50)
+	if err != nil {
+		return err
+	}
+
+	var sites goatcounter.Sites
+	err = sites.ForThisAccount(r.Context(), false)
+	if err != nil {
+		return err
+	}
+	codes := make(map[int64]string, len(sites))
+	for _, s := range sites {
+		codes[s.ID] = s.Code
+	}
+
+	// ID to get the next page with.
+	var older int64
+	if more {
+		older = events[len(events)-1].ID
+	}
+
+	return zhttp.Template(w, "settings_audit.gohtml", struct {
+		Globals
+		Events goatcounter.AuditLog
+		Sites  map[int64]string
+		Older  int64
+	}{newGlobals(w, r), events, codes, older})
+}
+
 func (h settings) purge(verr *zvalidate.Validator) zhttp.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		return zhttp.Template(w, "settings_purge.gohtml", struct {
@@ -527,6 +971,7 @@ func (h settings) purgeDo(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, Site(r.Context()), "paths.purge", goatcounter.AuditData{"path_ids": paths})
 
 	ctx := goatcounter.CopyContextValues(r.Context())
 	bgrun.Run(fmt.Sprintf("purge:%d", Site(ctx).ID), func() {
@@ -643,7 +1088,7 @@ func (h settings) exportImport(w http.ResponseWriter, r *http.Request) error {
 			sendErr := blackmail.Send("GoatCounter import error",
 				blackmail.From("GoatCounter import", goatcounter.Config(r.Context()).EmailFrom),
 				blackmail.To(user.Email),
-				blackmail.BodyMustText(goatcounter.TplEmailImportError{err}.Render))
+				blackmail.BodyMustText(goatcounter.TplEmailImportError{Error: err}.Render))
 			if sendErr != nil {
 				zlog.Error(sendErr)
 			}
@@ -657,6 +1102,7 @@ func (h settings) exportImport(w http.ResponseWriter, r *http.Request) error {
 		}
 	})
 
+	audit(r, Site(r.Context()), "import", goatcounter.AuditData{"file": head.Filename, "replace": replace})
 	zhttp.Flash(w, "Import started in the background; you’ll get an email when it’s done.")
 	return zhttp.SeeOther(w, "/settings/export")
 }
@@ -676,6 +1122,8 @@ func (h settings) exportStart(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	audit(r, Site(r.Context()), "export", goatcounter.AuditData{"id": export.ID})
+
 	ctx := goatcounter.CopyContextValues(r.Context())
 	bgrun.Run(fmt.Sprintf("export web:%d", Site(ctx).ID),
 		func() { export.Run(ctx, fp, true) })
@@ -692,11 +1140,55 @@ func (h settings) auth(verr *zvalidate.Validator) zhttp.HandlerFunc {
 			return err
 		}
 
+		u := goatcounter.GetUser(r.Context())
+		var keys goatcounter.WebAuthnCredentials
+		err = keys.List(r.Context(), u.ID)
+		if err != nil {
+			return err
+		}
+
+		var codes int
+		if u.TOTPEnabled {
+			codes, err = u.RecoveryCodesLeft(r.Context())
+			if err != nil {
+				return err
+			}
+		}
+
+		var sessions goatcounter.UserSessions
+		err = sessions.List(r.Context(), u.ID)
+		if err != nil {
+			return err
+		}
+		var current int64
+		if s, err := currentSession(r); err == nil && s != nil {
+			current = s.ID
+		}
+
+		var sites goatcounter.Sites
+		err = sites.ForThisAccount(r.Context(), false)
+		if err != nil {
+			return err
+		}
+
+		usage := make(map[int64][]apiRatelimitUsage, len(tokens))
+		for _, t := range tokens {
+			usage[t.ID] = apiTokenUsage(t.ID)
+		}
+
 		return zhttp.Template(w, "settings_auth.gohtml", struct {
 			Globals
-			Validate  *zvalidate.Validator
-			APITokens goatcounter.APITokens
-		}{newGlobals(w, r), verr, tokens})
+			Validate       *zvalidate.Validator
+			APITokens      goatcounter.APITokens
+			Usage          map[int64][]apiRatelimitUsage
+			Permissions    []goatcounter.APIPermissionInfo
+			Sites          goatcounter.Sites
+			SecurityKeys   goatcounter.WebAuthnCredentials
+			RecoveryCodes  int
+			Sessions       goatcounter.UserSessions
+			CurrentSession int64
+		}{newGlobals(w, r), verr, tokens, usage, goatcounter.APIPermissionList, sites,
+			keys, codes, sessions, current})
 	}
 }
 
@@ -774,22 +1266,61 @@ func (h settings) deleteDo(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, mainSite, "site.delete", goatcounter.AuditData{"account": true})
 	return zhttp.SeeOther(w, "https://"+goatcounter.Config(r.Context()).Domain)
 }
 
+// viewSave saves the view with the given name, creating a new view if it doesn't
+// exist yet.
 func (h settings) viewSave(w http.ResponseWriter, r *http.Request) error {
 	site := Site(r.Context())
-	v, i := site.Settings.Views.Get("default") // TODO: only default view for now.
-	_, err := zhttp.Decode(r, &v)
+	before := auditSite(site)
+
+	var args goatcounter.View
+	_, err := zhttp.Decode(r, &args)
 	if err != nil {
 		return err
 	}
+	if args.Name == "" {
+		args.Name = "default"
+	}
 
-	site.Settings.Views[i] = v
+	v, i := site.Settings.Views.Get(args.Name)
+	v.Name, v.Filter, v.Daily, v.AsText, v.Period = args.Name, args.Filter, args.Daily, args.AsText, args.Period
+	if i == -1 {
+		site.Settings.Views = append(site.Settings.Views, v)
+	} else {
+		site.Settings.Views[i] = v
+	}
 	err = site.Update(r.Context())
 	if err != nil {
 		return err
 	}
+	auditSettings(r, site, before)
 
 	return zhttp.JSON(w, map[string]string{})
 }
+
+// viewRemove removes a view; the default view can't be removed.
+func (h settings) viewRemove(w http.ResponseWriter, r *http.Request) error {
+	site := Site(r.Context())
+	name := r.FormValue("name")
+	_, i := site.Settings.Views.Get(name)
+	if i == -1 {
+		return guru.Errorf(404, "unknown view: %q", name)
+	}
+	if strings.EqualFold(name, "default") {
+		return guru.New(400, "can't remove the default view")
+	}
+
+	before := auditSite(site)
+	site.Settings.Views = append(site.Settings.Views[:i], site.Settings.Views[i+1:]...)
+	err := site.Update(r.Context())
+	if err != nil {
+		return err
+	}
+	auditSettings(r, site, before)
+
+	zhttp.Flash(w, "View ‘%s’ removed.", name)
+	return zhttp.SeeOther(w, "/settings/dashboard")
+}
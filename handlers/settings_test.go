@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,6 +62,53 @@ func TestSettingsTpl(t *testing.T) {
 	}
 }
 
+func TestSettingsMainSave(t *testing.T) {
+	tests := []handlerTest{
+		{
+			setup: func(ctx context.Context, t *testing.T) {
+				site := goatcounter.MustGetSite(ctx)
+				site.Settings.Views = append(site.Settings.Views, goatcounter.View{
+					Name:    "docs",
+					Period:  "month",
+					Widgets: goatcounter.Widgets{{"name": "totalpages", "on": true}},
+				})
+				site.Settings.Widgets = goatcounter.Widgets{{"name": "pages", "on": true}}
+				err := site.Update(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			router:       newBackend,
+			path:         "/settings/main",
+			body:         map[string]string{"link_domain": "example.org"},
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			var site goatcounter.Site
+			err := site.ByID(r.Context(), goatcounter.MustGetSite(r.Context()).ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if site.LinkDomain != "example.org" {
+				t.Errorf("link_domain not saved: %q", site.LinkDomain)
+			}
+
+			v, i := site.Settings.Views.Get("docs")
+			if i == -1 || v.Period != "month" || len(v.Widgets) == 0 || v.Widgets[0]["name"] != "totalpages" {
+				t.Errorf("view not preserved: %#v", site.Settings.Views)
+			}
+			if len(site.Settings.Widgets) == 0 || site.Settings.Widgets[0]["name"] != "pages" {
+				t.Errorf("widgets not preserved: %#v", site.Settings.Widgets)
+			}
+		})
+	}
+}
+
 func TestSettingsPurge(t *testing.T) {
 	tests := []handlerTest{
 		{
@@ -100,8 +148,10 @@ func TestSettingsPurge(t *testing.T) {
 func TestSettingsSitesAdd(t *testing.T) {
 	tests := []handlerTest{
 		{
-			name:         "new site",
-			setup:        func(ctx context.Context, t *testing.T) {},
+			name: "new site",
+			setup: func(ctx context.Context, t *testing.T) {
+				goatcounter.Config(ctx).Serve = true
+			},
 			router:       newBackend,
 			path:         "/settings/sites/add",
 			body:         map[string]string{"cname": "add.example.com"},
@@ -318,3 +368,255 @@ func TestSettingsSitesRemove(t *testing.T) {
 		})
 	}
 }
+
+func TestSettingsUsers(t *testing.T) {
+	addUser := func(pending bool) func(context.Context, *testing.T) {
+		return func(ctx context.Context, t *testing.T) {
+			u := goatcounter.User{Email: "other@example.com", Access: goatcounter.AccessReadOnly}
+			if !pending {
+				u.Password = []byte("coconuts")
+			}
+			err := u.Insert(ctx, pending)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tests := []handlerTest{
+		{
+			name:         "invite",
+			router:       newBackend,
+			path:         "/settings/users/add",
+			body:         map[string]string{"email": "new@example.com", "access": "s"},
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o
+				2        new@example.com        s`,
+		},
+		{
+			name:         "invite owner",
+			router:       newBackend,
+			path:         "/settings/users/add",
+			body:         map[string]string{"email": "new@example.com", "access": "o"},
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 200,
+			wantFormBody: "can&#39;t invite a new owner",
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o`,
+		},
+		{
+			name:         "change access",
+			setup:        addUser(true),
+			router:       newBackend,
+			path:         "/settings/users/access/2",
+			body:         map[string]string{"access": "a"},
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o
+				2        other@example.com      a`,
+		},
+		{
+			name:         "remove",
+			setup:        addUser(true),
+			router:       newBackend,
+			path:         "/settings/users/remove/2",
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o`,
+		},
+		{
+			name:         "remove self",
+			router:       newBackend,
+			path:         "/settings/users/remove/1",
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 400,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o`,
+		},
+		{
+			name:         "transfer",
+			setup:        addUser(false),
+			router:       newBackend,
+			path:         "/settings/users/transfer/2",
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  a
+				2        other@example.com      o`,
+		},
+		{
+			name:         "transfer to pending",
+			setup:        addUser(true),
+			router:       newBackend,
+			path:         "/settings/users/transfer/2",
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 400,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  o
+				2        other@example.com      r`,
+		},
+		{
+			name: "admin can't transfer",
+			setup: func(ctx context.Context, t *testing.T) {
+				addUser(false)(ctx, t)
+				err := zdb.Exec(ctx, `update users set access='a' where user_id=1`)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			router:       newBackend,
+			path:         "/settings/users/transfer/2",
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 403,
+			want: `
+				user_id  email                  access
+				1        test@gctest.localhost  a
+				2        other@example.com      r`,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			got := zdb.DumpString(r.Context(), `select user_id, email, access from users order by user_id`)
+			if d := zdb.Diff(got, tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestSettingsAccess(t *testing.T) {
+	viewer := func(ctx context.Context, t *testing.T) {
+		err := zdb.Exec(ctx, `update users set access='r'`)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []handlerTest{
+		{
+			name:     "settings",
+			setup:    viewer,
+			router:   newBackend,
+			path:     "/settings",
+			auth:     true,
+			wantCode: 303,
+		},
+		{
+			name:     "auth",
+			setup:    viewer,
+			router:   newBackend,
+			path:     "/settings/auth",
+			auth:     true,
+			wantCode: 200,
+		},
+		{
+			name:     "main",
+			setup:    viewer,
+			router:   newBackend,
+			path:     "/settings/main",
+			auth:     true,
+			wantCode: 403,
+		},
+		{
+			name:     "users",
+			setup:    viewer,
+			router:   newBackend,
+			path:     "/settings/users",
+			auth:     true,
+			wantCode: 403,
+		},
+		{
+			name:     "dashboard",
+			setup:    viewer,
+			router:   newBackend,
+			path:     "/",
+			auth:     true,
+			wantCode: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, nil)
+	}
+}
+
+func TestSettingsAudit(t *testing.T) {
+	tests := []struct {
+		handlerTest
+		wantAction, wantData string
+	}{
+		{handlerTest{
+			name:         "invite",
+			router:       newBackend,
+			path:         "/settings/users/add",
+			body:         map[string]string{"email": "new@example.com", "access": "s"},
+			method:       "POST",
+			auth:         true,
+			wantFormCode: 303,
+		}, "user.invite", `{"access":"s","user":"new@example.com"}`},
+		{handlerTest{
+			name:         "view",
+			router:       newBackend,
+			path:         "/settings/view",
+			body:         map[string]string{"name": "default", "period": "30"},
+			method:       "POST",
+			auth:         true,
+			wantCode:     200,
+			wantFormCode: 200,
+		}, "site.settings", `"period":"30"`},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt.handlerTest, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			var l goatcounter.AuditLog
+			_, err := l.List(r.Context(), 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(l) != 1 {
+				t.Fatalf("wrong number of events: %d", len(l))
+			}
+			e := l[0]
+			if e.Action != tt.wantAction || e.Email != "test@gctest.localhost" || e.IP != "192.0.2.1" ||
+				!strings.Contains(e.Data.String(), tt.wantData) {
+				t.Errorf("wrong event: %#v", e)
+			}
+		})
+	}
+
+	runTest(t, handlerTest{
+		name: "list",
+		setup: func(ctx context.Context, t *testing.T) {
+			e := goatcounter.AuditEvent{Action: "user.password", IP: "192.0.2.1"}
+			err := e.Insert(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		router:   newBackend,
+		path:     "/settings/audit",
+		auth:     true,
+		wantCode: 200,
+		wantBody: "<td>user.password</td>",
+	}, nil)
+}
@@ -6,7 +6,7 @@ package handlers
 
 import (
 	"context"
-	"crypto/sha1"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,27 +16,46 @@ import (
 	"strings"
 	"time"
 
-	"code.soquee.net/otp"
 	"github.com/go-chi/chi/v5"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/net/xsrftoken"
 	"zgo.at/blackmail"
 	"zgo.at/goatcounter"
 	"zgo.at/goatcounter/bgrun"
+	"zgo.at/goatcounter/oidc"
+	"zgo.at/goatcounter/totp"
+	"zgo.at/goatcounter/webauthn"
 	"zgo.at/guru"
+	"zgo.at/zcache"
 	"zgo.at/zdb"
 	"zgo.at/zhttp"
 	"zgo.at/zhttp/auth"
 	"zgo.at/zhttp/mware"
 	"zgo.at/zlog"
+	"zgo.at/zstd/zcrypto"
+	"zgo.at/zstd/znet"
+	"zgo.at/zstd/zstring"
 	"zgo.at/zvalidate"
 )
 
 const (
 	actionTOTP = "totp"
 	mfaError   = "Token did not match; perhaps you waited too long? Try again."
+	oidcCookie = "oidc"
+
+	webauthnCookie = "webauthn"
 )
 
+var errPasswordLogin = guru.New(http.StatusForbidden, "signing in with a password is disabled; use single sign-on")
+
+// passwordLogin reports if signing in with a password is allowed; this is
+// always the case unless single sign-on is set up without the password
+// fallback.
+func passwordLogin(ctx context.Context) bool {
+	p := goatcounter.Config(ctx).OIDC
+	return p == nil || p.Password
+}
+
 type user struct{}
 
 func (h user) mount(r chi.Router) {
@@ -55,15 +74,28 @@ func (h user) mount(r chi.Router) {
 	rate.Get("/user/reset/{key}", zhttp.Wrap(h.reset))
 	rate.Get("/user/verify/{key}", zhttp.Wrap(h.verify))
 	rate.Post("/user/reset/{key}", zhttp.Wrap(h.doReset))
+	rate.Get("/user/invite/{key}", zhttp.Wrap(h.invite))
+	rate.Post("/user/invite/{key}", zhttp.Wrap(h.doInvite))
+	rate.Get("/user/oidc", zhttp.Wrap(h.oidc))
+	rate.Get("/user/oidc/callback", zhttp.Wrap(h.oidcCallback))
+	rate.Post("/user/webauthn/login", zhttp.Wrap(h.webauthnLogin))
+	rate.Post("/user/webauthn/login/finish", zhttp.Wrap(h.webauthnLoginFinish))
 
 	auth := r.With(loggedIn)
 	auth.Post("/user/logout", zhttp.Wrap(h.logout))
 	auth.Post("/user/change-password", zhttp.Wrap(h.changePassword))
+	auth.Post("/user/change-email", zhttp.Wrap(h.changeEmail))
 	auth.Post("/user/disable-totp", zhttp.Wrap(h.disableTOTP))
 	auth.Post("/user/enable-totp", zhttp.Wrap(h.enableTOTP))
+	auth.Post("/user/recovery-codes", zhttp.Wrap(h.recoveryCodes))
 	auth.Post("/user/resend-verify", zhttp.Wrap(h.resendVerify))
 	auth.Post("/user/api-token", zhttp.Wrap(h.newAPIToken))
 	auth.Post("/user/api-token/remove/{id}", zhttp.Wrap(h.deleteAPIToken))
+	auth.Post("/user/webauthn/register", zhttp.Wrap(h.webauthnRegister))
+	auth.Post("/user/webauthn/register/finish", zhttp.Wrap(h.webauthnRegisterFinish))
+	auth.Post("/user/webauthn/remove/{id}", zhttp.Wrap(h.webauthnRemove))
+	auth.Post("/user/sessions/remove/{id}", zhttp.Wrap(h.removeSession))
+	auth.Post("/user/sessions/remove-all", zhttp.Wrap(h.removeAllSessions))
 }
 
 func (h user) new(w http.ResponseWriter, r *http.Request) error {
@@ -104,6 +136,10 @@ func (h user) requestReset(w http.ResponseWriter, r *http.Request) error {
 		return zhttp.SeeOther(w, "/")
 	}
 
+	if !passwordLogin(r.Context()) {
+		return errPasswordLogin
+	}
+
 	// Legacy email flow.
 	args := struct {
 		Email string `json:"email"`
@@ -115,17 +151,7 @@ func (h user) requestReset(w http.ResponseWriter, r *http.Request) error {
 
 	site := Site(r.Context())
 	var user goatcounter.User
-	err = user.BySite(r.Context(), site.IDOrParent())
-	if err != nil {
-		return err
-	}
-
-	if !strings.EqualFold(args.Email, user.Email) {
-		zhttp.FlashError(w, "Unknown email: %q", args.Email)
-		return zhttp.SeeOther(w, "/user/forgot")
-	}
-
-	err = u.ByEmail(r.Context(), args.Email)
+	err = user.ByEmail(r.Context(), args.Email)
 	if err != nil {
 		if zdb.ErrNoRows(err) {
 			zhttp.FlashError(w, "Not an account on this site: %q", args.Email)
@@ -134,7 +160,7 @@ func (h user) requestReset(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	err = u.RequestReset(r.Context())
+	err = user.RequestReset(r.Context())
 	if err != nil {
 		return err
 	}
@@ -144,8 +170,8 @@ func (h user) requestReset(w http.ResponseWriter, r *http.Request) error {
 		err := blackmail.Send(
 			fmt.Sprintf("Password reset for %s", site.Domain(ctx)),
 			blackmail.From("GoatCounter login", goatcounter.Config(ctx).EmailFrom),
-			blackmail.To(u.Email),
-			blackmail.BodyMustText(goatcounter.TplEmailPasswordReset{ctx, *site, *u}.Render))
+			blackmail.To(user.Email),
+			blackmail.BodyMustText(goatcounter.TplEmailPasswordReset{Context: ctx, Site: *site, User: user}.Render))
 		if err != nil {
 			zlog.Errorf("password reset: %s", err)
 		}
@@ -158,7 +184,9 @@ func (h user) requestReset(w http.ResponseWriter, r *http.Request) error {
 func (h user) totpLogin(w http.ResponseWriter, r *http.Request) error {
 	args := struct {
 		LoginMAC string `json:"loginmac"`
+		UserID   int64  `json:"user"`
 		Token    string `json:"totp_token"`
+		Recovery string `json:"recovery_code"`
 	}{}
 	_, err := zhttp.Decode(r, &args)
 	if err != nil {
@@ -167,19 +195,38 @@ func (h user) totpLogin(w http.ResponseWriter, r *http.Request) error {
 
 	site := Site(r.Context())
 
-	var u goatcounter.User
-	err = u.BySite(r.Context(), site.IDOrParent())
+	u, err := mfaUser(r.Context(), args.LoginMAC, args.UserID)
 	if err != nil {
 		return err
 	}
-
-	valid := xsrftoken.Valid(args.LoginMAC, *u.LoginToken, strconv.FormatInt(u.ID, 10), actionTOTP)
-	if !valid {
+	if u == nil || !u.TOTPEnabled {
 		zhttp.Flash(w, "Invalid login")
 		return zhttp.SeeOther(w, "/")
 	}
+	if d := u.Locked(); d > 0 {
+		zhttp.FlashError(w, lockedError(d))
+		return zhttp.SeeOther(w, "/user/new?email="+url.QueryEscape(u.Email))
+	}
+
+	if args.Recovery != "" {
+		ok, err := u.UseRecoveryCode(r.Context(), args.Recovery)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			loginFailed(r, site, u)
+			zhttp.FlashError(w, "Invalid recovery code.")
+			return mfaPage(w, r, u, args.LoginMAC)
+		}
+
+		sendEmailRecoveryCode(r.Context(), site, u)
+		err = setLogin(w, r, u, site)
+		if err != nil {
+			return err
+		}
+		return zhttp.SeeOther(w, "/")
+	}
 
-	tokGen := otp.NewOTP(u.TOTPSecret, 6, sha1.New, otp.TOTP(30*time.Second, time.Now))
 	tokInt, err := strconv.ParseInt(args.Token, 10, 32)
 	if err != nil {
 		return err
@@ -188,44 +235,141 @@ func (h user) totpLogin(w http.ResponseWriter, r *http.Request) error {
 	// Check a 30 second window on either side of the current time as well. It's
 	// common for clocks to be slightly out of sync and this prevents most errors
 	// and is what the spec recommends.
-	if tokGen(0, nil) != int32(tokInt) &&
-		tokGen(-1, nil) != int32(tokInt) &&
-		tokGen(1, nil) != int32(tokInt) {
+	now := time.Now()
+	if totp.Token(u.TOTPSecret, now) != int32(tokInt) &&
+		totp.Token(u.TOTPSecret, now.Add(-totp.Period)) != int32(tokInt) &&
+		totp.Token(u.TOTPSecret, now.Add(totp.Period)) != int32(tokInt) {
+		loginFailed(r, site, u)
 		zhttp.FlashError(w, mfaError)
-		return zhttp.Template(w, "totp.gohtml", struct {
-			Globals
-			LoginMAC string
-		}{newGlobals(w, r), args.LoginMAC})
+		return mfaPage(w, r, u, args.LoginMAC)
 	}
 
-	auth.SetCookie(w, *u.LoginToken, cookieDomain(site, r))
+	err = setLogin(w, r, u, site)
+	if err != nil {
+		return err
+	}
 	return zhttp.SeeOther(w, "/")
 }
 
+// loginFailed records a failed login for the user, and emails them if this
+// locked their account.
+func loginFailed(r *http.Request, site *goatcounter.Site, u *goatcounter.User) {
+	locked, err := u.LoginFailed(r.Context())
+	if err != nil {
+		zlog.FieldsRequest(r).Error(err)
+		return
+	}
+	if locked {
+		audit(r, site, "user.locked", goatcounter.AuditData{"user": u.Email})
+		sendEmailLoginLocked(r.Context(), site, u, r.RemoteAddr)
+	}
+}
+
+func lockedError(d time.Duration) string {
+	wait := fmt.Sprintf("%d seconds", int(d.Seconds())+1)
+	if d > time.Minute {
+		wait = fmt.Sprintf("%d minutes", int(d.Minutes())+1)
+	}
+	return "Too many failed login attempts; try again in " + wait + " or reset your password."
+}
+
+// mfaUser gets the user for the second step of signing in, after the password
+// was verified by requestLogin. The user is nil if the loginMAC isn't valid.
+func mfaUser(ctx context.Context, loginMAC string, userID int64) (*goatcounter.User, error) {
+	var u goatcounter.User
+	err := u.ByID(ctx, userID)
+	if err != nil {
+		if zdb.ErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(u.Password) == 0 {
+		return nil, nil
+	}
+	if !xsrftoken.Valid(loginMAC, mfaKey(&u), strconv.FormatInt(u.ID, 10), actionTOTP) {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+// mfaKey gets the key for the loginMAC. The password hash is used as it's
+// secret and changes when the password is changed, which invalidates any
+// pending logins.
+func mfaKey(u *goatcounter.User) string {
+	return string(u.Password)
+}
+
+// currentSession gets the session for this request from the cookie; this is
+// nil if there is no cookie, which is the case for the authentication proxy.
+func currentSession(r *http.Request) (*goatcounter.UserSession, error) {
+	c, err := r.Cookie("key")
+	if err != nil {
+		return nil, nil
+	}
+	var s goatcounter.UserSession
+	err = s.ByToken(r.Context(), c.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// setLogin creates a new session for the user on this device, and sets the
+// cookie.
+func setLogin(w http.ResponseWriter, r *http.Request, u *goatcounter.User, site *goatcounter.Site) error {
+	s, err := u.Login(goatcounter.WithSite(r.Context(), site), r.UserAgent(), r.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	auth.SetCookie(w, s.Token, cookieDomain(site, r))
+	return nil
+}
+
+// mfaPage asks for the TOTP token or security key.
+func mfaPage(w http.ResponseWriter, r *http.Request, u *goatcounter.User, loginMAC string) error {
+	var keys goatcounter.WebAuthnCredentials
+	err := keys.List(r.Context(), u.ID)
+	if err != nil {
+		return err
+	}
+
+	return zhttp.Template(w, "totp.gohtml", struct {
+		Globals
+		LoginMAC string
+		UserID   int64
+		TOTP     bool
+		WebAuthn bool
+	}{newGlobals(w, r), loginMAC, u.ID, bool(u.TOTPEnabled), len(keys) > 0})
+}
+
 func (h user) requestLogin(w http.ResponseWriter, r *http.Request) error {
 	u := goatcounter.GetUser(r.Context())
 	if u != nil && u.ID > 0 {
 		return zhttp.SeeOther(w, "/")
 	}
 
-	site := Site(r.Context())
-
-	var user goatcounter.User
-	err := user.BySite(r.Context(), site.IDOrParent())
-	if err != nil {
-		return err
+	if !passwordLogin(r.Context()) {
+		return errPasswordLogin
 	}
 
+	site := Site(r.Context())
+
 	args := struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
 	}{}
-	_, err = zhttp.Decode(r, &args)
+	_, err := zhttp.Decode(r, &args)
 	if err != nil {
 		return err
 	}
 
-	if !strings.EqualFold(args.Email, user.Email) {
+	var user goatcounter.User
+	err = user.ByEmail(r.Context(), args.Email)
+	if err != nil {
+		if !zdb.ErrNoRows(err) {
+			return err
+		}
 		zhttp.FlashError(w, "Wrong password for %q", args.Email)
 		return zhttp.SeeOther(w, "/user/new")
 	}
@@ -237,9 +381,15 @@ func (h user) requestLogin(w http.ResponseWriter, r *http.Request) error {
 		return zhttp.SeeOther(w, "/user/forgot?email="+url.QueryEscape(args.Email))
 	}
 
+	if d := user.Locked(); d > 0 {
+		zhttp.FlashError(w, lockedError(d))
+		return zhttp.SeeOther(w, "/user/new?email="+url.QueryEscape(args.Email))
+	}
+
 	err = bcrypt.CompareHashAndPassword(user.Password, []byte(args.Password))
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			loginFailed(r, site, &user)
 			zhttp.FlashError(w, "Wrong password for %q", args.Email)
 		} else {
 			zhttp.FlashError(w, "Something went wrong :-( An error has been logged for investigation.")
@@ -248,19 +398,20 @@ func (h user) requestLogin(w http.ResponseWriter, r *http.Request) error {
 		return zhttp.SeeOther(w, "/user/new?email="+url.QueryEscape(args.Email))
 	}
 
-	err = user.Login(r.Context())
+	var keys goatcounter.WebAuthnCredentials
+	err = keys.List(r.Context(), user.ID)
 	if err != nil {
 		return err
 	}
-
-	if user.TOTPEnabled {
-		return zhttp.Template(w, "totp.gohtml", struct {
-			Globals
-			LoginMAC string
-		}{newGlobals(w, r), xsrftoken.Generate(*user.LoginToken, strconv.FormatInt(user.ID, 10), actionTOTP)})
+	if user.TOTPEnabled || len(keys) > 0 {
+		return mfaPage(w, r, &user,
+			xsrftoken.Generate(mfaKey(&user), strconv.FormatInt(user.ID, 10), actionTOTP))
 	}
 
-	auth.SetCookie(w, *user.LoginToken, cookieDomain(site, r))
+	err = setLogin(w, r, &user, site)
+	if err != nil {
+		return err
+	}
 	return zhttp.SeeOther(w, "/")
 }
 
@@ -318,11 +469,555 @@ func (h user) doReset(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	err = user.Unlock(r.Context())
+	if err != nil {
+		return err
+	}
+
 	zhttp.Flash(w, "Password reset; use your new password to login.")
 	return zhttp.SeeOther(w, "/user/new")
 }
 
+// byInvite gets the user from the invitation key.
+func byInvite(r *http.Request) (goatcounter.User, error) {
+	var user goatcounter.User
+	err := user.ByEmailToken(r.Context(), chi.URLParam(r, "key"))
+	if err != nil {
+		if !zdb.ErrNoRows(err) {
+			return user, err
+		}
+		return user, guru.New(http.StatusForbidden, "unknown invitation; perhaps it was already accepted?")
+	}
+	if !user.Pending() {
+		return user, guru.New(http.StatusForbidden, "this invitation was already accepted")
+	}
+	return user, nil
+}
+
+func (h user) invite(w http.ResponseWriter, r *http.Request) error {
+	user, err := byInvite(r)
+	if err != nil {
+		return err
+	}
+
+	return zhttp.Template(w, "user_invite.gohtml", struct {
+		Globals
+		Email string
+		Key   string
+	}{newGlobals(w, r), user.Email, chi.URLParam(r, "key")})
+}
+
+func (h user) doInvite(w http.ResponseWriter, r *http.Request) error {
+	user, err := byInvite(r)
+	if err != nil {
+		return err
+	}
+	if !passwordLogin(r.Context()) {
+		return errPasswordLogin
+	}
+
+	var args struct {
+		Password  string `json:"password"`
+		Password2 string `json:"password2"`
+	}
+	_, err = zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	back := "/user/invite/" + chi.URLParam(r, "key")
+	if args.Password != args.Password2 {
+		zhttp.FlashError(w, "Password confirmation doesn’t match.")
+		return zhttp.SeeOther(w, back)
+	}
+
+	err = user.UpdatePassword(r.Context(), args.Password)
+	if err != nil {
+		var vErr *zvalidate.Validator
+		if errors.As(err, &vErr) {
+			zhttp.FlashError(w, fmt.Sprintf("%s", err))
+			return zhttp.SeeOther(w, back)
+		}
+		return err
+	}
+	err = user.VerifyEmail(r.Context())
+	if err != nil {
+		return err
+	}
+	err = setLogin(w, r, &user, Site(r.Context()))
+	if err != nil {
+		return err
+	}
+
+	zhttp.Flash(w, "Welcome! You can now use your email and password to sign in.")
+	return zhttp.SeeOther(w, "/")
+}
+
+func oidcProvider(ctx context.Context) (*oidc.Provider, error) {
+	p := goatcounter.Config(ctx).OIDC
+	if p == nil {
+		return nil, guru.New(http.StatusNotFound, "single sign-on is not enabled")
+	}
+	return p, nil
+}
+
+func oidcRedirect(r *http.Request) string {
+	return Site(r.Context()).URL(r.Context()) + "/user/oidc/callback"
+}
+
+// oidc redirects to the OpenID Connect provider to sign in; the state and nonce
+// are stored in a cookie and verified in oidcCallback.
+func (h user) oidc(w http.ResponseWriter, r *http.Request) error {
+	p, err := oidcProvider(r.Context())
+	if err != nil {
+		return err
+	}
+
+	state, nonce := zcrypto.Secret256(), zcrypto.Secret256()
+	u, err := p.AuthURL(r.Context(), oidcRedirect(r), state, nonce)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookie,
+		Value:    state + "." + nonce,
+		Path:     "/user/oidc",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   zhttp.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return zhttp.SeeOther(w, u)
+}
+
+// oidcCallback signs in the user after the provider redirected back; users
+// are created if they don't exist yet and the provider restricts who can sign
+// in with domains or a role claim.
+//
+// This doesn't ask for a TOTP token, as that's the provider's responsibility.
+func (h user) oidcCallback(w http.ResponseWriter, r *http.Request) error {
+	p, err := oidcProvider(r.Context())
+	if err != nil {
+		return err
+	}
+
+	c, err := r.Cookie(oidcCookie)
+	if err != nil {
+		return guru.New(400, "no sign in in progress; perhaps it expired? Try again.")
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcCookie, Path: "/user/oidc", MaxAge: -1})
+	state, nonce := zstring.Split2(c.Value, ".")
+
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		if d := q.Get("error_description"); d != "" {
+			e = d
+		}
+		zhttp.FlashError(w, "Single sign-on failed: %s", e)
+		return zhttp.SeeOther(w, "/user/new")
+	}
+	if state == "" || q.Get("state") != state {
+		return guru.New(400, "state doesn't match; try again.")
+	}
+
+	claims, err := p.Exchange(r.Context(), q.Get("code"), oidcRedirect(r), nonce)
+	if err != nil {
+		return err
+	}
+
+	email := p.Email(claims)
+	if email == "" {
+		zhttp.FlashError(w, "The single sign-on provider didn't send a verified email address.")
+		return zhttp.SeeOther(w, "/user/new")
+	}
+	if !p.AllowEmail(email) {
+		zhttp.FlashError(w, "%q isn't allowed to sign in here.", email)
+		return zhttp.SeeOther(w, "/user/new")
+	}
+
+	access, fromClaim := oidcAccess(p, claims)
+	var user goatcounter.User
+	err = user.ByEmail(r.Context(), email)
+	switch {
+	case zdb.ErrNoRows(err):
+		// Anyone with an account at the provider can sign in if there are no
+		// domains, so only create users if the domains or role claim
+		// restrict who this is.
+		if len(p.Domains) == 0 && !fromClaim {
+			zhttp.FlashError(w, "%q doesn't have an account here; ask an administrator to invite you.", email)
+			return zhttp.SeeOther(w, "/user/new")
+		}
+		user = goatcounter.User{Email: email, EmailVerified: true, Access: access}
+		err = user.Insert(r.Context(), true)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if !user.EmailVerified {
+			err = user.VerifyEmail(r.Context())
+			if err != nil {
+				return err
+			}
+		}
+		// The provider is in charge of the roles if the claim is sent, but
+		// leave the owner alone.
+		if fromClaim && user.Access != access && user.Access != goatcounter.AccessOwner {
+			err = user.UpdateAccess(r.Context(), access)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = setLogin(w, r, &user, Site(r.Context()))
+	if err != nil {
+		return err
+	}
+	return zhttp.SeeOther(w, "/")
+}
+
+// oidcAccess gets the access level from the role claim, if any; the highest
+// level is used if there are several roles.
+func oidcAccess(p *oidc.Provider, claims oidc.Claims) (goatcounter.UserAccess, bool) {
+	if p.RoleClaim == "" {
+		return goatcounter.AccessReadOnly, false
+	}
+
+	values := claims.Strings(p.RoleClaim)
+	roles := make([]string, 0, len(values))
+	for _, v := range values {
+		roles = append(roles, p.Role(v))
+	}
+	for _, r := range []struct {
+		role   string
+		access goatcounter.UserAccess
+	}{
+		{"admin", goatcounter.AccessAdmin},
+		{"settings", goatcounter.AccessSettings},
+		{"viewer", goatcounter.AccessReadOnly},
+	} {
+		if zstring.Contains(roles, r.role) {
+			return r.access, true
+		}
+	}
+	return goatcounter.AccessReadOnly, false
+}
+
+// webauthnSessions stores the challenges of WebAuthn requests in progress, by
+// the value of the webauthn cookie.
+var webauthnSessions = zcache.New(5*time.Minute, time.Minute)
+
+type webauthnSession struct {
+	challenge []byte
+	siteID    int64
+	userID    int64 // 0 when signing in without a password.
+}
+
+// webauthnStart creates a new challenge and stores it for webauthnFinish.
+func webauthnStart(w http.ResponseWriter, r *http.Request, userID int64) []byte {
+	key := zcrypto.Secret256()
+	s := webauthnSession{challenge: webauthn.Challenge(), siteID: Site(r.Context()).ID, userID: userID}
+	webauthnSessions.SetDefault(key, s)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     webauthnCookie,
+		Value:    key,
+		Path:     "/user/webauthn",
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   zhttp.CookieSecure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return s.challenge
+}
+
+// webauthnFinish gets the session created by webauthnStart; every challenge
+// can be used only once.
+func webauthnFinish(w http.ResponseWriter, r *http.Request) (webauthnSession, error) {
+	errNoSession := guru.New(400, "no security key request in progress; perhaps it expired? Try again.")
+
+	c, err := r.Cookie(webauthnCookie)
+	if err != nil {
+		return webauthnSession{}, errNoSession
+	}
+	http.SetCookie(w, &http.Cookie{Name: webauthnCookie, Path: "/user/webauthn", MaxAge: -1})
+
+	s, ok := webauthnSessions.Get(c.Value)
+	webauthnSessions.Delete(c.Value)
+	if !ok || s.(webauthnSession).siteID != Site(r.Context()).ID {
+		return webauthnSession{}, errNoSession
+	}
+	return s.(webauthnSession), nil
+}
+
+// relyingParty gets the WebAuthn relying party for the domain in this request;
+// credentials are bound to the domain they're registered on.
+func relyingParty(r *http.Request) webauthn.RelyingParty {
+	scheme := "https"
+	if goatcounter.Config(r.Context()).Dev {
+		scheme = "http"
+	}
+	return webauthn.RelyingParty{
+		ID:     znet.RemovePort(r.Host),
+		Name:   "GoatCounter",
+		Origin: scheme + "://" + r.Host,
+	}
+}
+
+var b64 = base64.RawURLEncoding
+
+// b64Decode decodes the base64url values that the browser sends.
+func b64Decode(s ...string) ([][]byte, error) {
+	d := make([][]byte, len(s))
+	for i := range s {
+		var err error
+		d[i], err = b64.DecodeString(s[i])
+		if err != nil || len(d[i]) == 0 {
+			return nil, guru.New(400, "invalid or missing security key data")
+		}
+	}
+	return d, nil
+}
+
+// webauthnRegister sends the options for navigator.credentials.create() to add
+// a new security key.
+func (h user) webauthnRegister(w http.ResponseWriter, r *http.Request) error {
+	u := goatcounter.GetUser(r.Context())
+
+	var keys goatcounter.WebAuthnCredentials
+	err := keys.List(r.Context(), u.ID)
+	if err != nil {
+		return err
+	}
+	exclude := make([]map[string]string, 0, len(keys))
+	for _, k := range keys {
+		exclude = append(exclude, map[string]string{"type": "public-key", "id": b64.EncodeToString(k.RawID)})
+	}
+
+	rp := relyingParty(r)
+	return zhttp.JSON(w, map[string]interface{}{
+		"challenge": b64.EncodeToString(webauthnStart(w, r, u.ID)),
+		"rp":        map[string]string{"id": rp.ID, "name": rp.Name},
+		"user": map[string]string{
+			"id":          b64.EncodeToString([]byte(strconv.FormatInt(u.ID, 10))),
+			"name":        u.Email,
+			"displayName": u.Email,
+		},
+		"pubKeyCredParams": []map[string]interface{}{
+			{"type": "public-key", "alg": webauthn.AlgES256},
+			{"type": "public-key", "alg": webauthn.AlgRS256},
+		},
+		"excludeCredentials": exclude,
+		"authenticatorSelection": map[string]string{
+			"residentKey":      "preferred",
+			"userVerification": "preferred",
+		},
+		"attestation": "none",
+		"timeout":     300_000,
+	})
+}
+
+// webauthnRegisterFinish verifies and stores the new security key.
+func (h user) webauthnRegisterFinish(w http.ResponseWriter, r *http.Request) error {
+	u := goatcounter.GetUser(r.Context())
+	var args struct {
+		Name        string `json:"name"`
+		ClientData  string `json:"client_data"`
+		Attestation string `json:"attestation"`
+	}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	s, err := webauthnFinish(w, r)
+	if err != nil {
+		return err
+	}
+	if s.userID != u.ID {
+		return guru.New(400, "security key request was for a different user")
+	}
+	d, err := b64Decode(args.ClientData, args.Attestation)
+	if err != nil {
+		return err
+	}
+
+	cred, err := relyingParty(r).Register(s.challenge, d[0], d[1])
+	if err != nil {
+		return guru.Errorf(400, "could not verify the security key: %s", err)
+	}
+
+	key := goatcounter.WebAuthnCredential{
+		Name:      args.Name,
+		RawID:     cred.ID,
+		PublicKey: cred.PublicKey,
+		SignCount: int64(cred.SignCount),
+	}
+	err = key.Insert(r.Context())
+	if err != nil {
+		if zdb.ErrUnique(err) {
+			return guru.New(400, "this security key is already registered")
+		}
+		return err
+	}
+	audit(r, Site(r.Context()), "user.security_key.add", goatcounter.AuditData{"name": key.Name})
+
+	zhttp.Flash(w, "Security key %q added", key.Name)
+	return zhttp.JSON(w, map[string]string{"location": "/settings/auth"})
+}
+
+// webauthnRemove removes a security key.
+func (h user) webauthnRemove(w http.ResponseWriter, r *http.Request) error {
+	v := zvalidate.New()
+	id := v.Integer("id", chi.URLParam(r, "id"))
+	if v.HasErrors() {
+		return v
+	}
+
+	var key goatcounter.WebAuthnCredential
+	err := key.ByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	err = key.Delete(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.security_key.remove", goatcounter.AuditData{"name": key.Name})
+
+	zhttp.Flash(w, "Security key %q removed", key.Name)
+	return zhttp.SeeOther(w, "/settings/auth")
+}
+
+// webauthnLogin sends the options for navigator.credentials.get().
+//
+// This is used as the second factor after requestLogin if loginmac is set, and
+// to sign in without a password otherwise. The latter requires the
+// authenticator to verify the user with a PIN, biometrics, etc. and is only
+// possible with keys that store the credential ("passkeys"), as we don't know
+// which user is signing in.
+func (h user) webauthnLogin(w http.ResponseWriter, r *http.Request) error {
+	args := struct {
+		LoginMAC string `json:"loginmac"`
+		UserID   int64  `json:"user"`
+	}{}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	var (
+		userID int64
+		uv     = "required"
+		allow  = []map[string]string{}
+	)
+	if args.LoginMAC != "" {
+		u, err := mfaUser(r.Context(), args.LoginMAC, args.UserID)
+		if err != nil {
+			return err
+		}
+		if u == nil {
+			return guru.New(403, "invalid login")
+		}
+		if d := u.Locked(); d > 0 {
+			return guru.New(403, lockedError(d))
+		}
+
+		var keys goatcounter.WebAuthnCredentials
+		err = keys.List(r.Context(), u.ID)
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			allow = append(allow, map[string]string{"type": "public-key", "id": b64.EncodeToString(k.RawID)})
+		}
+		userID, uv = u.ID, "discouraged"
+	} else if !passwordLogin(r.Context()) {
+		return errPasswordLogin
+	}
+
+	return zhttp.JSON(w, map[string]interface{}{
+		"challenge":        b64.EncodeToString(webauthnStart(w, r, userID)),
+		"rpId":             relyingParty(r).ID,
+		"allowCredentials": allow,
+		"userVerification": uv,
+		"timeout":          300_000,
+	})
+}
+
+// webauthnLoginFinish verifies the signature from the security key and signs
+// in the user.
+func (h user) webauthnLoginFinish(w http.ResponseWriter, r *http.Request) error {
+	args := struct {
+		ID         string `json:"id"`
+		ClientData string `json:"client_data"`
+		AuthData   string `json:"auth_data"`
+		Signature  string `json:"signature"`
+	}{}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	s, err := webauthnFinish(w, r)
+	if err != nil {
+		return err
+	}
+	d, err := b64Decode(args.ID, args.ClientData, args.AuthData, args.Signature)
+	if err != nil {
+		return err
+	}
+
+	errUnknown := guru.New(403, "unknown security key")
+	var key goatcounter.WebAuthnCredential
+	err = key.ByRawID(r.Context(), d[0])
+	if err != nil {
+		if zdb.ErrNoRows(err) {
+			return errUnknown
+		}
+		return err
+	}
+	if s.userID != 0 && key.UserID != s.userID {
+		return errUnknown
+	}
+
+	count, err := relyingParty(r).Verify(s.challenge, key.Credential(), d[1], d[2], d[3], s.userID == 0)
+	if err != nil {
+		return guru.Errorf(403, "could not verify the security key: %s", err)
+	}
+	err = key.UpdateUsed(r.Context(), count)
+	if err != nil {
+		return err
+	}
+
+	var u goatcounter.User
+	err = u.ByID(r.Context(), key.UserID)
+	if err != nil {
+		return err
+	}
+	if d := u.Locked(); d > 0 {
+		return guru.New(403, lockedError(d))
+	}
+	err = setLogin(w, r, &u, Site(r.Context()))
+	if err != nil {
+		return err
+	}
+	return zhttp.JSON(w, map[string]string{"location": "/"})
+}
+
 func (h user) logout(w http.ResponseWriter, r *http.Request) error {
+	s, err := currentSession(r)
+	if err == nil && s != nil {
+		err = s.Delete(r.Context())
+	}
+	if err != nil && !zdb.ErrNoRows(err) {
+		zlog.Errorf("logout: %s", err)
+	}
+
 	if goatcounter.Config(r.Context()).GoatcounterCom {
 		isAdmin := false
 		for _, c := range r.Cookies() {
@@ -337,11 +1032,40 @@ func (h user) logout(w http.ResponseWriter, r *http.Request) error {
 		}
 	}
 
-	u := goatcounter.GetUser(r.Context())
-	err := u.Logout(r.Context())
+	auth.ClearCookie(w, Site(r.Context()).Domain(r.Context()))
+	return zhttp.SeeOther(w, "/")
+}
+
+// removeSession logs out a session on another device.
+func (h user) removeSession(w http.ResponseWriter, r *http.Request) error {
+	v := zvalidate.New()
+	id := v.Integer("id", chi.URLParam(r, "id"))
+	if v.HasErrors() {
+		return v
+	}
+
+	var s goatcounter.UserSession
+	err := s.ByID(r.Context(), id)
 	if err != nil {
-		zlog.Errorf("logout: %s", err)
+		return err
+	}
+	err = s.Delete(r.Context())
+	if err != nil {
+		return err
 	}
+	audit(r, Site(r.Context()), "user.session.remove", goatcounter.AuditData{"browser": s.Browser(), "ip": s.IP})
+
+	zhttp.Flash(w, "Logged out %s", s.Browser())
+	return zhttp.SeeOther(w, "/settings/auth")
+}
+
+// removeAllSessions logs out everywhere, including this device.
+func (h user) removeAllSessions(w http.ResponseWriter, r *http.Request) error {
+	err := goatcounter.GetUser(r.Context()).LogoutAll(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.session.remove_all", nil)
 
 	auth.ClearCookie(w, Site(r.Context()).Domain(r.Context()))
 	return zhttp.SeeOther(w, "/")
@@ -353,6 +1077,7 @@ func (h user) disableTOTP(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, Site(r.Context()), "user.mfa.disable", nil)
 
 	return zhttp.SeeOther(w, "/settings/auth")
 }
@@ -367,7 +1092,6 @@ func (h user) enableTOTP(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	tokGen := otp.NewOTP(u.TOTPSecret, 6, sha1.New, otp.TOTP(30*time.Second, time.Now))
 	tokInt, err := strconv.ParseInt(args.Token, 10, 32)
 	if err != nil {
 		return err
@@ -376,9 +1100,10 @@ func (h user) enableTOTP(w http.ResponseWriter, r *http.Request) error {
 	// Check a 30 second window on either side of the current time as well. It's
 	// common for clocks to be slightly out of sync and this prevents most errors
 	// and is what the spec recommends.
-	if tokGen(0, nil) != int32(tokInt) &&
-		tokGen(-1, nil) != int32(tokInt) &&
-		tokGen(1, nil) != int32(tokInt) {
+	now := time.Now()
+	if totp.Token(u.TOTPSecret, now) != int32(tokInt) &&
+		totp.Token(u.TOTPSecret, now.Add(-totp.Period)) != int32(tokInt) &&
+		totp.Token(u.TOTPSecret, now.Add(totp.Period)) != int32(tokInt) {
 		zhttp.FlashError(w, mfaError)
 		return zhttp.SeeOther(w, "/settings/auth")
 	}
@@ -387,7 +1112,27 @@ func (h user) enableTOTP(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
-	return zhttp.SeeOther(w, "/settings/auth")
+	audit(r, Site(r.Context()), "user.mfa.enable", nil)
+	return h.recoveryCodes(w, r)
+}
+
+// recoveryCodes creates a new set of recovery codes and shows them; this is the
+// only time they're shown.
+func (h user) recoveryCodes(w http.ResponseWriter, r *http.Request) error {
+	u := goatcounter.GetUser(r.Context())
+	if !u.TOTPEnabled {
+		return guru.New(400, "MFA isn't enabled")
+	}
+
+	codes, err := u.NewRecoveryCodes(r.Context())
+	if err != nil {
+		return err
+	}
+	audit(r, Site(r.Context()), "user.mfa.recovery_codes", nil)
+	return zhttp.Template(w, "totp_recovery.gohtml", struct {
+		Globals
+		Codes []string
+	}{newGlobals(w, r), codes})
 }
 
 func (h user) changePassword(w http.ResponseWriter, r *http.Request) error {
@@ -427,11 +1172,49 @@ func (h user) changePassword(w http.ResponseWriter, r *http.Request) error {
 		}
 		return err
 	}
+	audit(r, Site(r.Context()), "user.password", nil)
 
 	zhttp.Flash(w, "Password changed")
 	return zhttp.SeeOther(w, "/")
 }
 
+func (h user) changeEmail(w http.ResponseWriter, r *http.Request) error {
+	u := goatcounter.GetUser(r.Context())
+	var args struct {
+		Email string `json:"email"`
+	}
+	_, err := zhttp.Decode(r, &args)
+	if err != nil {
+		return err
+	}
+
+	if args.Email == u.Email {
+		return zhttp.SeeOther(w, "/settings/auth")
+	}
+
+	old := u.Email
+	emailChanged := goatcounter.Config(r.Context()).GoatcounterCom
+	u.Email = args.Email
+	err = u.Update(r.Context(), emailChanged)
+	if err != nil {
+		var vErr *zvalidate.Validator
+		if errors.As(err, &vErr) {
+			zhttp.FlashError(w, fmt.Sprintf("%s", err))
+			return zhttp.SeeOther(w, "/settings/auth")
+		}
+		return err
+	}
+	audit(r, Site(r.Context()), "user.email", goatcounter.AuditData{"old": old, "new": u.Email})
+
+	if emailChanged {
+		sendEmailVerify(r.Context(), Site(r.Context()), u, goatcounter.Config(r.Context()).EmailFrom)
+		zhttp.Flash(w, "Email changed; you will need to verify the new address")
+	} else {
+		zhttp.Flash(w, "Email changed")
+	}
+	return zhttp.SeeOther(w, "/settings/auth")
+}
+
 func (h user) resendVerify(w http.ResponseWriter, r *http.Request) error {
 	user := goatcounter.GetUser(r.Context())
 	if user.EmailVerified {
@@ -451,16 +1234,58 @@ func (h user) newAPIToken(w http.ResponseWriter, r *http.Request) error {
 		return zhttp.SeeOther(w, "/settings/auth")
 	}
 
-	var token goatcounter.APIToken
-	_, err := zhttp.Decode(r, &token)
+	var args struct {
+		Name        string   `json:"name"`
+		Permissions []string `json:"permissions"`
+		Sites       []int64  `json:"sites"`
+		IPRanges    string   `json:"ip_ranges"`
+		Ratelimits  string   `json:"ratelimits"`
+		ExpiresAt   string   `json:"expires_at"`
+	}
+	_, err := zhttp.Decode(r, &args)
 	if err != nil {
 		return err
 	}
 
+	perm, err := goatcounter.ParseAPIPermissions(args.Permissions)
+	if err != nil {
+		return guru.New(http.StatusBadRequest, err.Error())
+	}
+	token := goatcounter.APIToken{
+		Name:        args.Name,
+		Permissions: perm,
+		Sites:       args.Sites,
+		IPRanges:    strings.Split(args.IPRanges, ","),
+	}
+	token.Ratelimits, err = goatcounter.ParseAPIRatelimits(args.Ratelimits)
+	if err != nil {
+		zhttp.FlashError(w, "Invalid rate limits: %s", err)
+		return zhttp.SeeOther(w, "/settings/auth")
+	}
+	if args.ExpiresAt != "" {
+		// Valid until the end of the day, in the site's timezone.
+		exp, err := time.ParseInLocation("2006-01-02", args.ExpiresAt,
+			Site(r.Context()).Settings.Timezone.Loc())
+		if err != nil {
+			zhttp.FlashError(w, "Invalid expiry date: %q", args.ExpiresAt)
+			return zhttp.SeeOther(w, "/settings/auth")
+		}
+		exp = exp.Add(24*time.Hour - time.Second)
+		token.ExpiresAt = &exp
+	}
+
 	err = token.Insert(r.Context())
 	if err != nil {
+		var vErr *zvalidate.Validator
+		if errors.As(err, &vErr) {
+			zhttp.FlashError(w, fmt.Sprintf("%s", err))
+			return zhttp.SeeOther(w, "/settings/auth")
+		}
 		return err
 	}
+	audit(r, Site(r.Context()), "api_token.create", goatcounter.AuditData{
+		"name": token.Name, "permissions": token.Permissions, "sites": token.Sites,
+		"ip_ranges": token.IPRanges, "ratelimits": token.Ratelimits, "expires_at": token.ExpiresAt})
 
 	zhttp.Flash(w, "Token created")
 	return zhttp.SeeOther(w, "/settings/auth")
@@ -483,6 +1308,7 @@ func (h user) deleteAPIToken(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	audit(r, Site(r.Context()), "api_token.delete", goatcounter.AuditData{"name": token.Name})
 
 	zhttp.Flash(w, "Token removed")
 	return zhttp.SeeOther(w, "/settings/auth")
@@ -494,7 +1320,52 @@ func sendEmailVerify(ctx context.Context, site *goatcounter.Site, user *goatcoun
 		err := blackmail.Send("Verify your email",
 			mail.Address{Name: "GoatCounter", Address: emailFrom},
 			blackmail.To(user.Email),
-			blackmail.BodyMustText(goatcounter.TplEmailVerify{ctx, *site, *user}.Render))
+			blackmail.BodyMustText(goatcounter.TplEmailVerify{Context: ctx, Site: *site, User: *user}.Render))
+		if err != nil {
+			zlog.Errorf("blackmail: %s", err)
+		}
+	})
+}
+
+func sendEmailRecoveryCode(ctx context.Context, site *goatcounter.Site, user *goatcounter.User) {
+	left, err := user.RecoveryCodesLeft(ctx)
+	if err != nil {
+		zlog.Error(err)
+		return
+	}
+
+	ctx = goatcounter.CopyContextValues(ctx)
+	bgrun.Run("email:recovery-code", func() {
+		err := blackmail.Send("A recovery code was used to sign in to GoatCounter",
+			mail.Address{Name: "GoatCounter", Address: goatcounter.Config(ctx).EmailFrom},
+			blackmail.To(user.Email),
+			blackmail.BodyMustText(goatcounter.TplEmailRecoveryCode{Context: ctx, Site: *site, User: *user, Left: left}.Render))
+		if err != nil {
+			zlog.Errorf("blackmail: %s", err)
+		}
+	})
+}
+
+func sendEmailLoginLocked(ctx context.Context, site *goatcounter.Site, user *goatcounter.User, ip string) {
+	ctx = goatcounter.CopyContextValues(ctx)
+	bgrun.Run("email:login-locked", func() {
+		err := blackmail.Send("Too many failed attempts to sign in to GoatCounter",
+			mail.Address{Name: "GoatCounter", Address: goatcounter.Config(ctx).EmailFrom},
+			blackmail.To(user.Email),
+			blackmail.BodyMustText(goatcounter.TplEmailLoginLocked{Context: ctx, Site: *site, User: *user, IP: ip}.Render))
+		if err != nil {
+			zlog.Errorf("blackmail: %s", err)
+		}
+	})
+}
+
+func sendEmailInvite(ctx context.Context, site *goatcounter.Site, user, invitedBy *goatcounter.User) {
+	ctx = goatcounter.CopyContextValues(ctx)
+	bgrun.Run("email:invite", func() {
+		err := blackmail.Send(fmt.Sprintf("You’ve been invited to GoatCounter for %s", site.Display(ctx)),
+			mail.Address{Name: "GoatCounter", Address: goatcounter.Config(ctx).EmailFrom},
+			blackmail.To(user.Email),
+			blackmail.BodyMustText(goatcounter.TplEmailInvite{Context: ctx, Site: *site, User: *user, InvitedBy: *invitedBy}.Render))
 		if err != nil {
 			zlog.Errorf("blackmail: %s", err)
 		}
@@ -6,11 +6,25 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/goatcounter/oidc/oidctest"
+	"zgo.at/goatcounter/totp"
+	"zgo.at/goatcounter/webauthn"
+	"zgo.at/goatcounter/webauthn/webauthntest"
+	"zgo.at/zdb"
+	"zgo.at/zstd/zjson"
+	"zgo.at/zstd/ztest"
 )
 
 func TestUserNew(t *testing.T) {
@@ -20,7 +34,7 @@ func TestUserNew(t *testing.T) {
 			router: newBackend,
 			setup: func(ctx context.Context, t *testing.T) {
 				u := goatcounter.User{Site: 1, Email: "user_test@example.com", Password: []byte("coconuts")}
-				err := u.Insert(ctx)
+				err := u.Insert(ctx, false)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -61,7 +75,755 @@ func TestUserLogout(t *testing.T) {
 
 	for _, tt := range tests {
 		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
-			// TODO: ensure we're actually logged out.
+			var sessions goatcounter.UserSessions
+			err := sessions.List(r.Context(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sessions) != 0 {
+				t.Errorf("still logged in: %v", sessions)
+			}
 		})
 	}
 }
+
+func TestUserSessions(t *testing.T) {
+	// Add another session for the user, in addition to the one from login().
+	other := func(ctx context.Context, t *testing.T) {
+		_, err := goatcounter.GetUser(ctx).Login(ctx,
+			"Mozilla/5.0 (X11; Linux x86_64; rv:86.0) Gecko/20100101 Firefox/86.0", "192.0.2.2")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []handlerTest{
+		{
+			name:     "list",
+			setup:    other,
+			router:   newBackend,
+			path:     "/settings/auth",
+			auth:     true,
+			wantCode: 200,
+			wantBody: "Firefox",
+		},
+		{
+			name:         "remove",
+			setup:        other,
+			router:       newBackend,
+			method:       "POST",
+			path:         "/user/sessions/remove/1",
+			auth:         true,
+			wantFormCode: 303,
+			want: `
+				user_session_id  user_id  ip
+				2                1        192.0.2.1`,
+		},
+		{
+			name:         "remove all",
+			setup:        other,
+			router:       newBackend,
+			method:       "POST",
+			path:         "/user/sessions/remove-all",
+			auth:         true,
+			wantFormCode: 303,
+			want:         `user_session_id  user_id  ip`,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			if tt.want == "" {
+				return
+			}
+			got := zdb.DumpString(r.Context(), `select user_session_id, user_id, ip from user_sessions order by user_session_id`)
+			if d := zdb.Diff(got, tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestUserLockout(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	other := goatcounter.User{Site: 1, Email: "other@example.com", Password: []byte("coconuts"), Access: goatcounter.AccessReadOnly}
+	err := other.Insert(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signin := func(t *testing.T, pwd string) {
+		t.Helper()
+		r, rr := newTest(ctx, "POST", "/user/requestlogin", strings.NewReader(`{"email":"other@example.com","password":"`+pwd+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 303)
+	}
+	check := func(t *testing.T, want string) {
+		t.Helper()
+		got := zdb.DumpString(ctx, fmt.Sprintf(`
+			select login_failures, case when locked_until is null then 0 else 1 end as locked,
+				(select count(*) from user_sessions where user_id=users.user_id) as sessions
+			from users where user_id=%d`, other.ID))
+		if d := zdb.Diff(got, want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	// Wait long enough between attempts for the backoff to expire, until it's
+	// locked.
+	now := time.Now().UTC()
+	for i := 0; i < 10; i++ {
+		now = now.Add(5 * time.Minute)
+		gctest.SetNow(t, now)
+		signin(t, "wrong")
+	}
+	check(t, "login_failures  locked  sessions\n10              1       0")
+
+	// Correct password doesn't work while locked.
+	signin(t, "coconuts")
+	check(t, "login_failures  locked  sessions\n10              1       0")
+
+	// Admin unlocks it.
+	r, rr := newTest(ctx, "POST", fmt.Sprintf("/settings/users/unlock/%d", other.ID), strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	login(t, r)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	ztest.Code(t, rr, 303)
+	check(t, "login_failures  locked  sessions\n0               0       0")
+
+	signin(t, "coconuts")
+	check(t, "login_failures  locked  sessions\n0               0       1")
+}
+
+func TestUserChangeEmail(t *testing.T) {
+	tests := []handlerTest{
+		{
+			name: "viewer",
+			setup: func(ctx context.Context, t *testing.T) {
+				err := zdb.Exec(ctx, `update users set access='r'`)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			router:       newBackend,
+			method:       "POST",
+			path:         "/user/change-email",
+			body:         map[string]string{"email": "new@example.com"},
+			auth:         true,
+			wantFormCode: 303,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			var u goatcounter.User
+			err := u.ByID(r.Context(), goatcounter.GetUser(r.Context()).ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Email != "new@example.com" {
+				t.Errorf("email not changed: %q", u.Email)
+			}
+		})
+	}
+}
+
+func TestUserInvite(t *testing.T) {
+	invite := func(ctx context.Context, t *testing.T) {
+		u := goatcounter.User{Email: "new@example.com", Access: goatcounter.AccessReadOnly}
+		err := u.Insert(ctx, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = zdb.Exec(ctx, `update users set email_token='invite-key' where user_id=$1`, u.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []handlerTest{
+		{
+			name:     "form",
+			setup:    invite,
+			router:   newBackend,
+			path:     "/user/invite/invite-key",
+			wantCode: 200,
+			wantBody: "Accept invitation for new@example.com",
+		},
+		{
+			name:     "unknown",
+			setup:    invite,
+			router:   newBackend,
+			path:     "/user/invite/other-key",
+			wantCode: 403,
+		},
+		{
+			name:         "accept",
+			setup:        invite,
+			router:       newBackend,
+			method:       "POST",
+			path:         "/user/invite/invite-key",
+			body:         map[string]string{"password": "coconuts", "password2": "coconuts"},
+			wantFormCode: 303,
+		},
+	}
+
+	for _, tt := range tests {
+		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			if tt.method != "POST" {
+				return
+			}
+
+			var u goatcounter.User
+			err := u.ByID(r.Context(), 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Pending() {
+				t.Error("still pending")
+			}
+			if rr.Header().Get("Location") != "/" {
+				t.Errorf("Location: %q", rr.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestUserOIDC(t *testing.T) {
+	idp := oidctest.New()
+	defer idp.Close()
+
+	// Go through the entire flow, like a browser would.
+	signin := func(t *testing.T, ctx context.Context) *httptest.ResponseRecorder {
+		t.Helper()
+		r, rr := newTest(ctx, "GET", "/user/oidc", nil)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 303)
+		cookies := rr.Result().Cookies()
+		if len(cookies) != 1 {
+			t.Fatalf("cookies: %v", cookies)
+		}
+
+		c := idp.Client()
+		c.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		resp, err := c.Get(rr.Header().Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		loc, err := url.Parse(resp.Header.Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loc.Path != "/user/oidc/callback" {
+			t.Fatalf("wrong redirect: %s", loc)
+		}
+
+		r, rr = newTest(ctx, "GET", loc.RequestURI(), nil)
+		r.AddCookie(cookies[0])
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	setup := func(t *testing.T) context.Context {
+		ctx := gctest.DB(t)
+		p := idp.Provider()
+		p.RoleClaim = "groups"
+		p.Domains = []string{"example.com"}
+		goatcounter.Config(ctx).OIDC = p
+		return ctx
+	}
+	users := func(ctx context.Context) string {
+		return zdb.DumpString(ctx, `select user_id, email, access from users order by user_id`)
+	}
+
+	t.Run("new user", func(t *testing.T) {
+		ctx := setup(t)
+		idp.Claims["groups"] = []string{"staff", "settings"}
+
+		rr := signin(t, ctx)
+		ztest.Code(t, rr, 303)
+		if l := rr.Header().Get("Location"); l != "/" {
+			t.Errorf("Location: %q", l)
+		}
+
+		want := `
+			user_id  email                  access
+			1        test@gctest.localhost  o
+			2        sso@example.com        s`
+		if d := zdb.Diff(users(ctx), want); d != "" {
+			t.Error(d)
+		}
+
+		var u goatcounter.User
+		err := u.ByEmail(ctx, "sso@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sessions goatcounter.UserSessions
+		err = sessions.List(ctx, u.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) != 1 || u.Pending() {
+			t.Errorf("not logged in: %#v", u)
+		}
+	})
+
+	t.Run("existing user", func(t *testing.T) {
+		ctx := setup(t)
+		idp.Claims["groups"] = "admin"
+		u := goatcounter.User{Email: "sso@example.com", Access: goatcounter.AccessReadOnly}
+		err := u.Insert(ctx, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ztest.Code(t, signin(t, ctx), 303)
+
+		want := `
+			user_id  email                  access
+			1        test@gctest.localhost  o
+			2        sso@example.com        a`
+		if d := zdb.Diff(users(ctx), want); d != "" {
+			t.Error(d)
+		}
+	})
+
+	t.Run("roles", func(t *testing.T) {
+		ctx := setup(t)
+		goatcounter.Config(ctx).OIDC.Roles = map[string]string{"gc-editors": "settings", "staff": "viewer"}
+		idp.Claims["groups"] = []string{"admin", "staff", "gc-editors"}
+
+		ztest.Code(t, signin(t, ctx), 303)
+
+		want := `
+			user_id  email                  access
+			1        test@gctest.localhost  o
+			2        sso@example.com        s`
+		if d := zdb.Diff(users(ctx), want); d != "" {
+			t.Error(d)
+		}
+	})
+
+	t.Run("wrong domain", func(t *testing.T) {
+		ctx := setup(t)
+		goatcounter.Config(ctx).OIDC.Domains = []string{"example.org"}
+
+		rr := signin(t, ctx)
+		ztest.Code(t, rr, 303)
+		if l := rr.Header().Get("Location"); l != "/user/new" {
+			t.Errorf("Location: %q", l)
+		}
+
+		want := `
+			user_id  email                  access
+			1        test@gctest.localhost  o`
+		if d := zdb.Diff(users(ctx), want); d != "" {
+			t.Error(d)
+		}
+	})
+
+	t.Run("no restrictions", func(t *testing.T) {
+		ctx := setup(t)
+		goatcounter.Config(ctx).OIDC.Domains = nil
+		goatcounter.Config(ctx).OIDC.RoleClaim = ""
+
+		rr := signin(t, ctx)
+		ztest.Code(t, rr, 303)
+		if l := rr.Header().Get("Location"); l != "/user/new" {
+			t.Errorf("Location: %q", l)
+		}
+
+		want := `
+			user_id  email                  access
+			1        test@gctest.localhost  o`
+		if d := zdb.Diff(users(ctx), want); d != "" {
+			t.Error(d)
+		}
+
+		u := goatcounter.User{Email: "sso@example.com", Access: goatcounter.AccessReadOnly}
+		err := u.Insert(ctx, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l := signin(t, ctx).Header().Get("Location"); l != "/" {
+			t.Errorf("existing user: Location: %q", l)
+		}
+	})
+
+	t.Run("no password", func(t *testing.T) {
+		ctx := setup(t)
+
+		r, rr := newTest(ctx, "GET", "/user/new", nil)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 200)
+		if b := rr.Body.String(); !strings.Contains(b, "Sign in with single sign-on") || strings.Contains(b, "requestlogin") {
+			t.Errorf("wrong body:\n%s", b)
+		}
+
+		r, rr = newTest(ctx, "POST", "/user/requestlogin", strings.NewReader(`{"email":"test@gctest.localhost","password":"coconuts"}`))
+		r.Header.Set("Content-Type", "application/json")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 403)
+
+		goatcounter.Config(ctx).OIDC.Password = true
+		r, rr = newTest(ctx, "POST", "/user/requestlogin", strings.NewReader(`{"email":"test@gctest.localhost","password":"coconuts"}`))
+		r.Header.Set("Content-Type", "application/json")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 303)
+	})
+}
+
+func TestUserAuthProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		create   bool
+		header   map[string]string
+		path     string
+		wantCode int
+		want     string
+	}{
+		{"existing", "192.0.2.0/24", false,
+			map[string]string{"Remote-Email": "test@gctest.localhost"}, "/settings/main", 200, `
+				user_id  email                  access
+				1        test@gctest.localhost  o`},
+		{"no header", "192.0.2.0/24", false,
+			nil, "/settings/main", 303, `
+				user_id  email                  access
+				1        test@gctest.localhost  o`},
+		{"unknown", "192.0.2.0/24", false,
+			map[string]string{"Remote-Email": "new@example.com"}, "/", 403, `
+				user_id  email                  access
+				1        test@gctest.localhost  o`},
+		{"create", "192.0.2.0/24", true,
+			map[string]string{"Remote-Email": "new@example.com"}, "/", 200, `
+				user_id  email                  access
+				1        test@gctest.localhost  o
+				2        new@example.com        r`},
+		{"untrusted", "10.0.0.1", true,
+			map[string]string{"Remote-Email": "test@gctest.localhost"}, "/settings/main", 303, `
+				user_id  email                  access
+				1        test@gctest.localhost  o`},
+		{"spoofed", "10.0.0.1", true,
+			map[string]string{"Remote-Email": "test@gctest.localhost", "X-Forwarded-For": "10.0.0.1"}, "/settings/main", 303, `
+				user_id  email                  access
+				1        test@gctest.localhost  o`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := gctest.DB(t)
+			p, err := goatcounter.NewAuthProxy("Remote-Email", tt.from, tt.create)
+			if err != nil {
+				t.Fatal(err)
+			}
+			goatcounter.Config(ctx).AuthProxy = p
+
+			r, rr := newTest(ctx, "GET", tt.path, nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+			ztest.Code(t, rr, tt.wantCode)
+
+			got := zdb.DumpString(ctx, `select user_id, email, access from users order by user_id`)
+			if d := zdb.Diff(got, tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestUserWebAuthn(t *testing.T) {
+	const origin, rpID = "https://gctest.localhost", "gctest.localhost"
+
+	post := func(t *testing.T, ctx context.Context, path string, auth bool, c *http.Cookie, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		r, rr := newTest(ctx, "POST", path, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if auth {
+			login(t, r)
+		}
+		if c != nil {
+			r.AddCookie(c)
+		}
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+	session := func(t *testing.T, rr *httptest.ResponseRecorder, opts interface{}) *http.Cookie {
+		t.Helper()
+		ztest.Code(t, rr, 200)
+		zjson.MustUnmarshal(rr.Body.Bytes(), opts)
+		for _, c := range rr.Result().Cookies() {
+			if c.Name == webauthnCookie {
+				return c
+			}
+		}
+		t.Fatal("no webauthn cookie")
+		return nil
+	}
+	dec := func(t *testing.T, s string) []byte {
+		t.Helper()
+		b, err := b64.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	register := func(t *testing.T, ctx context.Context, a *webauthntest.Authenticator) {
+		t.Helper()
+		var opts struct {
+			Challenge string `json:"challenge"`
+			Exclude   []struct {
+				ID string `json:"id"`
+			} `json:"excludeCredentials"`
+		}
+		c := session(t, post(t, ctx, "/user/webauthn/register", true, nil, nil), &opts)
+
+		form := url.Values{
+			"name":        {"My key"},
+			"client_data": {b64.EncodeToString(a.ClientData("webauthn.create", dec(t, opts.Challenge), origin))},
+			"attestation": {b64.EncodeToString(a.Attestation(rpID))},
+		}
+		rr := post(t, ctx, "/user/webauthn/register/finish", true, c, form)
+		ztest.Code(t, rr, 200)
+
+		// Challenge can only be used once.
+		rr = post(t, ctx, "/user/webauthn/register/finish", true, c, form)
+		ztest.Code(t, rr, 400)
+	}
+
+	type loginOpts struct {
+		Challenge string `json:"challenge"`
+		Allow     []struct {
+			ID string `json:"id"`
+		} `json:"allowCredentials"`
+		UV string `json:"userVerification"`
+	}
+	finish := func(t *testing.T, ctx context.Context, a *webauthntest.Authenticator, c *http.Cookie, opts loginOpts, flags byte) *httptest.ResponseRecorder {
+		t.Helper()
+		cd := a.ClientData("webauthn.get", dec(t, opts.Challenge), origin)
+		ad, sig := a.Sign(rpID, flags, cd)
+		return post(t, ctx, "/user/webauthn/login/finish", false, c, url.Values{
+			"id":          {b64.EncodeToString(a.ID)},
+			"client_data": {b64.EncodeToString(cd)},
+			"auth_data":   {b64.EncodeToString(ad)},
+			"signature":   {b64.EncodeToString(sig)},
+		})
+	}
+	loggedIn := func(t *testing.T, rr *httptest.ResponseRecorder) {
+		t.Helper()
+		ztest.Code(t, rr, 200)
+		var got struct {
+			Location string `json:"location"`
+		}
+		err := json.Unmarshal(rr.Body.Bytes(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Location != "/" {
+			t.Errorf("wrong body: %s", rr.Body.String())
+		}
+		for _, c := range rr.Result().Cookies() {
+			if c.Name == "key" && c.Value != "" {
+				return
+			}
+		}
+		t.Errorf("not logged in: %v", rr.Result().Cookies())
+	}
+
+	t.Run("register", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		register(t, ctx, webauthntest.New(webauthn.AlgES256))
+		register(t, ctx, webauthntest.New(webauthn.AlgRS256))
+
+		got := zdb.DumpString(ctx, `select webauthn_credential_id, user_id, name, sign_count from webauthn_credentials`)
+		want := `
+			webauthn_credential_id  user_id  name    sign_count
+			1                       1        My key  1
+			2                       1        My key  1`
+		if d := zdb.Diff(got, want); d != "" {
+			t.Error(d)
+		}
+
+		rr := post(t, ctx, "/user/webauthn/remove/1", true, nil, nil)
+		ztest.Code(t, rr, 303)
+		got = zdb.DumpString(ctx, `select webauthn_credential_id from webauthn_credentials`)
+		if d := zdb.Diff(got, "webauthn_credential_id\n2"); d != "" {
+			t.Error(d)
+		}
+	})
+
+	t.Run("second factor", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		a := webauthntest.New(webauthn.AlgES256)
+		register(t, ctx, a)
+
+		r, rr := newTest(ctx, "POST", "/user/requestlogin", strings.NewReader(`{"email":"test@gctest.localhost","password":"coconuts"}`))
+		r.Header.Set("Content-Type", "application/json")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 200)
+		m := regexp.MustCompile(`data-loginmac="(.+?)"`).FindStringSubmatch(rr.Body.String())
+		if m == nil || strings.Contains(rr.Body.String(), "totp_token") {
+			t.Fatalf("wrong body:\n%s", rr.Body.String())
+		}
+
+		rr = post(t, ctx, "/user/webauthn/login", false, nil, url.Values{"loginmac": {"wrong"}, "user": {"1"}})
+		ztest.Code(t, rr, 403)
+
+		var opts loginOpts
+		c := session(t, post(t, ctx, "/user/webauthn/login", false, nil, url.Values{"loginmac": {m[1]}, "user": {"1"}}), &opts)
+		if len(opts.Allow) != 1 || opts.Allow[0].ID != b64.EncodeToString(a.ID) || opts.UV != "discouraged" {
+			t.Errorf("wrong options: %#v", opts)
+		}
+		loggedIn(t, finish(t, ctx, a, c, opts, webauthntest.FlagUserPresent))
+
+		// Other key.
+		c = session(t, post(t, ctx, "/user/webauthn/login", false, nil, url.Values{"loginmac": {m[1]}, "user": {"1"}}), &opts)
+		rr = finish(t, ctx, webauthntest.New(webauthn.AlgES256), c, opts, webauthntest.FlagUserPresent)
+		ztest.Code(t, rr, 403)
+	})
+
+	t.Run("passwordless", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		a := webauthntest.New(webauthn.AlgES256)
+		register(t, ctx, a)
+
+		var opts loginOpts
+		c := session(t, post(t, ctx, "/user/webauthn/login", false, nil, nil), &opts)
+		if len(opts.Allow) != 0 || opts.UV != "required" {
+			t.Errorf("wrong options: %#v", opts)
+		}
+		rr := finish(t, ctx, a, c, opts, webauthntest.FlagUserPresent)
+		ztest.Code(t, rr, 403)
+
+		c = session(t, post(t, ctx, "/user/webauthn/login", false, nil, nil), &opts)
+		loggedIn(t, finish(t, ctx, a, c, opts, webauthntest.FlagUserPresent|webauthntest.FlagUserVerified))
+
+		got := zdb.DumpString(ctx, `select user_id, sign_count from webauthn_credentials`)
+		want := `
+			user_id  sign_count
+			1        3`
+		if d := zdb.Diff(got, want); d != "" {
+			t.Error(d)
+		}
+	})
+	t.Run("locked", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		a := webauthntest.New(webauthn.AlgES256)
+		register(t, ctx, a)
+
+		err := zdb.Exec(ctx, `update users set login_failures=10, locked_until=$1 where user_id=1`,
+			goatcounter.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var opts loginOpts
+		c := session(t, post(t, ctx, "/user/webauthn/login", false, nil, nil), &opts)
+		rr := finish(t, ctx, a, c, opts, webauthntest.FlagUserPresent|webauthntest.FlagUserVerified)
+		ztest.Code(t, rr, 403)
+		if !strings.Contains(rr.Body.String(), "Too many failed login attempts") {
+			t.Errorf("wrong body: %s", rr.Body.String())
+		}
+	})
+}
+
+func TestUserRecoveryCodes(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	codes := func(t *testing.T, rr *httptest.ResponseRecorder) []string {
+		t.Helper()
+		ztest.Code(t, rr, 200)
+		c := regexp.MustCompile(`[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}`).FindAllString(rr.Body.String(), -1)
+		if len(c) != 10 {
+			t.Fatalf("wrong body:\n%s", rr.Body.String())
+		}
+		return c
+	}
+	left := func(t *testing.T, want string) {
+		t.Helper()
+		got := zdb.DumpString(ctx, `select count(*) as n from totp_recovery_codes`)
+		if d := zdb.Diff(got, "n\n"+want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	// Enable TOTP.
+	u := goatcounter.GetUser(ctx)
+	tok := totp.Token(u.TOTPSecret, time.Now())
+	r, rr := newTest(ctx, "POST", "/user/enable-totp", strings.NewReader(url.Values{"totp_token": {fmt.Sprintf("%06d", tok)}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	login(t, r)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	first := codes(t, rr)
+	left(t, "10")
+
+	// Regenerate.
+	r, rr = newTest(ctx, "POST", "/user/recovery-codes", strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	login(t, r)
+	newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+	second := codes(t, rr)
+	left(t, "10")
+
+	signin := func(t *testing.T, code string) *httptest.ResponseRecorder {
+		t.Helper()
+		r, rr := newTest(ctx, "POST", "/user/requestlogin", strings.NewReader(`{"email":"test@gctest.localhost","password":"coconuts"}`))
+		r.Header.Set("Content-Type", "application/json")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 200)
+		m := regexp.MustCompile(`name="loginmac" value="(.+?)"`).FindStringSubmatch(rr.Body.String())
+		if m == nil || !strings.Contains(rr.Body.String(), "recovery_code") {
+			t.Fatalf("wrong body:\n%s", rr.Body.String())
+		}
+
+		r, rr = newTest(ctx, "POST", "/user/totplogin", strings.NewReader(url.Values{
+			"loginmac": {m[1]}, "user": {"1"}, "recovery_code": {code}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		return rr
+	}
+
+	ztest.Code(t, signin(t, first[0]), 200) // Old code.
+	ztest.Code(t, signin(t, strings.ToUpper(strings.ReplaceAll(second[0], "-", ""))), 303)
+	left(t, "9")
+	ztest.Code(t, signin(t, second[0]), 200) // Already used.
+	left(t, "9")
+}
+
+func TestUserAPIToken(t *testing.T) {
+	ctx := gctest.DB(t)
+	err := zdb.Exec(ctx, `update users set email_verified=1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(t *testing.T, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		form.Set("name", "test")
+		r, rr := newTest(ctx, "POST", "/user/api-token", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		login(t, r)
+		newBackend(zdb.MustGetDB(ctx)).ServeHTTP(rr, r)
+		ztest.Code(t, rr, 303)
+		return rr
+	}
+
+	add(t, url.Values{"permissions": {"count", "stats"}, "sites": {"1"},
+		"ip_ranges": {"192.0.2.0/24, 2001:db8::1"}, "expires_at": {"2040-01-02"}})
+	add(t, url.Values{"ip_ranges": {"nope"}}) // Invalid; not added.
+
+	got := zdb.DumpString(ctx, `select permissions, sites, ip_ranges, expires_at from api_tokens`)
+	want := `
+		permissions  sites  ip_ranges                     expires_at
+		65           1      192.0.2.0/24,2001:db8::1/128  2040-01-02 23:59:59`
+	if d := zdb.Diff(got, want); d != "" {
+		t.Error(d)
+	}
+}
@@ -42,6 +42,7 @@ func (h vcounter) mount(r chi.Router, db zdb.DB) {
 	})
 
 	c.Get("/counter/*", zhttp.Wrap(h.counter))
+	c.Get("/chart/*", zhttp.Wrap(h.chart))
 }
 
 var (
@@ -26,7 +26,6 @@ import (
 	"zgo.at/tz"
 	"zgo.at/zdb"
 	"zgo.at/zhttp"
-	"zgo.at/zhttp/auth"
 	"zgo.at/zhttp/header"
 	"zgo.at/zhttp/mware"
 	"zgo.at/zlog"
@@ -215,7 +214,7 @@ func (h website) doSignup(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	site := goatcounter.Site{Code: args.Code, LinkDomain: args.LinkDomain, Plan: h.defaultPlan}
-	user := goatcounter.User{Email: args.Email, Password: []byte(args.Password)}
+	user := goatcounter.User{Email: args.Email, Password: []byte(args.Password), Access: goatcounter.AccessOwner}
 
 	v := zvalidate.New()
 	if strings.TrimSpace(args.TuringTest) != "9" {
@@ -265,7 +264,7 @@ func (h website) doSignup(w http.ResponseWriter, r *http.Request) error {
 
 	// Create user.
 	user.Site = site.ID
-	err = user.Insert(txctx)
+	err = user.Insert(txctx, false)
 	if err != nil {
 		var vErr *zvalidate.Validator
 		if !errors.As(err, &vErr) {
@@ -292,11 +291,9 @@ func (h website) doSignup(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	err = user.Login(goatcounter.WithSite(r.Context(), &site))
+	err = setLogin(w, r, &user, &site)
 	if err != nil {
 		zlog.Errorf("login during account creation: %w", err)
-	} else {
-		auth.SetCookie(w, *user.LoginToken, cookieDomain(&site, r))
 	}
 
 	ctx := goatcounter.CopyContextValues(r.Context())
@@ -304,7 +301,7 @@ func (h website) doSignup(w http.ResponseWriter, r *http.Request) error {
 		err := blackmail.Send("Welcome to GoatCounter!",
 			blackmail.From("GoatCounter", goatcounter.Config(r.Context()).EmailFrom),
 			blackmail.To(user.Email),
-			blackmail.BodyMustText(goatcounter.TplEmailWelcome{ctx, site, user, goatcounter.Config(ctx).DomainCount}.Render),
+			blackmail.BodyMustText(goatcounter.TplEmailWelcome{Context: ctx, Site: site, User: user, CountDomain: goatcounter.Config(ctx).DomainCount}.Render),
 		)
 		if err != nil {
 			zlog.Errorf("welcome email: %s", err)
@@ -413,7 +410,7 @@ func (h website) doForgot(w http.ResponseWriter, r *http.Request) error {
 		err := blackmail.Send("Your GoatCounter sites",
 			mail.Address{Name: "GoatCounter", Address: goatcounter.Config(ctx).EmailFrom},
 			blackmail.To(args.Email),
-			blackmail.BodyMustText(goatcounter.TplEmailForgotSite{ctx, sites, args.Email}.Render))
+			blackmail.BodyMustText(goatcounter.TplEmailForgotSite{Context: ctx, Sites: sites, Email: args.Email}.Render))
 		if err != nil {
 			zlog.Error(err)
 		}
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+
+	"zgo.at/goatcounter/bgrun"
 	"zgo.at/zdb"
 )
 
@@ -79,6 +81,7 @@ func TestWebsiteSignup(t *testing.T) {
 
 	for _, tt := range tests {
 		runTest(t, tt, func(t *testing.T, rr *httptest.ResponseRecorder, r *http.Request) {
+			bgrun.Wait() // Welcome email.
 			// TODO: test state
 		})
 	}
@@ -43,6 +43,7 @@ type Hit struct {
 	Bot   int        `db:"bot" json:"b,omitempty"`
 
 	RefScheme       *string    `db:"ref_scheme" json:"-"`
+	Channel         string     `db:"channel" json:"-"`
 	UserAgentHeader string     `db:"-" json:"-"`
 	Location        string     `db:"location" json:"-"`
 	FirstVisit      zbool.Bool `db:"first_visit" json:"-"`
@@ -212,6 +213,12 @@ func (h *Hit) Defaults(ctx context.Context, initial bool) error {
 		}
 	}
 	h.Ref = strings.TrimRight(h.Ref, "/")
+	if h.Ref != "" && (h.RefScheme == nil || *h.RefScheme != *RefSchemeCampaign) {
+		if g, ok := site.Settings.RefGroups.Rewrite(h.Ref); ok {
+			h.Ref, h.RefScheme = g, RefSchemeGenerated
+		}
+	}
+	h.Channel = RefChannel(ctx, h.Ref, h.RefScheme)
 
 	if initial {
 		return nil
@@ -39,6 +39,15 @@ type HitListStat struct {
 
 type HitLists []HitList
 
+// PathIDs gets a list of all path IDs.
+func (h HitLists) PathIDs() []int64 {
+	ids := make([]int64, 0, len(h))
+	for _, hh := range h {
+		ids = append(ids, hh.PathID)
+	}
+	return ids
+}
+
 // ListPathsLike lists all paths matching the like pattern.
 func (h *HitLists) ListPathsLike(ctx context.Context, search string, matchTitle bool) error {
 	err := zdb.Select(ctx, h, "load:hit_list.ListPathsLike", zdb.P{
@@ -66,40 +75,64 @@ func (h *HitLists) SiteTotalUnique(ctx context.Context) error {
 	return errors.Wrap(err, "HitLists.SiteTotalUnique")
 }
 
+// topPathsCTE selects the path_id for the top paths in the "top" CTE, ordered by
+// the number of unique visitors.
+//
+// This is used in a join rather than passing the path IDs as a list, as there
+// can be a lot of them for downloads.
+const topPathsCTE = `top as (
+	select path_id from hit_counts
+	where
+		hit_counts.site_id = :site and
+		{{:exclude path_id not in (:exclude) and}}
+		{{:filter path_id in (:filter) and}}
+		hour>=:top_start and hour<=:top_end
+	group by path_id
+	order by sum(total_unique) desc, path_id desc
+	limit :top_limit
+)`
+
+// topPaths gets the parameters for topPathsCTE.
+func topPaths(ctx context.Context, start, end time.Time, filter Filter, exclude []int64, limit int) zdb.P {
+	return zdb.P{
+		"site":      MustGetSite(ctx).ID,
+		"top_start": start,
+		"top_end":   end,
+		"filter":    filter.Paths,
+		"exclude":   exclude,
+		"top_limit": limit,
+	}
+}
+
+// pageLimit gets the number of pages to list; the site's LimitPages setting is
+// used if limit is 0.
+func pageLimit(site *Site, limit int) int {
+	if limit == 0 {
+		return int(zint.NonZero(int64(site.Settings.LimitPages()), 10))
+	}
+	return limit
+}
+
 var allDays = []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 
 // List the top paths for this site in the given time period.
+//
+// This lists at most limit paths; the site's LimitPages setting is used if
+// limit is 0.
 func (h *HitLists) List(
-	ctx context.Context, start, end time.Time, pathFilter, exclude []int64, daily bool,
+	ctx context.Context, start, end time.Time, filter Filter, exclude []int64, limit int, daily bool,
 ) (int, int, bool, error) {
 	site := MustGetSite(ctx)
 
 	// List the pages for this page; this gets the path_id, path, title.
 	var more bool
 	{
-		limit := int(zint.NonZero(int64(site.Settings.LimitPages()), 10))
-		err := zdb.Select(ctx, h, `/* HitLists.List */
-			with x as (
-				select path_id from hit_counts
-				where
-					hit_counts.site_id = :site and
-					{{:exclude path_id not in (:exclude) and}}
-					{{:filter path_id in (:filter) and}}
-					hour>=:start and hour<=:end
-				group by path_id
-				order by sum(total_unique) desc, path_id desc
-				limit :limit
-			)
-			select path_id, paths.path, paths.title, paths.event from x
+		limit = pageLimit(site, limit)
+		err := filter.sel(ctx, h, start, end, `/* HitLists.List */
+			with `+topPathsCTE+`
+			select path_id, paths.path, paths.title, paths.event from top
 			join paths using (path_id)`,
-			zdb.P{
-				"site":    site.ID,
-				"start":   start,
-				"end":     end,
-				"filter":  pathFilter,
-				"limit":   limit + 1,
-				"exclude": exclude,
-			})
+			topPaths(ctx, start, end, filter, exclude, limit+1))
 		if err != nil {
 			return 0, 0, false, errors.Wrap(err, "HitLists.List hit_counts")
 		}
@@ -120,52 +153,44 @@ func (h *HitLists) List(
 	// Get stats for every page.
 	hh := *h
 	var st []struct {
-		PathID      int64     `db:"path_id"`
-		Day         time.Time `db:"day"`
-		Stats       []byte    `db:"stats"`
-		StatsUnique []byte    `db:"stats_unique"`
+		PathID      int64      `db:"path_id"`
+		Day         filterTime `db:"day"`
+		Stats       []byte     `db:"stats"`
+		StatsUnique []byte     `db:"stats_unique"`
 	}
-	{
-		paths := make([]int64, len(hh))
-		for i := range hh {
-			paths[i] = hh[i].PathID
-		}
-
-		err := zdb.Select(ctx, &st, `/* HitLists.List */
-			select path_id, day, stats, stats_unique
-			from hit_stats
-			where
-				hit_stats.site_id = :site and
-				path_id in (:paths) and
-				day >= :start and day <= :end
-			order by day asc`,
-			zdb.P{
-				"site":  site.ID,
-				"start": start.Format("2006-01-02"),
-				"end":   end.Format("2006-01-02"),
-				"paths": paths,
-			})
-		if err != nil {
-			return 0, 0, false, errors.Wrap(err, "HitLists.List hit_stats")
-		}
+	p := topPaths(ctx, start, end, filter, exclude, limit+1)
+	p["start"], p["end"] = start.Format("2006-01-02"), end.Format("2006-01-02")
+	err := filter.sel(ctx, &st, start, end, `/* HitLists.List */
+		with `+topPathsCTE+`
+		select hit_stats.path_id, day, stats, stats_unique
+		from hit_stats
+		join top using (path_id)
+		where
+			hit_stats.site_id = :site and
+			day >= :start and day <= :end
+		order by day asc`, p)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "HitLists.List hit_stats")
 	}
 
 	// Add the hit_stats.
-	{
-		for i := range hh {
-			for _, s := range st {
-				if s.PathID == hh[i].PathID {
-					var x, y []int
-					zjson.MustUnmarshal(s.Stats, &x)
-					zjson.MustUnmarshal(s.StatsUnique, &y)
-					hh[i].Stats = append(hh[i].Stats, HitListStat{
-						Day:          s.Day.Format("2006-01-02"),
-						Hourly:       x,
-						HourlyUnique: y,
-					})
-				}
-			}
+	idx := make(map[int64]int, len(hh))
+	for i := range hh {
+		idx[hh[i].PathID] = i
+	}
+	for _, s := range st {
+		i, ok := idx[s.PathID]
+		if !ok { // The extra path to check if there are more.
+			continue
 		}
+		var x, y []int
+		zjson.MustUnmarshal(s.Stats, &x)
+		zjson.MustUnmarshal(s.StatsUnique, &y)
+		hh[i].Stats = append(hh[i].Stats, HitListStat{
+			Day:          s.Day.Format("2006-01-02"),
+			Hourly:       x,
+			HourlyUnique: y,
+		})
 	}
 
 	// Fill in blank days.
@@ -187,19 +212,19 @@ func (h *HitLists) List(
 const PathTotals = "TOTAL "
 
 // Totals gets the data for the "Totals" chart/widget.
-func (h *HitList) Totals(ctx context.Context, start, end time.Time, pathFilter []int64, daily bool) (int, error) {
+func (h *HitList) Totals(ctx context.Context, start, end time.Time, filter Filter, daily bool) (int, error) {
 	site := MustGetSite(ctx)
 
 	var tc []struct {
-		Hour        time.Time `db:"hour"`
-		Total       int       `db:"total"`
-		TotalUnique int       `db:"total_unique"`
+		Hour        filterTime `db:"hour"`
+		Total       int        `db:"total"`
+		TotalUnique int        `db:"total_unique"`
 	}
-	err := zdb.Select(ctx, &tc, "load:hit_list.Totals", zdb.P{
+	err := filter.sel(ctx, &tc, start, end, "load:hit_list.Totals", zdb.P{
 		"site":      site.ID,
 		"start":     start,
 		"end":       end,
-		"filter":    pathFilter,
+		"filter":    filter.Paths,
 		"no_events": site.Settings.TotalsNoEvents(),
 	})
 	if err != nil {
@@ -438,17 +463,17 @@ type TotalCount struct {
 // UTC. This is needed since the _stats tables are per day, rather than
 // per-hour, so we need to use the correct totals to make sure the percentage
 // calculations are accurate.
-func GetTotalCount(ctx context.Context, start, end time.Time, pathFilter []int64) (TotalCount, error) {
+func GetTotalCount(ctx context.Context, start, end time.Time, filter Filter) (TotalCount, error) {
 	site := MustGetSite(ctx)
 
 	var t TotalCount
-	err := zdb.Get(ctx, &t, "load:hit_list.GetTotalCount", zdb.P{
+	err := filter.get(ctx, &t, start, end, "load:hit_list.GetTotalCount", zdb.P{
 		"site":      site.ID,
 		"start":     start,
 		"end":       end,
 		"start_utc": start.In(site.Settings.Timezone.Location),
 		"end_utc":   end.In(site.Settings.Timezone.Location),
-		"filter":    pathFilter,
+		"filter":    filter.Paths,
 		"no_events": site.Settings.TotalsNoEvents(),
 		"tz":        site.Settings.Timezone.Offset(),
 	})
@@ -457,7 +482,7 @@ func GetTotalCount(ctx context.Context, start, end time.Time, pathFilter []int64
 
 // GetMax gets the path with the higest number of pageviews per hour or day for
 // this date range.
-func GetMax(ctx context.Context, start, end time.Time, pathFilter []int64, daily bool) (int, error) {
+func GetMax(ctx context.Context, start, end time.Time, filter Filter, daily bool) (int, error) {
 	site := MustGetSite(ctx)
 	var (
 		query  string
@@ -470,7 +495,7 @@ func GetMax(ctx context.Context, start, end time.Time, pathFilter []int64, daily
 			"start":  start,
 			"end":    end,
 			"tz":     site.Settings.Timezone.OffsetRFC3339(),
-			"filter": pathFilter,
+			"filter": filter.Paths,
 			"pgsql":  zdb.Driver(ctx) == zdb.DriverPostgreSQL,
 			"sqlite": zdb.Driver(ctx) == zdb.DriverSQLite,
 		}
@@ -480,12 +505,12 @@ func GetMax(ctx context.Context, start, end time.Time, pathFilter []int64, daily
 			"site":   site.ID,
 			"start":  start,
 			"end":    end,
-			"filter": pathFilter,
+			"filter": filter.Paths,
 		}
 	}
 
 	var max int
-	err := zdb.Get(ctx, &max, query, params)
+	err := filter.get(ctx, &max, start, end, query, params)
 	if err != nil && !zdb.ErrNoRows(err) {
 		return 0, errors.Wrap(err, "getMax")
 	}
@@ -495,3 +520,58 @@ func GetMax(ctx context.Context, start, end time.Time, pathFilter []int64, daily
 	}
 	return max, nil
 }
+
+// Heatmap is the number of pageviews per day of the week and hour of the day,
+// in the site's timezone.
+//
+// The first index is the day of the week as time.Weekday (0 is Sunday), the
+// second the hour.
+type Heatmap struct {
+	Total       [7][24]int `json:"total"`
+	TotalUnique [7][24]int `json:"total_unique"`
+	Max         int        `json:"max"`
+	MaxUnique   int        `json:"max_unique"`
+}
+
+// GetHeatmap gets the pageviews per day of the week and hour for this date
+// range.
+func GetHeatmap(ctx context.Context, start, end time.Time, filter Filter) (Heatmap, error) {
+	site := MustGetSite(ctx)
+
+	var hc []struct {
+		Hour        filterTime `db:"hour"`
+		Total       int        `db:"total"`
+		TotalUnique int        `db:"total_unique"`
+	}
+	err := filter.sel(ctx, &hc, start, end, "load:hit_list.Heatmap", zdb.P{
+		"site":   site.ID,
+		"start":  start,
+		"end":    end,
+		"filter": filter.Paths,
+	})
+	if err != nil {
+		return Heatmap{}, errors.Wrap(err, "GetHeatmap")
+	}
+
+	// hit_counts is stored per hour in UTC, so this needs to be done here
+	// rather than in the query.
+	var hm Heatmap
+	loc := site.Settings.Timezone.Loc()
+	for _, h := range hc {
+		t := h.Hour.In(loc)
+		d, hour := t.Weekday(), t.Hour()
+		hm.Total[d][hour] += h.Total
+		hm.TotalUnique[d][hour] += h.TotalUnique
+	}
+	for d := range hm.Total {
+		for hour := range hm.Total[d] {
+			if hm.Total[d][hour] > hm.Max {
+				hm.Max = hm.Total[d][hour]
+			}
+			if hm.TotalUnique[d][hour] > hm.MaxUnique {
+				hm.MaxUnique = hm.TotalUnique[d][hour]
+			}
+		}
+	}
+	return hm, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	. "zgo.at/goatcounter"
 	"zgo.at/goatcounter/gctest"
+	"zgo.at/tz"
 	"zgo.at/zdb"
 	"zgo.at/zstd/zjson"
 	"zgo.at/zstd/ztest"
@@ -164,13 +165,13 @@ func TestHitStatsList(t *testing.T) {
 
 			gctest.StoreHits(ctx, t, false, tt.in...)
 
-			pathsFilter, err := PathFilter(ctx, tt.inFilter, true)
+			filter, err := NewFilter(ctx, tt.inFilter)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			var stats HitLists
-			totalDisplay, uniqueDisplay, more, err := stats.List(ctx, start, end, pathsFilter, tt.inExclude, false)
+			totalDisplay, uniqueDisplay, more, err := stats.List(ctx, start, end, filter, tt.inExclude, 0, false)
 
 			got := fmt.Sprintf("%d %d %t %v", totalDisplay, uniqueDisplay, more, err)
 			if got != tt.wantReturn {
@@ -213,7 +214,7 @@ func TestGetMax(t *testing.T) {
 
 	t.Run("hourly", func(t *testing.T) {
 		want := []int{11, 11, 10, 11}
-		for i, filter := range [][]int64{nil, []int64{1}, []int64{2}, []int64{1, 2}} {
+		for i, filter := range []Filter{{}, {Paths: []int64{1}}, {Paths: []int64{2}}, {Paths: []int64{1, 2}}} {
 			got, err := GetMax(ctx, start, end, filter, false)
 			if err != nil {
 				t.Fatal(err)
@@ -227,7 +228,7 @@ func TestGetMax(t *testing.T) {
 
 	t.Run("daily", func(t *testing.T) {
 		want := []int{11, 11, 10, 11}
-		for i, filter := range [][]int64{nil, []int64{1}, []int64{2}, []int64{1, 2}} {
+		for i, filter := range []Filter{{}, {Paths: []int64{1}}, {Paths: []int64{2}}, {Paths: []int64{1, 2}}} {
 			got, err := GetMax(ctx, start, end, filter, true)
 			if err != nil {
 				t.Fatal(err)
@@ -255,7 +256,7 @@ func TestGetTotalCount(t *testing.T) {
 		Hit{Path: "ev", FirstVisit: false, Event: true})
 
 	{
-		tt, err := GetTotalCount(ctx, start, end, nil)
+		tt, err := GetTotalCount(ctx, start, end, Filter{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -267,6 +268,43 @@ func TestGetTotalCount(t *testing.T) {
 	}
 }
 
+func TestGetHeatmap(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:00:00") // Thursday
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/a", FirstVisit: true},
+		Hit{Path: "/b", FirstVisit: true},
+		Hit{Path: "/a"},
+	)
+
+	start, end := Now().Add(-24*time.Hour), Now().Add(24*time.Hour)
+	hm, err := GetHeatmap(ctx, start, end, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hm.Total[time.Thursday][12] != 3 || hm.TotalUnique[time.Thursday][12] != 2 || hm.Max != 3 || hm.MaxUnique != 2 {
+		t.Errorf("wrong heatmap: %v", hm)
+	}
+
+	hm, err = GetHeatmap(ctx, start, end, Filter{Paths: []int64{2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hm.Total[time.Thursday][12] != 1 || hm.Max != 1 {
+		t.Errorf("wrong heatmap with filter: %v", hm)
+	}
+
+	MustGetSite(ctx).Settings.Timezone = tz.MustNew("", "Pacific/Honolulu") // -10:00
+	hm, err = GetHeatmap(ctx, start, end, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hm.Total[time.Thursday][2] != 3 || hm.Total[time.Thursday][12] != 0 {
+		t.Errorf("wrong heatmap with timezone: %v", hm)
+	}
+}
+
 func TestHitListTotals(t *testing.T) {
 	gctest.SetNow(t, "2020-06-18 12:00:00")
 	ctx := gctest.DB(t)
@@ -303,7 +341,7 @@ func TestHitListTotals(t *testing.T) {
 			`12 {"Count":12,"CountUnique":2,"PathID":0,"Path":"TOTAL ","Event":false,"Title":"","RefScheme":null,"Max":0,"Stats":[` +
 				`{"Day":"2020-06-18","Hourly":[0,0,0,0,0,0,0,0,0,0,0,0,12,0,0,0,0,0,0,0,0,0,0,0],"HourlyUnique":[0,0,0,0,0,0,0,0,0,0,0,0,2,0,0,0,0,0,0,0,0,0,0,0],"Daily":0,"DailyUnique":0}]}`,
 		}
-		for i, filter := range [][]int64{nil, []int64{1}, []int64{2}, []int64{1, 2}} {
+		for i, filter := range []Filter{{}, {Paths: []int64{1}}, {Paths: []int64{2}}, {Paths: []int64{1, 2}}} {
 			var hs HitList
 			count, err := hs.Totals(ctx, start, end, filter, false)
 			if err != nil {
@@ -336,7 +374,7 @@ func TestHitListTotals(t *testing.T) {
 				`{"Day":"2020-06-18","Hourly":[0,0,0,0,0,0,0,0,0,0,0,0,12,0,0,0,0,0,0,0,0,0,0,0],"HourlyUnique":[0,0,0,0,0,0,0,0,0,0,0,0,2,0,0,0,0,0,0,0,0,0,0,0],"Daily":12,"DailyUnique":2}]}`,
 		}
 
-		for i, filter := range [][]int64{nil, []int64{1}, []int64{2}, []int64{1, 2}} {
+		for i, filter := range []Filter{{}, {Paths: []int64{1}}, {Paths: []int64{2}}, {Paths: []int64{1, 2}}} {
 			var hs HitList
 			count, err := hs.Totals(ctx, start, end, filter, true)
 			if err != nil {
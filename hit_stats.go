@@ -32,25 +32,25 @@ func asUTCDate(s *Site, t time.Time) string {
 }
 
 // ByRef lists all paths by referrer.
-func (h *HitStats) ByRef(ctx context.Context, start, end time.Time, pathFilter []int64, ref string) error {
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ByRef", zdb.P{
+func (h *HitStats) ByRef(ctx context.Context, start, end time.Time, filter Filter, ref string) error {
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ByRef", zdb.P{
 		"site":   MustGetSite(ctx).ID,
 		"start":  start,
 		"end":    end,
-		"filter": pathFilter,
+		"filter": filter.Paths,
 		"ref":    ref,
 	})
 	return errors.Wrap(err, "HitStats.ByRef")
 }
 
 // ListBrowsers lists all browser statistics for the given time period.
-func (h *HitStats) ListBrowsers(ctx context.Context, start, end time.Time, pathFilter []int64, limit, offset int) error {
+func (h *HitStats) ListBrowsers(ctx context.Context, start, end time.Time, filter Filter, limit, offset int) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListBrowsers", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListBrowsers", zdb.P{
 		"site":   site.ID,
 		"start":  asUTCDate(site, start),
 		"end":    asUTCDate(site, end),
-		"filter": pathFilter,
+		"filter": filter.Paths,
 		"limit":  limit + 1,
 		"offset": offset,
 	})
@@ -62,26 +62,26 @@ func (h *HitStats) ListBrowsers(ctx context.Context, start, end time.Time, pathF
 }
 
 // ListBrowser lists all the versions for one browser.
-func (h *HitStats) ListBrowser(ctx context.Context, browser string, start, end time.Time, pathFilter []int64) error {
+func (h *HitStats) ListBrowser(ctx context.Context, browser string, start, end time.Time, filter Filter) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListBrowser", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListBrowser", zdb.P{
 		"site":    site.ID,
 		"start":   asUTCDate(site, start),
 		"end":     asUTCDate(site, end),
-		"filter":  pathFilter,
+		"filter":  filter.Paths,
 		"browser": browser,
 	})
 	return errors.Wrap(err, "HitStats.ListBrowser")
 }
 
 // ListSystems lists OS statistics for the given time period.
-func (h *HitStats) ListSystems(ctx context.Context, start, end time.Time, pathFilter []int64, limit, offset int) error {
+func (h *HitStats) ListSystems(ctx context.Context, start, end time.Time, filter Filter, limit, offset int) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListSystems", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListSystems", zdb.P{
 		"site":   site.ID,
 		"start":  asUTCDate(site, start),
 		"end":    asUTCDate(site, end),
-		"filter": pathFilter,
+		"filter": filter.Paths,
 		"limit":  limit + 1,
 		"offset": offset,
 	})
@@ -93,13 +93,13 @@ func (h *HitStats) ListSystems(ctx context.Context, start, end time.Time, pathFi
 }
 
 // ListSystem lists all the versions for one system.
-func (h *HitStats) ListSystem(ctx context.Context, system string, start, end time.Time, pathFilter []int64) error {
+func (h *HitStats) ListSystem(ctx context.Context, system string, start, end time.Time, filter Filter) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListSystem", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListSystem", zdb.P{
 		"site":   site.ID,
 		"start":  asUTCDate(site, start),
 		"end":    asUTCDate(site, end),
-		"filter": pathFilter,
+		"filter": filter.Paths,
 		"system": system,
 	})
 	return errors.Wrap(err, "HitStats.ListSystem")
@@ -115,13 +115,13 @@ const (
 )
 
 // ListSizes lists all device sizes.
-func (h *HitStats) ListSizes(ctx context.Context, start, end time.Time, pathFilter []int64) error {
+func (h *HitStats) ListSizes(ctx context.Context, start, end time.Time, filter Filter) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListSizes", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListSizes", zdb.P{
 		"site":   site.ID,
 		"start":  asUTCDate(site, start),
 		"end":    asUTCDate(site, end),
-		"filter": pathFilter,
+		"filter": filter.Paths,
 	})
 	if err != nil {
 		return errors.Wrap(err, "HitStats.ListSize")
@@ -166,7 +166,7 @@ func (h *HitStats) ListSizes(ctx context.Context, start, end time.Time, pathFilt
 }
 
 // ListSize lists all sizes for one grouping.
-func (h *HitStats) ListSize(ctx context.Context, name string, start, end time.Time, pathFilter []int64) error {
+func (h *HitStats) ListSize(ctx context.Context, name string, start, end time.Time, filter Filter) error {
 	var (
 		min_size, max_size int
 		empty              bool
@@ -189,11 +189,11 @@ func (h *HitStats) ListSize(ctx context.Context, name string, start, end time.Ti
 	}
 
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListSize", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListSize", zdb.P{
 		"site":     site.ID,
 		"start":    asUTCDate(site, start),
 		"end":      asUTCDate(site, end),
-		"filter":   pathFilter,
+		"filter":   filter.Paths,
 		"min_size": min_size,
 		"max_size": max_size,
 		"empty":    empty,
@@ -208,13 +208,13 @@ func (h *HitStats) ListSize(ctx context.Context, name string, start, end time.Ti
 }
 
 // ListLocations lists all location statistics for the given time period.
-func (h *HitStats) ListLocations(ctx context.Context, start, end time.Time, pathFilter []int64, limit, offset int) error {
+func (h *HitStats) ListLocations(ctx context.Context, start, end time.Time, filter Filter, limit, offset int) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListLocations", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListLocations", zdb.P{
 		"site":   site.ID,
 		"start":  asUTCDate(site, start),
 		"end":    asUTCDate(site, end),
-		"filter": pathFilter,
+		"filter": filter.Paths,
 		"limit":  limit + 1,
 		"offset": offset,
 	})
@@ -226,13 +226,13 @@ func (h *HitStats) ListLocations(ctx context.Context, start, end time.Time, path
 }
 
 // ListLocation lists all divisions for a location
-func (h *HitStats) ListLocation(ctx context.Context, country string, start, end time.Time, pathFilter []int64) error {
+func (h *HitStats) ListLocation(ctx context.Context, country string, start, end time.Time, filter Filter) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:hit_stats.ListLocation", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:hit_stats.ListLocation", zdb.P{
 		"site":    site.ID,
 		"start":   asUTCDate(site, start),
 		"end":     asUTCDate(site, end),
-		"filter":  pathFilter,
+		"filter":  filter.Paths,
 		"country": country,
 	})
 	return errors.Wrap(err, "HitStats.ListLocation")
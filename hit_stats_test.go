@@ -41,7 +41,7 @@ func TestHitStats(t *testing.T) {
 		}
 	}
 
-	for _, filter := range [][]int64{nil} {
+	for _, filter := range []Filter{{}} {
 		// Browsers
 		{
 			var list HitStats
@@ -313,7 +313,7 @@ func TestListSizes(t *testing.T) {
 
 	t.Run("ListSizes", func(t *testing.T) {
 		var s HitStats
-		err := s.ListSizes(ctx, now, now, nil)
+		err := s.ListSizes(ctx, now, now, Filter{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -375,7 +375,7 @@ func TestListSizes(t *testing.T) {
 		var got string
 		for _, w := range widths {
 			var s HitStats
-			err := s.ListSize(ctx, w.name, now, now, nil)
+			err := s.ListSize(ctx, w.name, now, now, Filter{})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -479,7 +479,7 @@ func TestStatsByRef(t *testing.T) {
 		Hit{Path: "/a", Ref: "https://example.org"})
 
 	var s HitStats
-	err := s.ByRef(ctx, Now().Add(-1*time.Hour), Now().Add(1*time.Hour), []int64{1}, "example.com")
+	err := s.ByRef(ctx, Now().Add(-1*time.Hour), Now().Add(1*time.Hour), Filter{Paths: []int64{1}}, "example.com")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -166,10 +166,14 @@ func TestNewFollow(t *testing.T) {
 			time.Sleep(10 * time.Millisecond)
 			echo(l)
 		}
-
-		stop()
 	}()
 
+	// Stop once everything is read, rather than after the last write, as the
+	// follower may not have read the last line yet. Don't hang forever if it
+	// never does.
+	timeout := time.AfterFunc(10*time.Second, stop)
+	defer timeout.Stop()
+
 	data := []Line{}
 	for {
 		line, err := scan.Line(ctx)
@@ -181,6 +185,9 @@ func TestNewFollow(t *testing.T) {
 		}
 
 		data = append(data, line)
+		if len(data) == len(lines)*2 {
+			stop()
+		}
 	}
 
 	if len(data) != 8 {
@@ -241,7 +241,7 @@ func (m *ms) Persist(ctx context.Context) ([]Hit, error) {
 
 	newHits := make([]Hit, 0, len(hits))
 	ins := zdb.NewBulkInsert(ctx, "hits", []string{"site_id", "path_id", "ref",
-		"ref_scheme", "user_agent_id", "size", "location", "created_at", "bot",
+		"ref_scheme", "channel", "user_agent_id", "size", "location", "created_at", "bot",
 		"session", "first_visit"})
 	for _, h := range hits {
 		// Ignore spammers.
@@ -309,7 +309,7 @@ func (m *ms) Persist(ctx context.Context) ([]Hit, error) {
 		// insert them.
 		newHits = append(newHits, h)
 
-		ins.Values(h.Site, h.PathID, h.Ref, h.RefScheme, h.UserAgentID, h.Size,
+		ins.Values(h.Site, h.PathID, h.Ref, h.RefScheme, h.Channel, h.UserAgentID, h.Size,
 			h.Location, h.CreatedAt, h.Bot, h.Session, h.FirstVisit)
 	}
 
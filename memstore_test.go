@@ -95,8 +95,8 @@ func TestCollect(t *testing.T) {
 
 	out := strings.TrimSpace(zdb.DumpString(ctx, `select * from hits`))
 	want := strings.TrimSpace(`
-hit_id  site_id  path_id  user_agent_id  session                           bot  ref  ref_scheme  size  location  first_visit  created_at
-1       2        1        NULL           00112233445566778899aabbccddeeff  0         NULL                        0            2020-06-18 12:00:00`)
+hit_id  site_id  path_id  user_agent_id  session                           bot  ref  ref_scheme  channel  size  location  first_visit  created_at
+1       2        1        NULL           00112233445566778899aabbccddeeff  0         NULL        direct                   0            2020-06-18 12:00:00`)
 
 	if out != want {
 		t.Error(out)
@@ -0,0 +1,458 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package oidc implements login with OpenID Connect.
+//
+// Only the authorization code flow with ID tokens signed with RS256 is
+// supported; this is what every provider supports and uses by default.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/zstd/zstring"
+)
+
+// Provider is an OpenID Connect identity provider.
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	// Only allow users with an email address on these domains; any domain is
+	// allowed if this is empty.
+	Domains []string
+
+	// Claim to get the user's access level from; the claim can be a string or
+	// a list of strings. The default is to give new users read-only access.
+	RoleClaim string
+
+	// Map values in RoleClaim to the roles "admin", "settings", or "viewer";
+	// the values are used as the role if this is empty.
+	Roles map[string]string
+
+	// Also accept email addresses if the provider doesn't report them as
+	// verified with the email_verified claim.
+	AllowUnverifiedEmail bool
+
+	// Also allow logging in with a password.
+	Password bool
+
+	// HTTP client to use for requests to the provider.
+	Client *http.Client
+
+	mu   sync.Mutex
+	meta *metadata
+	keys map[string]*rsa.PublicKey
+}
+
+type metadata struct {
+	Issuer        string `json:"issuer"`
+	AuthEndpoint  string `json:"authorization_endpoint"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JWKSURI       string `json:"jwks_uri"`
+}
+
+// Parse the provider from a URL, in the form of:
+//
+//	https://issuer?client_id=..&client_secret=..&domains=..&role_claim=..&roles=..&password=..
+//
+// The domains parameter is a comma-separated list. The roles parameter is a
+// comma-separated list of value:role pairs, such as "gc-admins:admin,staff:viewer".
+// The secret can also be read from a file with client_secret_file=.. instead
+// of client_secret.
+//
+// Email addresses that the provider doesn't report as verified are rejected,
+// unless allow_unverified_email=true is set.
+func Parse(s string) (*Provider, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.Parse")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, errors.Errorf("oidc.Parse: issuer must be a http or https URL: %q", s)
+	}
+
+	q := u.Query()
+	p := &Provider{
+		ClientID:     q.Get("client_id"),
+		ClientSecret: q.Get("client_secret"),
+		RoleClaim:    q.Get("role_claim"),
+	}
+	if p.ClientID == "" {
+		return nil, errors.Errorf("oidc.Parse: client_id is required")
+	}
+	if f := q.Get("client_secret_file"); f != "" {
+		if p.ClientSecret != "" {
+			return nil, errors.Errorf("oidc.Parse: can't use both client_secret and client_secret_file")
+		}
+		secret, err := os.ReadFile(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "oidc.Parse: reading client_secret_file")
+		}
+		p.ClientSecret = strings.TrimSpace(string(secret))
+	}
+	if d := q.Get("domains"); d != "" {
+		for _, dd := range strings.Split(d, ",") {
+			if dd = strings.ToLower(strings.TrimSpace(dd)); dd != "" {
+				p.Domains = append(p.Domains, dd)
+			}
+		}
+	}
+	if r := q.Get("roles"); r != "" {
+		p.Roles = make(map[string]string)
+		for _, rr := range strings.Split(r, ",") {
+			rr = strings.TrimSpace(rr)
+			if rr == "" {
+				continue
+			}
+			i := strings.LastIndexByte(rr, ':')
+			if i == -1 {
+				return nil, errors.Errorf("oidc.Parse: invalid value for roles: %q: not in the form value:role", rr)
+			}
+			value, role := strings.TrimSpace(rr[:i]), strings.TrimSpace(rr[i+1:])
+			if !zstring.Contains([]string{"admin", "settings", "viewer"}, role) {
+				return nil, errors.Errorf("oidc.Parse: invalid value for roles: unknown role %q", role)
+			}
+			p.Roles[value] = role
+		}
+	}
+	if pw := q.Get("password"); pw != "" {
+		p.Password, err = strconv.ParseBool(pw)
+		if err != nil {
+			return nil, errors.Errorf("oidc.Parse: invalid value for password: %q", pw)
+		}
+	}
+	if a := q.Get("allow_unverified_email"); a != "" {
+		p.AllowUnverifiedEmail, err = strconv.ParseBool(a)
+		if err != nil {
+			return nil, errors.Errorf("oidc.Parse: invalid value for allow_unverified_email: %q", a)
+		}
+	}
+
+	u.RawQuery = ""
+	p.Issuer = strings.TrimRight(u.String(), "/")
+	return p, nil
+}
+
+// AllowEmail reports if this email address is on one of the allowed domains.
+func (p *Provider) AllowEmail(email string) bool {
+	if len(p.Domains) == 0 {
+		return true
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at == -1 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range p.Domains {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthURL gets the URL to redirect the user to.
+//
+// The state is passed back to the redirect URL, and the nonce is included in
+// the ID token; both should be random and verified after the redirect.
+func (p *Provider) AuthURL(ctx context.Context, redirect, state, nonce string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{
+		"response_type": {"code"},
+		"scope":         {"openid email profile"},
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {redirect},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	if strings.Contains(meta.AuthEndpoint, "?") {
+		return meta.AuthEndpoint + "&" + q.Encode(), nil
+	}
+	return meta.AuthEndpoint + "?" + q.Encode(), nil
+}
+
+// Exchange the code from the redirect for an ID token, and verify it.
+func (p *Provider) Exchange(ctx context.Context, code, redirect, nonce string) (Claims, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirect},
+	}
+	r, err := http.NewRequestWithContext(ctx, "POST", meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.Exchange")
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	var tok struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+		Desc    string `json:"error_description"`
+	}
+	err = p.do(r, &tok)
+	if err != nil {
+		if tok.Error != "" {
+			return nil, errors.Errorf("oidc.Exchange: %s: %s", tok.Error, tok.Desc)
+		}
+		return nil, errors.Wrap(err, "oidc.Exchange")
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("oidc.Exchange: no id_token in response")
+	}
+
+	claims, err := p.verify(ctx, tok.IDToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.Exchange")
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, errors.New("oidc.Exchange: nonce doesn't match")
+	}
+	return claims, nil
+}
+
+// Claims in an ID token.
+type Claims map[string]interface{}
+
+// Email gets the email address from the claims; this is blank if the provider
+// doesn't report it as verified, unless AllowUnverifiedEmail is set.
+func (p *Provider) Email(c Claims) string {
+	if v, _ := c["email_verified"].(bool); !v && !p.AllowUnverifiedEmail {
+		return ""
+	}
+	e, _ := c["email"].(string)
+	return e
+}
+
+// Role gets the role for a value in the RoleClaim.
+func (p *Provider) Role(value string) string {
+	if len(p.Roles) == 0 {
+		return value
+	}
+	return p.Roles[value]
+}
+
+// Strings gets all values for a claim that is either a string or a list of
+// strings.
+func (c Claims) Strings(claim string) []string {
+	switch v := c[claim].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		s := make([]string, 0, len(v))
+		for _, vv := range v {
+			if str, ok := vv.(string); ok {
+				s = append(s, str)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
+// verify the signature and the iss, aud, and exp claims of an ID token.
+func (p *Provider) verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed ID token")
+	}
+
+	var head struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	err := decodePart(parts[0], &head)
+	if err != nil {
+		return nil, err
+	}
+	if head.Alg != "RS256" {
+		return nil, errors.Errorf("unsupported signing algorithm %q", head.Alg)
+	}
+
+	key, err := p.key(ctx, head.Kid)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding signature")
+	}
+	h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(key, crypto.SHA256, h[:], sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	var claims Claims
+	err = decodePart(parts[1], &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if iss, _ := claims["iss"].(string); strings.TrimRight(iss, "/") != p.Issuer {
+		return nil, errors.Errorf("wrong issuer %q", iss)
+	}
+	if !zstring.Contains(claims.Strings("aud"), p.ClientID) {
+		return nil, errors.Errorf("wrong audience %q", claims.Strings("aud"))
+	}
+	exp, _ := claims["exp"].(float64)
+	// Allow a bit of leeway for clocks being out of sync.
+	if time.Unix(int64(exp), 0).Add(time.Minute).Before(time.Now()) {
+		return nil, errors.New("token has expired")
+	}
+	return claims, nil
+}
+
+func (p *Provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	r, err := http.NewRequestWithContext(ctx, "GET", p.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.metadata")
+	}
+	var meta metadata
+	err = p.do(r, &meta)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.metadata")
+	}
+	if strings.TrimRight(meta.Issuer, "/") != p.Issuer {
+		return nil, errors.Errorf("oidc.metadata: issuer in discovery document is %q, not %q", meta.Issuer, p.Issuer)
+	}
+	if meta.AuthEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc.metadata: discovery document is missing endpoints")
+	}
+
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// key gets a signing key by ID; the keys are fetched again if it's not known,
+// as providers rotate their keys from time to time.
+func (p *Provider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	k, ok := p.keys[kid]
+	p.mu.Unlock()
+	if ok {
+		return k, nil
+	}
+
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := http.NewRequestWithContext(ctx, "GET", meta.JWKSURI, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.key")
+	}
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	err = p.do(r, &jwks)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.key")
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Wrapf(err, "oidc.key: key %q", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Wrapf(err, "oidc.key: key %q", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	k, ok = keys[kid]
+	if !ok {
+		return nil, errors.Errorf("oidc.key: unknown key %q", kid)
+	}
+	return k, nil
+}
+
+// do a request and decode the JSON response in to scan.
+func (p *Provider) do(r *http.Request, scan interface{}) error {
+	c := p.Client
+	if c == nil {
+		c = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := c.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	// Decode errors as well, as the token endpoint returns details in the JSON.
+	jsonErr := json.Unmarshal(b, scan)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", r.Method, r.URL, resp.Status)
+	}
+	return jsonErr
+}
+
+func decodePart(part string, scan interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.Wrap(err, "decoding ID token")
+	}
+	err = json.Unmarshal(b, scan)
+	if err != nil {
+		return errors.Wrap(err, "decoding ID token")
+	}
+	return nil
+}
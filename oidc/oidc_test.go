@@ -0,0 +1,209 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"zgo.at/goatcounter/oidc"
+	"zgo.at/goatcounter/oidc/oidctest"
+	"zgo.at/zstd/ztest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *oidc.Provider
+		wantErr string
+	}{
+		{"https://sso.example.com/?client_id=gc", &oidc.Provider{
+			Issuer: "https://sso.example.com", ClientID: "gc"}, ""},
+		{"https://sso.example.com/realm?client_id=gc&client_secret=s3cr3t&domains=Example.com,+example.org&role_claim=groups&password=true",
+			&oidc.Provider{
+				Issuer: "https://sso.example.com/realm", ClientID: "gc", ClientSecret: "s3cr3t",
+				Domains: []string{"example.com", "example.org"}, RoleClaim: "groups", Password: true,
+			}, ""},
+		{"https://sso.example.com?client_id=gc&role_claim=groups&roles=gc-admins:admin,+urn:x:staff:viewer&allow_unverified_email=true",
+			&oidc.Provider{
+				Issuer: "https://sso.example.com", ClientID: "gc", RoleClaim: "groups",
+				Roles:                map[string]string{"gc-admins": "admin", "urn:x:staff": "viewer"},
+				AllowUnverifiedEmail: true,
+			}, ""},
+
+		{"https://sso.example.com", nil, "client_id is required"},
+		{"sso.example.com?client_id=gc", nil, "must be a http or https URL"},
+		{"https://sso.example.com?client_id=gc&password=maybe", nil, "invalid value for password"},
+		{"https://sso.example.com?client_id=gc&roles=admin", nil, "not in the form value:role"},
+		{"https://sso.example.com?client_id=gc&roles=x:owner", nil, `unknown role "owner"`},
+		{"https://sso.example.com?client_id=gc&allow_unverified_email=maybe", nil, "invalid value for allow_unverified_email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			p, err := oidc.Parse(tt.in)
+			if !ztest.ErrorContains(err, tt.wantErr) {
+				t.Fatalf("wrong error\nwant: %s\ngot:  %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(p, tt.want) {
+				t.Errorf("\nwant: %#v\ngot:  %#v", tt.want, p)
+			}
+		})
+	}
+}
+
+func TestParseSecretFile(t *testing.T) {
+	f := ztest.TempFile(t, "s3cr3t\n")
+	p, err := oidc.Parse("https://sso.example.com?client_id=gc&client_secret_file=" + f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ClientSecret != "s3cr3t" {
+		t.Errorf("ClientSecret: %q", p.ClientSecret)
+	}
+
+	_, err = oidc.Parse("https://sso.example.com?client_id=gc&client_secret=x&client_secret_file=" + f)
+	if !ztest.ErrorContains(err, "can't use both") {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestAllowEmail(t *testing.T) {
+	p := &oidc.Provider{}
+	if !p.AllowEmail("a@example.com") {
+		t.Error("no domains")
+	}
+
+	p.Domains = []string{"example.com"}
+	for email, want := range map[string]bool{
+		"a@example.com":     true,
+		"a@EXAMPLE.com":     true,
+		"a@example.org":     false,
+		"a@sub.example.com": false,
+		"example.com":       false,
+	} {
+		if got := p.AllowEmail(email); got != want {
+			t.Errorf("%q: got %t; want %t", email, got, want)
+		}
+	}
+}
+
+func TestExchange(t *testing.T) {
+	idp := oidctest.New()
+	defer idp.Close()
+	idp.Claims["groups"] = []string{"staff", "admin"}
+
+	ctx := context.Background()
+	const redirect = "http://gc.example.com/user/oidc/callback"
+
+	// Get a code from the authorization endpoint, like a browser would.
+	login := func(t *testing.T, p *oidc.Provider, nonce string) string {
+		t.Helper()
+		u, err := p.AuthURL(ctx, redirect, "state", nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := idp.Client()
+		c.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		resp, err := c.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		loc, err := url.Parse(resp.Header.Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(loc.String(), redirect) || loc.Query().Get("state") != "state" {
+			t.Fatalf("wrong redirect: %q", loc)
+		}
+		return loc.Query().Get("code")
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		p := idp.Provider()
+		claims, err := p.Exchange(ctx, login(t, p, "nonce"), redirect, "nonce")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := p.Email(claims); e != "sso@example.com" {
+			t.Errorf("email: %q", e)
+		}
+		if g := claims.Strings("groups"); !reflect.DeepEqual(g, []string{"staff", "admin"}) {
+			t.Errorf("groups: %q", g)
+		}
+	})
+
+	t.Run("reused code", func(t *testing.T) {
+		p := idp.Provider()
+		code := login(t, p, "nonce")
+		_, err := p.Exchange(ctx, code, redirect, "nonce")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = p.Exchange(ctx, code, redirect, "nonce")
+		if !ztest.ErrorContains(err, "invalid_grant") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		p := idp.Provider()
+		_, err := p.Exchange(ctx, login(t, p, "nonce"), redirect, "other")
+		if !ztest.ErrorContains(err, "nonce doesn't match") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		p := idp.Provider()
+		p.ClientSecret = "wrong"
+		_, err := p.Exchange(ctx, login(t, p, "nonce"), redirect, "nonce")
+		if !ztest.ErrorContains(err, "invalid_client") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		idp.Claims["email_verified"] = false
+		defer func() { idp.Claims["email_verified"] = true }()
+
+		p := idp.Provider()
+		claims, err := p.Exchange(ctx, login(t, p, "nonce"), redirect, "nonce")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := p.Email(claims); e != "" {
+			t.Errorf("email: %q", e)
+		}
+
+		p.AllowUnverifiedEmail = true
+		if e := p.Email(claims); e != "sso@example.com" {
+			t.Errorf("email with AllowUnverifiedEmail: %q", e)
+		}
+	})
+
+	t.Run("no email_verified", func(t *testing.T) {
+		delete(idp.Claims, "email_verified")
+		defer func() { idp.Claims["email_verified"] = true }()
+
+		p := idp.Provider()
+		claims, err := p.Exchange(ctx, login(t, p, "nonce"), redirect, "nonce")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := p.Email(claims); e != "" {
+			t.Errorf("email: %q", e)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package oidctest provides a stand-in OpenID Connect provider for tests.
+//
+// The authorization endpoint doesn't ask for anything, but immediately
+// redirects back with the claims in Server.Claims.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"zgo.at/goatcounter/oidc"
+)
+
+// Server is a stand-in OpenID Connect provider.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	// Claims to add to ID tokens, in addition to iss, aud, exp, iat, and nonce.
+	Claims map[string]interface{}
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]map[string]interface{}
+}
+
+// New starts a new server; it should be closed with Close().
+func New() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		ClientID:     "goatcounter",
+		ClientSecret: "secret",
+		Claims:       map[string]interface{}{"email": "sso@example.com", "email_verified": true},
+		key:          key,
+		codes:        make(map[string]map[string]interface{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Provider gets a provider for this server.
+func (s *Server) Provider() *oidc.Provider {
+	return &oidc.Provider{
+		Issuer:       s.URL,
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		Client:       s.Client(),
+	}
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, 200, map[string]string{
+		"issuer":                 s.URL,
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != s.ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "wrong client_id or response_type", 400)
+		return
+	}
+	rdr, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || rdr.Host == "" {
+		http.Error(w, "invalid redirect_uri", 400)
+		return
+	}
+
+	claims := map[string]interface{}{
+		"iss":   s.URL,
+		"aud":   s.ClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": q.Get("nonce"),
+	}
+	s.mu.Lock()
+	for k, v := range s.Claims {
+		claims[k] = v
+	}
+	code := fmt.Sprintf("code-%d", len(s.codes)+1)
+	s.codes[code] = claims
+	s.mu.Unlock()
+
+	rq := rdr.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	rdr.RawQuery = rq.Encode()
+	http.Redirect(w, r, rdr.String(), http.StatusSeeOther)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, _ := r.BasicAuth()
+	if id != url.QueryEscape(s.ClientID) || secret != url.QueryEscape(s.ClientSecret) {
+		writeJSON(w, 401, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		writeJSON(w, 400, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	s.mu.Lock()
+	claims, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, 400, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]string{
+		"access_token": "access-" + code,
+		"token_type":   "Bearer",
+		"id_token":     s.sign(claims),
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, 200, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (s *Server) sign(claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		j, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(j)
+	}
+
+	t := enc(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"}) + "." + enc(claims)
+	h := sha256.Sum256([]byte(t))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, h[:])
+	if err != nil {
+		panic(err)
+	}
+	return t + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,134 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/zdb"
+)
+
+// OverviewTotals are the number of pageviews and visitors in the selected
+// period and the period before that.
+type OverviewTotals struct {
+	Total           int `json:"total"`
+	TotalUnique     int `json:"total_unique"`
+	PrevTotal       int `json:"prev_total"`
+	PrevTotalUnique int `json:"prev_total_unique"`
+}
+
+// HasDelta reports if there is a previous period to compare against.
+func (t OverviewTotals) HasDelta() bool { return t.PrevTotalUnique > 0 }
+
+// Delta gets the change in visitors compared to the previous period as a
+// percentage, rounded down.
+func (t OverviewTotals) Delta() int {
+	if t.PrevTotalUnique == 0 {
+		return 0
+	}
+	return int(float64(t.TotalUnique-t.PrevTotalUnique) / float64(t.PrevTotalUnique) * 100)
+}
+
+// OverviewSite is a single site in the Overview.
+type OverviewSite struct {
+	OverviewTotals
+	Site Site `json:"-"`
+
+	// Visitors per day, in the site's timezone.
+	Days []int `json:"days"`
+}
+
+// Overview is a summary of the stats for several sites in the same account.
+type Overview struct {
+	OverviewTotals
+	Sites []OverviewSite
+
+	// Pages and referrers combined for all sites; the ID isn't set.
+	TopPaths HitStats
+	TopRefs  HitStats
+}
+
+// Get the overview for the sites in the period between start and end.
+//
+// The totals are compared against the period of the same length immediately
+// before start. Callers need to make sure that all sites belong to the same
+// account, as this isn't checked.
+func (o *Overview) Get(ctx context.Context, sites Sites, start, end time.Time) error {
+	if len(sites) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(sites))
+	for _, s := range sites {
+		ids = append(ids, s.ID)
+	}
+
+	var (
+		prevEnd   = start.Add(-time.Second)
+		prevStart = prevEnd.Add(-end.Sub(start))
+		params    = zdb.P{"sites": ids, "start": start, "end": end, "prev_start": prevStart}
+	)
+
+	var totals []struct {
+		SiteID      int64     `db:"site_id"`
+		Hour        time.Time `db:"hour"`
+		Total       int       `db:"total"`
+		TotalUnique int       `db:"total_unique"`
+	}
+	err := zdb.Select(ctx, &totals, "load:overview.Totals", params)
+	if err != nil {
+		return errors.Wrap(err, "Overview.Get")
+	}
+
+	o.Sites = make([]OverviewSite, 0, len(sites))
+	bySite := make(map[int64]int, len(sites))
+	for i, s := range sites {
+		bySite[s.ID] = i
+		o.Sites = append(o.Sites, OverviewSite{Site: s, Days: make([]int, overviewDays(s, start, end)+1)})
+	}
+	for _, t := range totals {
+		s := &o.Sites[bySite[t.SiteID]]
+		if t.Hour.Before(start) {
+			s.PrevTotal += t.Total
+			s.PrevTotalUnique += t.TotalUnique
+			continue
+		}
+
+		s.Total += t.Total
+		s.TotalUnique += t.TotalUnique
+		if d := overviewDays(s.Site, start, t.Hour); d >= 0 && d < len(s.Days) {
+			s.Days[d] += t.TotalUnique
+		}
+	}
+	for _, s := range o.Sites {
+		o.Total += s.Total
+		o.TotalUnique += s.TotalUnique
+		o.PrevTotal += s.PrevTotal
+		o.PrevTotalUnique += s.PrevTotalUnique
+	}
+
+	err = zdb.Select(ctx, &o.TopPaths.Stats, "load:overview.TopPaths", params)
+	if err != nil {
+		return errors.Wrap(err, "Overview.Get")
+	}
+	err = zdb.Select(ctx, &o.TopRefs.Stats, "load:overview.TopRefs", params)
+	if err != nil {
+		return errors.Wrap(err, "Overview.Get")
+	}
+	return nil
+}
+
+// overviewDays gets the number of days between start and t in the site's
+// timezone.
+func overviewDays(s Site, start, t time.Time) int {
+	loc := s.Settings.Timezone.Loc()
+	day := func(t time.Time) time.Time {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	return int(day(t).Sub(day(start)).Hours() / 24)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+)
+
+func TestOverview(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:00:00")
+	ctx := gctest.DB(t)
+
+	site := MustGetSite(ctx)
+	child := Site{Code: "child", Parent: &site.ID, Plan: PlanChild}
+	err := child.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/a", FirstVisit: true},
+		Hit{Path: "/a", FirstVisit: true, CreatedAt: Now().Add(-24 * time.Hour)},
+		Hit{Path: "/b", FirstVisit: true, CreatedAt: Now().Add(-8 * 24 * time.Hour)},
+		Hit{Site: child.ID, Path: "/a", FirstVisit: true, Ref: "http://example.com"},
+		Hit{Site: child.ID, Path: "/c"},
+	)
+
+	var sites Sites
+	err = sites.ForThisAccount(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 12, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 6, 18, 23, 59, 59, 0, time.UTC)
+
+	var o Overview
+	err = o.Get(ctx, sites, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		got := fmt.Sprintf("%v", o.OverviewTotals)
+		want := "{4 3 1 1}"
+		if got != want {
+			t.Errorf("totals\ngot:  %s\nwant: %s", got, want)
+		}
+		if d := o.Delta(); d != 200 {
+			t.Errorf("delta: %d", d)
+		}
+	}
+
+	{
+		var got string
+		for _, s := range o.Sites {
+			got += fmt.Sprintf("%s %v %v %d\n", s.Site.Code, s.OverviewTotals, s.Days, s.Delta())
+		}
+		want := "child {2 1 0 0} [0 0 0 0 0 0 1] 0\n" +
+			"gctest {2 2 1 1} [0 0 0 0 0 1 1] 100\n"
+		if got != want {
+			t.Errorf("sites\ngot:  %s\nwant: %s", got, want)
+		}
+	}
+
+	{
+		var got string
+		for _, s := range o.TopPaths.Stats {
+			got += fmt.Sprintf("%s %d %d; ", s.Name, s.Count, s.CountUnique)
+		}
+		want := "/a 3 3; /c 1 0; "
+		if got != want {
+			t.Errorf("top paths\ngot:  %s\nwant: %s", got, want)
+		}
+	}
+
+	{
+		var got string
+		for _, s := range o.TopRefs.Stats {
+			got += fmt.Sprintf("%q %d %d; ", s.Name, s.Count, s.CountUnique)
+		}
+		want := `"" 3 2; "example.com" 1 1; `
+		if got != want {
+			t.Errorf("top refs\ngot:  %s\nwant: %s", got, want)
+		}
+	}
+}
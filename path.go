@@ -133,37 +133,19 @@ func (p Path) updateTitle(ctx context.Context, currentTitle, newTitle string) er
 	return nil
 }
 
-// PathFilter returns a list of IDs matching the path name.
+// PathIDs returns the IDs for the path, matched case-insensitive.
 //
-// if matchTitle is true it will match the title as well.
-func PathFilter(ctx context.Context, filter string, matchTitle bool) ([]int64, error) {
+// The returned slice always has at least one entry, so it can be used as a
+// filter directly.
+func PathIDs(ctx context.Context, path string) ([]int64, error) {
 	var paths []int64
-
-	// The limit is here because that's the limit in SQL parameters; the
-	// returned []int64 is passed as parameters later on.
-	//
-	// Having (and scrolling!) more than 65k pages is a rather curious usage
-	// pattern: it has happened, but this was just because they were sending
-	// data with many unique IDs in the URL which really ought to be removed.
-	err := zdb.Select(ctx, &paths, `/* PathFilter */
+	err := zdb.Select(ctx, &paths, `/* PathIDs */
 		select path_id from paths
-		where
-			site_id = :site and (
-				lower(path) like lower(:filter)
-				{{:match_title or lower(title) like lower(:filter)}}
-			)
-		limit 65500`,
-		zdb.P{
-			"site":        MustGetSite(ctx).ID,
-			"filter":      "%" + filter + "%",
-			"match_title": matchTitle,
-		})
+		where site_id = :site and lower(path) = lower(:path)`,
+		zdb.P{"site": MustGetSite(ctx).ID, "path": path})
 	if err != nil {
-		return nil, errors.Wrap(err, "PathFilter")
+		return nil, errors.Wrap(err, "PathIDs")
 	}
-
-	// Nothing matches: make sure there's a slice with an invalid path_id, so
-	// the queries using the result don't select anything.
 	if len(paths) == 0 {
 		paths = []int64{-1}
 	}
@@ -18,13 +18,105 @@
 
 		;[report_errors, dashboard, period_select, tooltip, billing_subscribe,
 			setup_datepicker, filter_pages, add_ip, fill_tz, bind_scale,
-			copy_pre, widget_settings, saved_views,
+			copy_pre, widget_settings, saved_views, confirm_forms, webauthn,
 		].forEach(function(f) { f.call() })
 	})
 
 	// Set up all the dashboard widget contents (but not the header).
 	var dashboard = function() {
-		[draw_chart, paginate_pages, load_refs, hchart_detail, ref_pages].forEach(function(f) { f.call() })
+		[draw_chart, paginate_pages, load_refs, hchart_detail, worldmap_detail, ref_pages, download_links].forEach(function(f) { f.call() })
+	}
+
+	// Add the current period and filter to the widget download links.
+	var download_links = function() {
+		$('.download-link').on('click', function(e) {
+			var href = $(this).attr('href').split('?')[0]
+			$(this).attr('href', href + '?' + $.param(append_period({
+				daily: $('#daily').is(':checked'),
+			})))
+		})
+	}
+
+	// Ask for confirmation before submitting forms with data-confirm, or with
+	// a submit button with data-confirm.
+	var confirm_forms = function() {
+		$('form[data-confirm]').on('submit', function(e) {
+			if (!confirm($(this).attr('data-confirm')))
+				e.preventDefault()
+		})
+		$('button[data-confirm]').on('click', function(e) {
+			if (!confirm($(this).attr('data-confirm')))
+				e.preventDefault()
+		})
+	}
+
+	// Register security keys and sign in with them.
+	var webauthn = function() {
+		if (!$('#webauthn-register, .webauthn-login').length)
+			return
+		if (!window.PublicKeyCredential) {
+			$('#webauthn-register, .webauthn-login').css('display', 'none')
+			$('.webauthn-unsupported').css('display', '')
+			return
+		}
+
+		var to_b64 = function(buf) {
+				return btoa(String.fromCharCode.apply(null, new Uint8Array(buf))).
+					replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '')
+			},
+			from_b64 = function(s) {
+				return Uint8Array.from(atob(s.replace(/-/g, '+').replace(/_/g, '/')), (c) => c.charCodeAt(0))
+			},
+			post = function(url, data, success) {
+				jQuery.ajax({
+					url:     url,
+					method:  'POST',
+					data:    $.extend({csrf: CSRF}, data),
+					global:  false,  // Don't report expected errors such as a wrong key.
+					success: success,
+					error:   function(xhr) { alert((xhr.responseJSON && xhr.responseJSON.error) || xhr.responseText) },
+				})
+			},
+			fail = function(err) {
+				if (err.name !== 'NotAllowedError') // Cancelled by the user.
+					alert(`Security key error: ${err.message}`)
+			}
+
+		$('#webauthn-register').on('submit', function(e) {
+			e.preventDefault()
+			var name = $('#webauthn-name').val()
+			post('/user/webauthn/register', {}, function(opts) {
+				opts.challenge = from_b64(opts.challenge)
+				opts.user.id   = from_b64(opts.user.id)
+				opts.excludeCredentials.forEach((c) => c.id = from_b64(c.id))
+
+				navigator.credentials.create({publicKey: opts}).then(function(cred) {
+					post('/user/webauthn/register/finish', {
+						name:        name,
+						client_data: to_b64(cred.response.clientDataJSON),
+						attestation: to_b64(cred.response.attestationObject),
+					}, (data) => location.href = data.location)
+				}).catch(fail)
+			})
+		})
+
+		$('.webauthn-login').on('submit', function(e) {
+			e.preventDefault()
+			var form = $(this)
+			post('/user/webauthn/login', {loginmac: form.attr('data-loginmac') || '', user: form.attr('data-user') || 0}, function(opts) {
+				opts.challenge = from_b64(opts.challenge)
+				opts.allowCredentials.forEach((c) => c.id = from_b64(c.id))
+
+				navigator.credentials.get({publicKey: opts}).then(function(cred) {
+					post('/user/webauthn/login/finish', {
+						id:          to_b64(cred.rawId),
+						client_data: to_b64(cred.response.clientDataJSON),
+						auth_data:   to_b64(cred.response.authenticatorData),
+						signature:   to_b64(cred.response.signature),
+					}, (data) => location.href = data.location)
+				}).catch(fail)
+			})
+		})
 	}
 
 	// Set up error reporting.
@@ -67,19 +159,18 @@
 			$('body').on('click.saved-views',   (e) => { if (!$(e.target).closest('#dash-saved-views').length) close() })
 		})
 
-		$('.save-current-view').on('click', function(e) {
-			e.preventDefault()
+		var save = function(btn, name, success) {
 			var p = $('#dash-select-period').attr('class').substr(7)
 			if (p === '')
 				p = (get_date($('#period-end').val()) - get_date($('#period-start').val())) / 86400000
 
-			var done = paginate_button($(this), () => {
+			var done = paginate_button(btn, () => {
 				jQuery.ajax({
 					url:    '/settings/view',
 					method: 'POST',
 					data: {
 						csrf:      CSRF,
-						name:      'default',
+						name:      name,
 						filter:    $('#filter-paths').val(),
 						daily:     $('#daily').is(':checked'),
 						'as-text': $('#as-text').is(':checked'),
@@ -87,12 +178,32 @@
 					},
 					success: () => {
 						done()
-						var s = $('<em> Saved!</em>')
-						$(this).after(s)
-						setTimeout(() => s.remove(), 2000)
+						success()
 					},
 				})
 			})
+		}
+
+		$('.save-current-view').on('click', function(e) {
+			e.preventDefault()
+			save($(this), $('#dash-view').val() || 'default', () => {
+				var s = $('<em> Saved!</em>')
+				$(this).after(s)
+				setTimeout(() => s.remove(), 2000)
+			})
+		})
+
+		$('.save-new-view').on('click', function(e) {
+			e.preventDefault()
+			var name = prompt('Name for the new view:')
+			if (!name || name.trim() === '')
+				return
+			name = name.trim()
+			save($(this), name, () => location.href = '/?view=' + encodeURIComponent(name))
+		})
+
+		$('#dash-view').on('change', function(e) {
+			location.href = '/' + (this.value === 'default' ? '' : '?view=' + encodeURIComponent(this.value))
 		})
 	}
 
@@ -287,6 +398,13 @@
 				reload:    't',
 			}),
 			success: function(data) {
+				$('.filter-error').text(data.filter_error || '')
+				if (data.filter_error) {
+					if (done)
+						done()
+					return
+				}
+
 				$('#dash-widgets').html(data.widgets)
 				$('#dash-timerange').html(data.timerange)
 				dashboard()
@@ -485,6 +603,27 @@
 		})
 	}
 
+	// Show the regions when clicking on a country in the world map.
+	var worldmap_detail = function() {
+		$('.worldmap').on('click', '.load-map-detail', function(e) {
+			e.preventDefault()
+
+			var map    = $(this).closest('.worldmap'),
+				detail = map.find('.worldmap-detail'),
+				name   = $(this).attr('data-name')
+			if (detail.attr('data-name') === name)
+				return detail.attr('data-name', '').html('')
+
+			jQuery.ajax({
+				url:     map.attr('data-detail'),
+				data:    append_period({name: name, total: $('.js-total-unique-utc').text()}),
+				success: function(data) {
+					detail.attr('data-name', name).html(data.html)
+				},
+			})
+		})
+	}
+
 	// Set up the widgets settings tab
 	//
 	// TODO: my iPhone selects text on dragging. I can't get it to stop doing
@@ -696,6 +835,10 @@
 				}
 
 				title += !views ? ', future' : `, ${unique} ${ev ? 'unique clicks' : 'visits'}; <span class="views">${views} ${ev ? 'total clicks' : 'pageviews'}</span>`
+
+				// Annotations.
+				if (t.attr('data-a'))
+					title += t.attr('data-a').split('\n').map((a) => `<br><span class="annotation">${$('<span>').text(a).html()}</span>`).join('')
 			}
 			t.attr('data-title', title).removeAttr('title')
 
@@ -831,6 +974,8 @@
 		data['period-start'] = $('#period-start').val()
 		data['period-end']   = $('#period-end').val()
 		data['filter']       = $('#filter-paths').val()
+		if ($('#dash-view').length)
+			data['view'] = $('#dash-view').val()
 		return data
 	}
 
@@ -0,0 +1,130 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// APIRatelimit is the number of requests allowed per period (in seconds).
+type APIRatelimit struct {
+	Limit  int
+	Period int64
+}
+
+// APIRatelimits are the rate limits for groups of API endpoints; every token
+// gets its own limit for every group.
+type APIRatelimits map[string]APIRatelimit
+
+// APIRatelimitGroups are the groups of API endpoints with their own rate
+// limits, and the defaults for them.
+var APIRatelimitGroups = APIRatelimits{
+	"default": {60, 120},
+	"count":   {60, 120},
+	"export":  {10, 3600},
+	"sites":   {30, 120},
+}
+
+// NewAPIRatelimits creates rate limits from a comma-separated list of
+// group:limit/period, such as "export:5/3600,sites:10/60". Groups that aren't
+// given use the defaults from APIRatelimitGroups.
+func NewAPIRatelimits(s string) (APIRatelimits, error) {
+	l, err := ParseAPIRatelimits(s)
+	if err != nil {
+		return nil, fmt.Errorf("NewAPIRatelimits: %w", err)
+	}
+	for k, v := range APIRatelimitGroups {
+		if _, ok := l[k]; !ok {
+			l[k] = v
+		}
+	}
+	return l, nil
+}
+
+// ParseAPIRatelimits parses a comma-separated list of group:limit/period, such
+// as "export:5/3600,sites:10/60"; only the groups that are given are set.
+func ParseAPIRatelimits(s string) (APIRatelimits, error) {
+	l := make(APIRatelimits)
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+
+		var (
+			group  string
+			limit  int
+			period int64
+		)
+		c := strings.Index(f, ":")
+		if c > -1 {
+			group = f[:c]
+			_, err := fmt.Sscanf(f[c+1:], "%d/%d", &limit, &period)
+			if err != nil || limit < 1 || period < 1 {
+				c = -1
+			}
+		}
+		if c == -1 {
+			return nil, fmt.Errorf("invalid rate limit %q; must be as group:limit/period", f)
+		}
+		if _, ok := APIRatelimitGroups[group]; !ok {
+			return nil, fmt.Errorf("unknown group %q", group)
+		}
+		l[group] = APIRatelimit{limit, period}
+	}
+	return l, nil
+}
+
+// Get the rate limit for a group, falling back to the defaults if it's not
+// set.
+func (l APIRatelimits) Get(group string) APIRatelimit {
+	if r, ok := l[group]; ok {
+		return r
+	}
+	if r, ok := APIRatelimitGroups[group]; ok {
+		return r
+	}
+	return APIRatelimitGroups["default"]
+}
+
+func (l APIRatelimits) String() string {
+	groups := make([]string, 0, len(l))
+	for g := range l {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+
+	var b strings.Builder
+	for i, g := range groups {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s:%d/%d", g, l[g].Limit, l[g].Period)
+	}
+	return b.String()
+}
+
+// Scan converts the data from the DB.
+func (l *APIRatelimits) Scan(v interface{}) error {
+	if v == nil {
+		*l = nil
+		return nil
+	}
+	var err error
+	*l, err = ParseAPIRatelimits(fmt.Sprintf("%s", v))
+	return err
+}
+
+// MarshalText converts the rate limits to group:limit/period.
+func (l APIRatelimits) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+// UnmarshalText parses the rate limits from group:limit/period.
+func (l *APIRatelimits) UnmarshalText(v []byte) error {
+	var err error
+	*l, err = ParseAPIRatelimits(string(v))
+	return err
+}
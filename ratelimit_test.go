@@ -0,0 +1,80 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"testing"
+
+	"zgo.at/goatcounter"
+	"zgo.at/zstd/ztest"
+)
+
+func TestNewAPIRatelimits(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr string
+		want    map[string]goatcounter.APIRatelimit
+	}{
+		{"", "", map[string]goatcounter.APIRatelimit{
+			"default": {60, 120},
+			"export":  {10, 3600},
+		}},
+		{"export:5/60, sites:1/1", "", map[string]goatcounter.APIRatelimit{
+			"default": {60, 120},
+			"export":  {5, 60},
+			"sites":   {1, 1},
+		}},
+
+		{"export", "invalid rate limit", nil},
+		{"export:5", "invalid rate limit", nil},
+		{"export:0/60", "invalid rate limit", nil},
+		{"nope:5/60", "unknown group", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			l, err := goatcounter.NewAPIRatelimits(tt.in)
+			if !ztest.ErrorContains(err, tt.wantErr) {
+				t.Fatalf("wrong error\nwant: %s\ngot:  %v", tt.wantErr, err)
+			}
+			for group, want := range tt.want {
+				if got := l.Get(group); got != want {
+					t.Errorf("%q: got %v; want %v", group, got, want)
+				}
+			}
+		})
+	}
+
+	var l goatcounter.APIRatelimits
+	if got := l.Get("nope"); got != goatcounter.APIRatelimitGroups["default"] {
+		t.Errorf("nil: %v", got)
+	}
+}
+
+func TestParseAPIRatelimits(t *testing.T) {
+	l, err := goatcounter.ParseAPIRatelimits(" sites:1/1,export:5/60 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 2 {
+		t.Errorf("groups that aren't given are set: %v", l)
+	}
+	if s := l.String(); s != "export:5/60, sites:1/1" {
+		t.Errorf("wrong string: %q", s)
+	}
+
+	var scan goatcounter.APIRatelimits
+	err = scan.Scan(l.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scan.String() != l.String() {
+		t.Errorf("wrong scan: %v", scan)
+	}
+	err = scan.Scan(nil)
+	if err != nil || scan != nil {
+		t.Errorf("scan nil: %v, %v", scan, err)
+	}
+}
@@ -7,10 +7,13 @@ package goatcounter
 import (
 	"context"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"zgo.at/errors"
+	"zgo.at/json"
 	"zgo.at/zdb"
 	"zgo.at/zstd/zint"
 )
@@ -23,6 +26,134 @@ var (
 	RefSchemeCampaign  = ptr("c")
 )
 
+// Referrer channels, stored in the channel column.
+const (
+	ChannelDirect   = "direct"
+	ChannelCampaign = "campaign"
+	ChannelSearch   = "search"
+	ChannelSocial   = "social"
+	ChannelEmail    = "email"
+	ChannelReferral = "referral"
+)
+
+// Channels is a list of all channels, in the order they should be displayed.
+var Channels = []string{ChannelDirect, ChannelCampaign, ChannelSearch,
+	ChannelSocial, ChannelEmail, ChannelReferral}
+
+// ChannelLabel gets the human-readable label for a channel.
+func ChannelLabel(ch string) string {
+	switch ch {
+	case ChannelDirect:
+		return "Direct"
+	case ChannelCampaign:
+		return "Campaigns"
+	case ChannelSearch:
+		return "Search engines"
+	case ChannelSocial:
+		return "Social media"
+	case ChannelEmail:
+		return "Email"
+	case ChannelReferral:
+		return "Other websites"
+	}
+	return ch
+}
+
+// Built-in mapping of referrers to channels; this is matched against the
+// referrer as stored (after cleanRefURL) and against the host and all parent
+// domains of it, so "reddit.com" matches "old.reddit.com" as well.
+var channels = map[string]string{
+	"Google":           ChannelSearch,
+	"Yahoo":            ChannelSearch,
+	"Baidu":            ChannelSearch,
+	"bing.com":         ChannelSearch,
+	"duckduckgo.com":   ChannelSearch,
+	"ecosia.org":       ChannelSearch,
+	"qwant.com":        ChannelSearch,
+	"startpage.com":    ChannelSearch,
+	"search.brave.com": ChannelSearch,
+	"yandex.ru":        ChannelSearch,
+	"yandex.com":       ChannelSearch,
+	"search.aol.com":   ChannelSearch,
+	"naver.com":        ChannelSearch,
+	"seznam.cz":        ChannelSearch,
+
+	"Hacker News":        ChannelSocial,
+	"Telegram Messenger": ChannelSocial,
+	"Slack Chat":         ChannelSocial,
+	"reddit.com":         ChannelSocial,
+	"facebook.com":       ChannelSocial,
+	"twitter.com":        ChannelSocial,
+	"t.co":               ChannelSocial,
+	"linkedin.com":       ChannelSocial,
+	"lnkd.in":            ChannelSocial,
+	"lobste.rs":          ChannelSocial,
+	"instagram.com":      ChannelSocial,
+	"pinterest.com":      ChannelSocial,
+	"tumblr.com":         ChannelSocial,
+	"youtube.com":        ChannelSocial,
+	"mastodon.social":    ChannelSocial,
+	"vk.com":             ChannelSocial,
+	"weibo.com":          ChannelSocial,
+	"discord.com":        ChannelSocial,
+	"tildes.net":         ChannelSocial,
+
+	"Email":                 ChannelEmail,
+	"mail.google.com":       ChannelEmail,
+	"mail.yahoo.com":        ChannelEmail,
+	"outlook.live.com":      ChannelEmail,
+	"outlook.office.com":    ChannelEmail,
+	"outlook.office365.com": ChannelEmail,
+	"mail.proton.me":        ChannelEmail,
+	"mail.protonmail.com":   ChannelEmail,
+	"fastmail.com":          ChannelEmail,
+}
+
+// RefChannel classifies a referrer in to one of the channels.
+//
+// The site's RefChannels setting takes precedence over the built-in list.
+func RefChannel(ctx context.Context, ref string, refScheme *string) string {
+	if refScheme != nil && *refScheme == *RefSchemeCampaign {
+		return ChannelCampaign
+	}
+	if ref == "" {
+		return ChannelDirect
+	}
+
+	if ch := matchChannel(MustGetSite(ctx).Settings.RefChannelOverrides(), ref); ch != "" {
+		return ch
+	}
+	if ch := matchChannel(channels, ref); ch != "" {
+		return ch
+	}
+	return ChannelReferral
+}
+
+func matchChannel(m map[string]string, ref string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	if ch, ok := m[ref]; ok {
+		return ch
+	}
+
+	host := strings.ToLower(ref)
+	if i := strings.IndexAny(host, "/?"); i > -1 {
+		host = host[:i]
+	}
+	host = strings.TrimPrefix(host, "www.")
+	for {
+		if ch, ok := m[host]; ok {
+			return ch
+		}
+		i := strings.IndexByte(host, '.')
+		if i == -1 || strings.IndexByte(host[i+1:], '.') == -1 {
+			return ""
+		}
+		host = host[i+1:]
+	}
+}
+
 var groups = map[string]string{
 	// HN has <meta name="referrer" content="origin"> so we only get the domain.
 	"news.ycombinator.com":               "Hacker News",
@@ -74,6 +205,95 @@ var groups = map[string]string{
 	"www.baidu.com":     "Baidu",
 }
 
+type (
+	// RefGroups is a list of rules to group referrers, configured in the
+	// site's settings. This is applied after the built-in groups.
+	RefGroups []RefGroup
+
+	// RefGroup rewrites all referrers from a host matching Match to Name.
+	//
+	// Match is either a hostname with * as a wildcard (e.g. "*.facebook.com")
+	// or a regular expression between slashes (e.g. "/^lm?\.facebook\.com$/").
+	// Matching is always case-insensitive.
+	RefGroup struct {
+		Match string `json:"match"`
+		Name  string `json:"name"`
+
+		re  *regexp.Regexp
+		err error
+	}
+)
+
+func (g *RefGroup) compile() {
+	m := g.Match
+	if len(m) > 2 && m[0] == '/' && m[len(m)-1] == '/' {
+		g.re, g.err = regexp.Compile("(?i)" + m[1:len(m)-1])
+		return
+	}
+	g.re, g.err = regexp.Compile(`(?i)^` +
+		strings.ReplaceAll(regexp.QuoteMeta(m), `\*`, `.*`) + `$`)
+}
+
+// Rewrite the referrer if a rule matches the host.
+func (l RefGroups) Rewrite(ref string) (string, bool) {
+	host := ref
+	if i := strings.IndexAny(host, "/?"); i > -1 {
+		host = host[:i]
+	}
+	for _, g := range l {
+		if g.re != nil && g.re.MatchString(host) {
+			return g.Name, true
+		}
+	}
+	return ref, false
+}
+
+// String converts the rules to the representation used in the settings form:
+// one rule per line as "match = name".
+func (l RefGroups) String() string {
+	lines := make([]string, 0, len(l))
+	for _, g := range l {
+		lines = append(lines, g.Match+" = "+g.Name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// UnmarshalJSON compiles the match patterns after loading them.
+func (l *RefGroups) UnmarshalJSON(v []byte) error {
+	var g []RefGroup
+	err := json.Unmarshal(v, &g)
+	if err != nil {
+		return err
+	}
+	for i := range g {
+		g[i].compile()
+	}
+	*l = g
+	return nil
+}
+
+// UnmarshalText parses the rules from the settings form.
+func (l *RefGroups) UnmarshalText(v []byte) error {
+	g := make([]RefGroup, 0, 4)
+	for _, line := range strings.Split(string(v), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var rg RefGroup
+		if i := strings.LastIndexByte(line, '='); i > -1 {
+			rg.Match, rg.Name = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		} else {
+			rg.Match = line
+		}
+		rg.compile()
+		g = append(g, rg)
+	}
+	*l = g
+	return nil
+}
+
 // update
 
 var hostAlias = map[string]string{
@@ -194,11 +414,11 @@ func cleanRefURL(ref string, refURL *url.URL) (string, bool) {
 }
 
 // ListRefsByPath lists all references for a path.
-func (h *HitStats) ListRefsByPath(ctx context.Context, path string, start, end time.Time, offset int) error {
+func (h *HitStats) ListRefsByPath(ctx context.Context, path string, start, end time.Time, filter Filter, offset int) error {
 	site := MustGetSite(ctx)
 	limit := int(zint.NonZero(int64(site.Settings.LimitRefs()), 10))
 
-	err := zdb.Select(ctx, &h.Stats, "load:ref.ListRefsByPath.sql", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:ref.ListRefsByPath.sql", zdb.P{
 		"site":   site.ID,
 		"start":  start,
 		"end":    end,
@@ -219,14 +439,15 @@ func (h *HitStats) ListRefsByPath(ctx context.Context, path string, start, end t
 //
 // The returned count is the count without LinkDomain, and is different from the
 // total number of hits.
-func (h *HitStats) ListTopRefs(ctx context.Context, start, end time.Time, pathFilter []int64, offset int) error {
+func (h *HitStats) ListTopRefs(ctx context.Context, start, end time.Time, filter Filter, limit, offset int) error {
 	site := MustGetSite(ctx)
-	err := zdb.Select(ctx, &h.Stats, "load:ref.ListTopRefs.sql", zdb.P{
+	err := filter.sel(ctx, &h.Stats, start, end, "load:ref.ListTopRefs.sql", zdb.P{
 		"site":       site.ID,
 		"start":      start,
 		"end":        end,
-		"filter":     pathFilter,
+		"filter":     filter.Paths,
 		"ref":        site.LinkDomain + "%",
+		"limit":      limit + 1,
 		"offset":     offset,
 		"has_domain": site.LinkDomain != "",
 	})
@@ -234,9 +455,138 @@ func (h *HitStats) ListTopRefs(ctx context.Context, start, end time.Time, pathFi
 		return errors.Wrap(err, "HitStats.ListAllRefs")
 	}
 
-	if len(h.Stats) > 6 {
+	if len(h.Stats) > limit {
 		h.More = true
 		h.Stats = h.Stats[:len(h.Stats)-1]
 	}
 	return nil
 }
+
+// ListChannels lists the referrer statistics grouped by channel, excluding
+// referrals from the configured LinkDomain.
+//
+// The ID is set to the channel and the Name to a human-readable label.
+func (h *HitStats) ListChannels(ctx context.Context, start, end time.Time, filter Filter) error {
+	site := MustGetSite(ctx)
+	err := filter.sel(ctx, &h.Stats, start, end, "load:ref.ListChannels.sql", zdb.P{
+		"site":       site.ID,
+		"start":      start,
+		"end":        end,
+		"filter":     filter.Paths,
+		"ref":        site.LinkDomain + "%",
+		"has_domain": site.LinkDomain != "",
+	})
+	if err != nil {
+		return errors.Wrap(err, "HitStats.ListChannels")
+	}
+
+	for i := range h.Stats {
+		h.Stats[i].Name = ChannelLabel(h.Stats[i].ID)
+	}
+	return nil
+}
+
+// ListRefsByChannel lists the top referrers for a channel.
+func (h *HitStats) ListRefsByChannel(ctx context.Context, channel string, start, end time.Time, filter Filter, limit int) error {
+	site := MustGetSite(ctx)
+	err := filter.sel(ctx, &h.Stats, start, end, "load:ref.ListRefsByChannel.sql", zdb.P{
+		"site":       site.ID,
+		"start":      start,
+		"end":        end,
+		"filter":     filter.Paths,
+		"channel":    channel,
+		"ref":        site.LinkDomain + "%",
+		"has_domain": site.LinkDomain != "",
+		"limit":      limit,
+	})
+	return errors.Wrap(err, "HitStats.ListRefsByChannel")
+}
+
+// RefRewrite is an existing referrer that will be changed by the RefGroups
+// setting.
+type RefRewrite struct {
+	From  string `db:"ref"`
+	To    string `db:"-"`
+	Count int    `db:"count"`
+}
+
+type RefRewrites []RefRewrite
+
+// List all referrers that would be changed by the site's RefGroups setting.
+func (r *RefRewrites) List(ctx context.Context) error {
+	site := MustGetSite(ctx)
+	if len(site.Settings.RefGroups) == 0 {
+		return nil
+	}
+
+	var all RefRewrites
+	err := zdb.Select(ctx, &all, `/* RefRewrites.List */
+		select ref, coalesce(sum(total), 0) as count
+		from ref_counts
+		where site_id = :site and ref != '' and (ref_scheme is null or ref_scheme != 'c')
+		group by ref`,
+		zdb.P{"site": site.ID})
+	if err != nil {
+		return errors.Wrap(err, "RefRewrites.List")
+	}
+
+	for _, rw := range all {
+		to, ok := site.Settings.RefGroups.Rewrite(rw.From)
+		if !ok || to == rw.From {
+			continue
+		}
+		rw.To = to
+		*r = append(*r, rw)
+	}
+	sort.Slice(*r, func(i, j int) bool {
+		if (*r)[i].To == (*r)[j].To {
+			return (*r)[i].From < (*r)[j].From
+		}
+		return (*r)[i].To < (*r)[j].To
+	})
+	return nil
+}
+
+// Apply the rewrites to the existing data in ref_counts and hits.
+//
+// Rows in ref_counts for the old referrer are merged in to the new referrer.
+// Campaigns are never rewritten, same as for new pageviews.
+func (r RefRewrites) Apply(ctx context.Context) error {
+	site := MustGetSite(ctx)
+	return zdb.TX(ctx, func(ctx context.Context) error {
+		for _, rw := range r {
+			p := zdb.P{
+				"site":    site.ID,
+				"from":    rw.From,
+				"to":      rw.To,
+				"scheme":  *RefSchemeGenerated,
+				"channel": RefChannel(ctx, rw.To, RefSchemeGenerated),
+			}
+
+			err := zdb.Exec(ctx, `/* RefRewrites.Apply */
+				insert into ref_counts (site_id, path_id, ref, ref_scheme, channel, hour, total, total_unique)
+					select site_id, path_id, :to, :scheme, :channel, hour, total, total_unique
+					from ref_counts
+					where site_id = :site and ref = :from and (ref_scheme is null or ref_scheme != 'c')
+				on conflict (site_id, path_id, ref, hour) do update set
+					ref_scheme   = excluded.ref_scheme,
+					channel      = excluded.channel,
+					total        = ref_counts.total        + excluded.total,
+					total_unique = ref_counts.total_unique + excluded.total_unique`, p)
+			if err != nil {
+				return errors.Wrap(err, "RefRewrites.Apply")
+			}
+			err = zdb.Exec(ctx, `delete from ref_counts
+				where site_id = :site and ref = :from and (ref_scheme is null or ref_scheme != 'c')`, p)
+			if err != nil {
+				return errors.Wrap(err, "RefRewrites.Apply")
+			}
+			err = zdb.Exec(ctx, `update hits set ref = :to, ref_scheme = :scheme, channel = :channel
+				where site_id = :site and ref = :from and (ref_scheme is null or ref_scheme != 'c')`, p)
+			if err != nil {
+				return errors.Wrap(err, "RefRewrites.Apply")
+			}
+		}
+		return nil
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	. "zgo.at/goatcounter"
 	"zgo.at/goatcounter/gctest"
+	"zgo.at/zdb"
 	"zgo.at/zstd/zjson"
 	"zgo.at/zstd/ztest"
 )
@@ -29,7 +30,7 @@ func TestListRefsByPath(t *testing.T) {
 	end := time.Now().UTC().Add(1 * time.Hour)
 
 	var s HitStats
-	err := s.ListRefsByPath(ctx, "/x", start, end, 0)
+	err := s.ListRefsByPath(ctx, "/x", start, end, Filter{}, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -58,7 +59,7 @@ func TestListTopRefs(t *testing.T) {
 
 	{
 		var s HitStats
-		err := s.ListTopRefs(ctx, start, end, nil, 0)
+		err := s.ListTopRefs(ctx, start, end, Filter{}, 6, 0)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -91,7 +92,7 @@ func TestListTopRefs(t *testing.T) {
 
 	{
 		var s HitStats
-		err := s.ListTopRefs(ctx, start, end, []int64{2}, 0)
+		err := s.ListTopRefs(ctx, start, end, Filter{Paths: []int64{2}}, 6, 0)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -115,3 +116,256 @@ func TestListTopRefs(t *testing.T) {
 		}
 	}
 }
+
+func TestRefChannel(t *testing.T) {
+	ctx := gctest.DB(t)
+	ctx, _ = gctest.Site(ctx, t, Site{Settings: SiteSettings{
+		RefChannels: Strings{"news.example.com=social", "Email=referral"},
+	}})
+
+	tests := []struct {
+		ref    string
+		scheme *string
+		want   string
+	}{
+		{"", nil, ChannelDirect},
+		{"summer-sale", RefSchemeCampaign, ChannelCampaign},
+		{"Google", RefSchemeGenerated, ChannelSearch},
+		{"www.bing.com/search?q=x", RefSchemeHTTP, ChannelSearch},
+		{"old.reddit.com/r/golang", RefSchemeHTTP, ChannelSocial},
+		{"example.com/page", RefSchemeHTTP, ChannelReferral},
+		{"com", RefSchemeHTTP, ChannelReferral},
+
+		// Overrides.
+		{"news.example.com/x", RefSchemeHTTP, ChannelSocial},
+		{"a.news.example.com", RefSchemeHTTP, ChannelSocial},
+		{"Email", RefSchemeGenerated, ChannelReferral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got := RefChannel(ctx, tt.ref, tt.scheme)
+			if got != tt.want {
+				t.Errorf("\ngot:  %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListChannels(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/x", Ref: "https://www.google.com/search?q=x"},
+		Hit{Path: "/x", Ref: "https://twitter.com/someone"},
+		Hit{Path: "/x", Ref: "http://example.com"},
+		Hit{Path: "/x"},
+		Hit{Path: "/y"})
+
+	start := time.Now().UTC().Add(-1 * time.Hour)
+	end := time.Now().UTC().Add(1 * time.Hour)
+
+	{
+		var s HitStats
+		err := s.ListChannels(ctx, start, end, Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := fmt.Sprintf("%v", s)
+		want := `{false [{direct Direct 2 0 <nil>} {referral Other websites 1 0 <nil>} {search Search engines 1 0 <nil>} {social Social media 1 0 <nil>}]}`
+		if got != want {
+			t.Errorf("\ngot:  %q\nwant: %q", got, want)
+		}
+	}
+
+	{
+		var s HitStats
+		err := s.ListRefsByChannel(ctx, ChannelSearch, start, end, Filter{}, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := fmt.Sprintf("%v", s)
+		got = regexp.MustCompile(`0x[0-9a-f]{6,}`).ReplaceAllString(got, "0xaa")
+		want := `{false [{ Google 1 0 0xaa}]}`
+		if got != want {
+			t.Errorf("\ngot:  %q\nwant: %q", got, want)
+		}
+	}
+}
+
+func TestRefGroups(t *testing.T) {
+	var g RefGroups
+	err := g.UnmarshalText([]byte(`
+		*.facebook.com = Facebook
+		/^intranet\d*\.example\.com$/ = Intranet
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in, want string
+		wantOK   bool
+	}{
+		{"l.facebook.com", "Facebook", true},
+		{"LM.Facebook.com/some/path", "Facebook", true},
+		{"facebook.com", "facebook.com", false},
+		{"intranet42.example.com/x?y", "Intranet", true},
+		{"intranet.example.com.evil.com", "intranet.example.com.evil.com", false},
+		{"example.com", "example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := g.Rewrite(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("\ngot:  %q %t\nwant: %q %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var g2 RefGroups
+		err := g2.UnmarshalJSON(zjson.MustMarshal(g))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := g2.Rewrite("m.facebook.com"); got != "Facebook" {
+			t.Errorf("not compiled after JSON: %q", got)
+		}
+		if g2.String() != g.String() {
+			t.Errorf("\ngot:  %q\nwant: %q", g2.String(), g.String())
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		ctx := gctest.DB(t)
+		site := MustGetSite(ctx)
+		err := site.Settings.RefGroups.UnmarshalText([]byte("/(/ = x\nno-name.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = site.Update(ctx)
+		if !ztest.ErrorContains(err, "invalid pattern") || !ztest.ErrorContains(err, "no-name.com") {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestRefRewrites(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/x", Ref: "http://a.example.org"},
+		Hit{Path: "/x", Ref: "http://b.example.org"},
+		Hit{Path: "/x", Ref: "http://example.com"})
+
+	site := MustGetSite(ctx)
+	err := site.Settings.RefGroups.UnmarshalText([]byte("*.example.org = Example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = site.Update(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rw RefRewrites
+	err = rw.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%v", rw)
+	want := `[{a.example.org Example 1} {b.example.org Example 1}]`
+	if got != want {
+		t.Fatalf("\ngot:  %q\nwant: %q", got, want)
+	}
+
+	err = rw.Apply(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().UTC().Add(-1 * time.Hour)
+	end := time.Now().UTC().Add(1 * time.Hour)
+	var s HitStats
+	err = s.ListTopRefs(ctx, start, end, Filter{}, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = fmt.Sprintf("%v", s)
+	got = regexp.MustCompile(`0x[0-9a-f]{6,}`).ReplaceAllString(got, "0xaa")
+	want = `{false [{ Example 2 0 0xaa} { example.com 1 0 0xaa}]}`
+	if got != want {
+		t.Errorf("\ngot:  %q\nwant: %q", got, want)
+	}
+
+	// New pageviews should be rewritten too.
+	gctest.StoreHits(ctx, t, false, Hit{Path: "/y", Ref: "http://c.example.org/abc"})
+	var s2 HitStats
+	err = s2.ListTopRefs(ctx, start, end, Filter{}, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = fmt.Sprintf("%v", s2)
+	got = regexp.MustCompile(`0x[0-9a-f]{6,}`).ReplaceAllString(got, "0xaa")
+	want = `{false [{ Example 3 0 0xaa} { example.com 1 0 0xaa}]}`
+	if got != want {
+		t.Errorf("\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestRefRewritesCampaign(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/x", Ref: "http://a.example.org"},
+		Hit{Path: "/x", Query: "utm_campaign=Example"},
+		Hit{Path: "/y", Query: "utm_campaign=a.example.org"})
+
+	site := MustGetSite(ctx)
+	err := site.Settings.RefGroups.UnmarshalText([]byte("*.example.org = Example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = site.Update(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rw RefRewrites
+	err = rw.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%v", rw)
+	want := `[{a.example.org Example 1}]`
+	if got != want {
+		t.Fatalf("\ngot:  %q\nwant: %q", got, want)
+	}
+
+	err = rw.Apply(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got = zdb.DumpString(ctx, `select ref, ref_scheme, channel, total from ref_counts order by ref`)
+	want = `
+		ref            ref_scheme  channel   total
+		Example        g           referral  2
+		a.example.org  c           campaign  1`
+	if d := ztest.Diff(got, want, ztest.DiffNormalizeWhitespace); d != "" {
+		t.Error(d)
+	}
+
+	got = zdb.DumpString(ctx, `select ref, ref_scheme, channel from hits order by ref, ref_scheme`)
+	want = `
+		ref            ref_scheme  channel
+		Example        c           campaign
+		Example        g           referral
+		a.example.org  c           campaign`
+	if d := ztest.Diff(got, want, ztest.DiffNormalizeWhitespace); d != "" {
+		t.Error(d)
+	}
+}
@@ -42,6 +42,9 @@ type (
 		DataRetention int            `json:"data_retention"`
 		Campaigns     Strings        `json:"campaigns"`
 		IgnoreIPs     Strings        `json:"ignore_ips"`
+		EmbedOrigins  Strings        `json:"embed_origins"`
+		RefChannels   Strings        `json:"ref_channels"`
+		RefGroups     RefGroups      `json:"ref_groups"`
 		Collect       zint.Bitflag16 `json:"collect"`
 
 		// User preferences.
@@ -59,17 +62,22 @@ type (
 	Widgets []Widget
 	Widget  map[string]interface{}
 
-	widgetSettings map[string]widgetSetting
-	widgetSetting  struct {
-		Type  string
+	// WidgetSettings are the configurable settings for a widget, keyed by
+	// the setting name.
+	WidgetSettings map[string]WidgetSetting
+	WidgetSetting  struct {
+		Type  string // "number", "checkbox", or "text".
 		Label string
 		Help  string
 
-		Value interface{}
+		Value interface{} // Default value.
 	}
 
 	// Views for the dashboard; these settings apply to all widget and are
 	// configurable in the yellow box at the top.
+	//
+	// There is always a view named "default", and there may be any number of
+	// other named views.
 	Views []View
 	View  struct {
 		Name   string `json:"name"`
@@ -77,56 +85,122 @@ type (
 		Daily  bool   `json:"daily"`
 		AsText bool   `json:"as-text"`
 		Period string `json:"period"` // "week", "week-cur", or n days: "8"
+
+		// Widgets for this view; the site's widgets are used if this is empty.
+		Widgets Widgets `json:"widgets,omitempty"`
 	}
 )
 
+type registeredWidget struct {
+	name     string
+	on       bool
+	settings WidgetSettings
+}
+
+// All configurable widgets, in the order they're added to new sites. Add new
+// ones with RegisterWidget().
+var registeredWidgets = []registeredWidget{
+	{name: "pages", on: true, settings: WidgetSettings{
+		"limit_pages": WidgetSetting{
+			Type:  "number",
+			Label: "Page size",
+			Help:  "Number of pages to load",
+			Value: float64(10),
+		},
+		"limit_refs": WidgetSetting{
+			Type:  "number",
+			Label: "Referrers page size",
+			Help:  "Number of referrers to load when clicking on a path",
+			Value: float64(10),
+		},
+	}},
+	{name: "totalpages", on: true, settings: WidgetSettings{
+		"align": WidgetSetting{
+			Type:  "checkbox",
+			Label: "Align with pages",
+			Help:  "Add margin to the left so it aligns with pages charts",
+			Value: false,
+		},
+		"no-events": WidgetSetting{
+			Type:  "checkbox",
+			Label: "Exclude events",
+			Help:  "Don't include events in the Totals overview",
+			Value: false,
+		},
+	}},
+	{name: "toprefs", on: true},
+	{name: "browsers", on: true},
+	{name: "systems", on: true},
+	{name: "sizes", on: true},
+	{name: "locations", on: true},
+	{name: "heatmap", on: true},
+	{name: "visitors", on: true},
+	{name: "channels", on: true},
+	{name: "worldmap", on: false},
+}
+
+// RegisterWidget adds a new configurable widget with the given settings; on
+// controls if it's enabled by default for new sites. Existing sites get the
+// widget added as disabled.
+//
+// This only registers the settings; you probably want to use
+// widgets.Register(), which calls this.
+//
+// This is not safe for concurrent use and should be called from init(). It
+// will panic if a widget with this name is already registered.
+func RegisterWidget(name string, on bool, settings WidgetSettings) {
+	for _, w := range registeredWidgets {
+		if w.name == name {
+			panic(fmt.Sprintf("goatcounter.RegisterWidget: widget %q already registered", name))
+		}
+	}
+	registeredWidgets = append(registeredWidgets, registeredWidget{name: name, on: on, settings: settings})
+}
+
 // Default widgets for new sites.
 //
 // This *must* return a list of all configurable widgets; even if it's off by
 // default.
-//
-// As a function to ensure a global map isn't accidentally modified.
 func defaultWidgets() Widgets {
 	s := defaultWidgetSettings()
-	w := Widgets{}
-	for _, n := range []string{"pages", "totalpages", "toprefs", "browsers", "systems", "sizes", "locations"} {
-		w = append(w, map[string]interface{}{"on": true, "name": n, "s": s[n].getMap()})
+	w := make(Widgets, 0, len(registeredWidgets))
+	for _, r := range registeredWidgets {
+		w = append(w, map[string]interface{}{"on": r.on, "name": r.name, "s": s[r.name].getMap()})
 	}
 	return w
 }
 
 // List of all settings for widgets with some data.
-func defaultWidgetSettings() map[string]widgetSettings {
-	return map[string]widgetSettings{
-		"pages": map[string]widgetSetting{
-			"limit_pages": widgetSetting{
-				Type:  "number",
-				Label: "Page size",
-				Help:  "Number of pages to load",
-				Value: float64(10),
-			},
-			"limit_refs": widgetSetting{
-				Type:  "number",
-				Label: "Referrers page size",
-				Help:  "Number of referrers to load when clicking on a path",
-				Value: float64(10),
-			},
-		},
-		"totalpages": map[string]widgetSetting{
-			"align": widgetSetting{
-				Type:  "checkbox",
-				Label: "Align with pages",
-				Help:  "Add margin to the left so it aligns with pages charts",
-				Value: false,
-			},
-			"no-events": widgetSetting{
-				Type:  "checkbox",
-				Label: "Exclude events",
-				Help:  "Don't include events in the Totals overview",
-				Value: false,
-			},
-		},
+//
+// As a function to ensure a global map isn't accidentally modified.
+func defaultWidgetSettings() map[string]WidgetSettings {
+	m := make(map[string]WidgetSettings, len(registeredWidgets))
+	for _, r := range registeredWidgets {
+		if len(r.settings) == 0 {
+			continue
+		}
+		s := make(WidgetSettings, len(r.settings))
+		for k, v := range r.settings {
+			s[k] = v
+		}
+		m[r.name] = s
+	}
+	return m
+}
+
+// withRegistered adds all registered widgets that aren't in the list yet as
+// disabled.
+func (w Widgets) withRegistered() Widgets {
+	if len(w) == 0 {
+		return w
+	}
+	s := defaultWidgetSettings()
+	for _, r := range registeredWidgets {
+		if w.Get(r.name) == nil {
+			w = append(w, map[string]interface{}{"on": false, "name": r.name, "s": s[r.name].getMap()})
+		}
 	}
+	return w
 }
 
 func (ss SiteSettings) String() string { return string(zjson.MustMarshal(ss)) }
@@ -136,14 +210,24 @@ func (ss SiteSettings) Value() (driver.Value, error) { return json.Marshal(ss) }
 
 // Scan converts the data returned from the DB into the struct.
 func (ss *SiteSettings) Scan(v interface{}) error {
+	var err error
 	switch vv := v.(type) {
 	case []byte:
-		return json.Unmarshal(vv, ss)
+		err = json.Unmarshal(vv, ss)
 	case string:
-		return json.Unmarshal([]byte(vv), ss)
+		err = json.Unmarshal([]byte(vv), ss)
 	default:
 		return fmt.Errorf("SiteSettings.Scan: unsupported type: %T", v)
 	}
+	if err != nil {
+		return err
+	}
+
+	ss.Widgets = ss.Widgets.withRegistered()
+	for i := range ss.Views {
+		ss.Views[i].Widgets = ss.Views[i].Widgets.withRegistered()
+	}
+	return nil
 }
 
 func (ss *SiteSettings) Defaults() {
@@ -160,11 +244,24 @@ func (ss *SiteSettings) Defaults() {
 		ss.Campaigns = []string{"utm_campaign", "utm_source", "ref"}
 	}
 
+	for i := range ss.EmbedOrigins {
+		ss.EmbedOrigins[i] = strings.TrimRight(strings.TrimSpace(ss.EmbedOrigins[i]), "/")
+	}
+	for i := range ss.RefChannels {
+		k, ch := splitRefChannel(ss.RefChannels[i])
+		ss.RefChannels[i] = k + "=" + ch
+	}
+
 	if len(ss.Widgets) == 0 {
 		ss.Widgets = defaultWidgets()
 	}
-	if len(ss.Views) == 0 {
-		ss.Views = Views{{Name: "default", Period: "week"}}
+	ss.Widgets = ss.Widgets.withRegistered()
+	if _, i := ss.Views.Get("default"); i == -1 {
+		ss.Views = append(Views{{Name: "default", Period: "week"}}, ss.Views...)
+	}
+	for i := range ss.Views {
+		ss.Views[i].Name = strings.TrimSpace(ss.Views[i].Name)
+		ss.Views[i].Widgets = ss.Views[i].Widgets.withRegistered()
 	}
 	if ss.Collect == 0 {
 		ss.Collect = CollectReferrer | CollectUserAgent | CollectScreenSize | CollectLocation | CollectLocationRegion
@@ -217,7 +314,7 @@ func (ss SiteSettings) CollectFlags() []CollectFlag {
 	}
 }
 
-func (s widgetSettings) getMap() map[string]interface{} {
+func (s WidgetSettings) getMap() map[string]interface{} {
 	m := make(map[string]interface{})
 	for k, v := range s {
 		m[k] = v.Value
@@ -269,7 +366,7 @@ func (w Widgets) Get(name string) Widget {
 }
 
 // GetSettings gets all setting for this widget.
-func (w Widgets) GetSettings(name string) widgetSettings {
+func (w Widgets) GetSettings(name string) WidgetSettings {
 	for _, v := range w {
 		if v["name"] == name {
 			def := defaultWidgetSettings()[name]
@@ -288,7 +385,7 @@ func (w Widgets) GetSettings(name string) widgetSettings {
 			return def
 		}
 	}
-	return make(widgetSettings)
+	return make(WidgetSettings)
 }
 
 // On reports if this setting should be displayed.
@@ -312,6 +409,24 @@ func (v Views) Get(name string) (View, int) {
 	return View{}, -1
 }
 
+// Names gets the names of all views.
+func (v Views) Names() []string {
+	n := make([]string, 0, len(v))
+	for _, vv := range v {
+		n = append(n, vv.Name)
+	}
+	return n
+}
+
+// WithView gets a copy of the settings with the widgets replaced by the widgets
+// of the view, if it has any.
+func (ss SiteSettings) WithView(v View) SiteSettings {
+	if len(v.Widgets) > 0 {
+		ss.Widgets = v.Widgets
+	}
+	return ss
+}
+
 // Some shortcuts for getting the settings.
 
 func (ss SiteSettings) LimitPages() int {
@@ -329,3 +444,38 @@ func (ss SiteSettings) TotalsAlign() bool {
 func (ss SiteSettings) TotalsNoEvents() bool {
 	return ss.Widgets.GetSettings("totalpages")["no-events"].Value.(bool)
 }
+
+// EmbedAllowed reports if widgets can be embedded on the given origin (e.g.
+// "https://example.com").
+func (ss SiteSettings) EmbedAllowed(origin string) bool {
+	for _, o := range ss.EmbedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// RefChannelOverrides gets the RefChannels setting as a map of referrer or host
+// to channel.
+func (ss SiteSettings) RefChannelOverrides() map[string]string {
+	if len(ss.RefChannels) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(ss.RefChannels))
+	for _, c := range ss.RefChannels {
+		k, ch := splitRefChannel(c)
+		m[k] = ch
+		m[strings.ToLower(strings.TrimPrefix(k, "www."))] = ch
+	}
+	return m
+}
+
+// splitRefChannel splits a "ref=channel" override.
+func splitRefChannel(s string) (string, string) {
+	i := strings.LastIndexByte(s, '=')
+	if i == -1 {
+		return strings.TrimSpace(s), ""
+	}
+	return strings.TrimSpace(s[:i]), strings.ToLower(strings.TrimSpace(s[i+1:]))
+}
@@ -0,0 +1,174 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/goatcounter/filter"
+	"zgo.at/zdb"
+	"zgo.at/zstd/zcrypto"
+	"zgo.at/zstd/zstring"
+	"zgo.at/zvalidate"
+)
+
+// ShareToken gives read-only access to the dashboard without logging in, even
+// if the site isn't public.
+type ShareToken struct {
+	ID     int64 `db:"share_token_id" json:"-"`
+	SiteID int64 `db:"site_id" json:"-"`
+	UserID int64 `db:"user_id" json:"-"`
+
+	Name  string `db:"name" json:"name"`
+	Token string `db:"token" json:"-"`
+
+	// Always use this filter; people using the link can only narrow it down
+	// further.
+	Filter string `db:"filter" json:"filter"`
+
+	// Only display these widgets; display all enabled widgets if empty.
+	Widgets Strings `db:"widgets" json:"widgets"`
+
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// Defaults sets fields to default values, unless they're already set.
+func (t *ShareToken) Defaults(ctx context.Context) {
+	t.SiteID = MustGetSite(ctx).ID
+	if u := GetUser(ctx); u != nil {
+		t.UserID = u.ID
+	}
+	if t.Token == "" {
+		t.Token = zcrypto.Secret256()
+	}
+	t.Name = strings.TrimSpace(t.Name)
+	t.Filter = strings.TrimSpace(t.Filter)
+	t.Widgets = zstring.Filter(t.Widgets, zstring.FilterEmpty)
+	if t.ExpiresAt != nil {
+		e := t.ExpiresAt.UTC().Truncate(time.Second)
+		t.ExpiresAt = &e
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = Now()
+	}
+}
+
+// Validate the object.
+func (t *ShareToken) Validate(ctx context.Context) error {
+	v := zvalidate.New()
+	v.Required("name", t.Name)
+	v.Required("site_id", t.SiteID)
+	v.Required("user_id", t.UserID)
+	v.Required("token", t.Token)
+	v.Len("name", t.Name, 0, 200)
+
+	if t.Filter != "" {
+		_, err := NewFilter(ctx, t.Filter)
+		if err != nil {
+			var fErr *filter.Error
+			if !errors.As(err, &fErr) {
+				return err
+			}
+			v.Append("filter", fErr.Error())
+		}
+	}
+
+	names := make([]string, 0, 8)
+	for _, w := range defaultWidgets() {
+		names = append(names, w["name"].(string))
+	}
+	for i, w := range t.Widgets {
+		v.Include(fmt.Sprintf("widgets[%d]", i), w, names)
+	}
+
+	if t.ExpiresAt != nil && t.ID == 0 && t.ExpiresAt.Before(Now()) {
+		v.Append("expires_at", "is in the past")
+	}
+	return v.ErrorOrNil()
+}
+
+// Insert a new row.
+func (t *ShareToken) Insert(ctx context.Context) error {
+	if t.ID > 0 {
+		return errors.New("ID > 0")
+	}
+
+	t.Defaults(ctx)
+	err := t.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.ID, err = zdb.InsertID(ctx, "share_token_id",
+		`insert into share_tokens (site_id, user_id, name, token, filter, widgets, expires_at, created_at) values (?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.SiteID, t.UserID, t.Name, t.Token, t.Filter, t.Widgets, t.ExpiresAt, t.CreatedAt)
+	return errors.Wrap(err, "ShareToken.Insert")
+}
+
+// ByID gets a share token by ID.
+func (t *ShareToken) ByID(ctx context.Context, id int64) error {
+	return errors.Wrapf(zdb.Get(ctx, t, `/* ShareToken.ByID */
+		select * from share_tokens where share_token_id=$1 and site_id=$2`,
+		id, MustGetSite(ctx).ID), "ShareToken.ByID %d", id)
+}
+
+// ByToken gets a share token by the token string; this will return
+// sql.ErrNoRows if the token has expired.
+func (t *ShareToken) ByToken(ctx context.Context, token string) error {
+	return errors.Wrap(zdb.Get(ctx, t, `/* ShareToken.ByToken */
+		select * from share_tokens
+		where token=$1 and site_id=$2 and (expires_at is null or expires_at > $3)`,
+		token, MustGetSite(ctx).ID, Now()), "ShareToken.ByToken")
+}
+
+// UpdateLastUsed sets the last used time to now.
+//
+// This is only updated once a minute, since loading the dashboard will use the
+// token for several requests.
+func (t *ShareToken) UpdateLastUsed(ctx context.Context) error {
+	now := Now()
+	if t.LastUsedAt != nil && now.Sub(*t.LastUsedAt) < time.Minute {
+		return nil
+	}
+
+	t.LastUsedAt = &now
+	err := zdb.Exec(ctx, `/* ShareToken.UpdateLastUsed */
+		update share_tokens set last_used_at=$1 where share_token_id=$2`,
+		t.LastUsedAt, t.ID)
+	return errors.Wrap(err, "ShareToken.UpdateLastUsed")
+}
+
+// Delete (revoke) this token.
+func (t *ShareToken) Delete(ctx context.Context) error {
+	err := zdb.Exec(ctx,
+		`/* ShareToken.Delete */ delete from share_tokens where share_token_id=$1 and site_id=$2`,
+		t.ID, MustGetSite(ctx).ID)
+	return errors.Wrapf(err, "ShareToken.Delete %d", t.ID)
+}
+
+// Expired reports if this token has expired.
+func (t ShareToken) Expired() bool {
+	return t.ExpiresAt != nil && !t.ExpiresAt.After(Now())
+}
+
+// HasWidget reports if this token allows displaying the widget.
+func (t ShareToken) HasWidget(name string) bool {
+	return len(t.Widgets) == 0 || zstring.Contains(t.Widgets, name)
+}
+
+type ShareTokens []ShareToken
+
+// List all share tokens for this site.
+func (t *ShareTokens) List(ctx context.Context) error {
+	return errors.Wrap(zdb.Select(ctx, t, `/* ShareTokens.List */
+		select * from share_tokens where site_id=$1 order by created_at desc, share_token_id desc`,
+		MustGetSite(ctx).ID), "ShareTokens.List")
+}
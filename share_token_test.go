@@ -0,0 +1,65 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/zdb"
+	"zgo.at/zvalidate"
+)
+
+func TestShareToken(t *testing.T) {
+	ctx := gctest.DB(t)
+
+	past := Now().Add(-time.Hour)
+	for _, tt := range []ShareToken{
+		{},
+		{Name: "x", Filter: "(/foo"},
+		{Name: "x", Filter: "size:huge"},
+		{Name: "x", Widgets: Strings{"pages", "nope"}},
+		{Name: "x", ExpiresAt: &past},
+	} {
+		err := tt.Insert(ctx)
+		var vErr *zvalidate.Validator
+		if !errors.As(err, &vErr) {
+			t.Errorf("%v: wrong error: %#v", tt, err)
+		}
+	}
+
+	future := Now().Add(time.Hour)
+	tok := ShareToken{Name: "x", Filter: "path:/docs/*", Widgets: Strings{"pages"}, ExpiresAt: &future}
+	err := tok.Insert(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tok.HasWidget("pages") || tok.HasWidget("browsers") {
+		t.Error("HasWidget")
+	}
+
+	var got ShareToken
+	err = got.ByToken(ctx, tok.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Filter != tok.Filter || len(got.Widgets) != 1 {
+		t.Errorf("wrong token: %#v", got)
+	}
+
+	err = got.UpdateLastUsed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gctest.SetNow(t, future.Add(time.Minute))
+	err = got.ByToken(ctx, tok.Token)
+	if !zdb.ErrNoRows(err) {
+		t.Errorf("expired token: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -42,7 +43,7 @@ var reserved = []string{
 }
 
 var statTables = []string{"hit_stats", "system_stats", "browser_stats",
-	"location_stats", "size_stats"}
+	"location_stats", "size_stats", "visitor_stats"}
 
 type Site struct {
 	ID     int64  `db:"site_id" json:"id,readonly"`
@@ -167,9 +168,29 @@ func (s *Site) Validate(ctx context.Context) error {
 	v.Range("widgets.pages.s.limit_pages", int64(s.Settings.LimitPages()), 1, 100)
 	v.Range("widgets.pages.s.limit_refs", int64(s.Settings.LimitRefs()), 1, 25)
 
-	if _, i := s.Settings.Views.Get("default"); i == -1 || len(s.Settings.Views) != 1 {
+	if _, i := s.Settings.Views.Get("default"); i == -1 {
 		v.Append("views", "view not set")
 	}
+	seen := make(map[string]struct{}, len(s.Settings.Views))
+	for _, view := range s.Settings.Views {
+		n := strings.ToLower(view.Name)
+		if _, ok := seen[n]; ok {
+			v.Append("views", fmt.Sprintf("duplicate view name %q", view.Name))
+		}
+		seen[n] = struct{}{}
+		v.Required("views.name", view.Name)
+		v.Len("views.name", view.Name, 0, 50)
+		if len(view.Widgets) > 0 {
+			for _, w := range view.Widgets {
+				if name, _ := w["name"].(string); s.Settings.Widgets.Get(name) == nil {
+					v.Append("views.widgets", fmt.Sprintf("unknown widget %q in view %q", name, view.Name))
+				}
+			}
+			ss := s.Settings.WithView(view)
+			v.Range("widgets.pages.s.limit_pages", int64(ss.LimitPages()), 1, 100)
+			v.Range("widgets.pages.s.limit_refs", int64(ss.LimitRefs()), 1, 25)
+		}
+	}
 
 	if s.Settings.DataRetention > 0 {
 		v.Range("settings.data_retention", int64(s.Settings.DataRetention), 14, 0)
@@ -180,6 +201,34 @@ func (s *Site) Validate(ctx context.Context) error {
 			v.IP("settings.ignore_ips", ip)
 		}
 	}
+	for _, o := range s.Settings.EmbedOrigins {
+		if o == "*" {
+			continue
+		}
+		u, err := url.Parse(o)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			v.Append("settings.embed_origins",
+				fmt.Sprintf("%q is not a valid origin; it should be in the form of https://example.com", o))
+		}
+	}
+	for _, g := range s.Settings.RefGroups {
+		switch {
+		case g.Match == "" || g.Name == "":
+			v.Append("settings.ref_groups",
+				fmt.Sprintf("%q is not valid; it should be in the form of match = name", g.Match))
+		case g.err != nil:
+			v.Append("settings.ref_groups", fmt.Sprintf("invalid pattern %q: %s", g.Match, g.err))
+		}
+	}
+	for _, c := range s.Settings.RefChannels {
+		k, ch := splitRefChannel(c)
+		if k == "" || ch == "" {
+			v.Append("settings.ref_channels",
+				fmt.Sprintf("%q is not valid; it should be in the form of referrer=channel", c))
+			continue
+		}
+		v.Include("settings.ref_channels", ch, Channels)
+	}
 
 	v.Domain("link_domain", s.LinkDomain)
 
@@ -0,0 +1,34 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package totp generates time-based one-time passwords as described in RFC
+// 6238.
+//
+// Only 6 digits with a 30 second period and HMAC-SHA1 is supported; this is
+// what authenticator apps expect.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/binary"
+	"time"
+)
+
+// Period is the time a token is valid for.
+const Period = 30 * time.Second
+
+// Token generates the token for the time t.
+func Token(secret []byte, t time.Time) int32 {
+	var c [8]byte
+	binary.BigEndian.PutUint64(c[:], uint64(t.Unix()/int64(Period/time.Second)))
+
+	m := hmac.New(sha1.New, secret)
+	m.Write(c[:])
+	sum := m.Sum(nil)
+
+	// Dynamic truncation from RFC 4226 section 5.3.
+	o := sum[len(sum)-1] & 0xf
+	return int32(binary.BigEndian.Uint32(sum[o:o+4])&0x7fffffff) % 1_000_000
+}
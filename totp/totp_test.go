@@ -0,0 +1,37 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package totp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Test vectors from RFC 6238 Appendix B for SHA-1; these are 8 digits, so
+// compare only the last 6.
+func TestToken(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		t    int64
+		want int32
+	}{
+		{59, 94287082},
+		{1111111109, 7081804},
+		{1111111111, 14050471},
+		{1234567890, 89005924},
+		{2000000000, 69279037},
+		{20000000000, 65353130},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d", tt.t), func(t *testing.T) {
+			got := Token(secret, time.Unix(tt.t, 0))
+			if want := tt.want % 1_000_000; got != want {
+				t.Errorf("got %06d; want %06d", got, want)
+			}
+		})
+	}
+}
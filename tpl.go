@@ -46,6 +46,7 @@ func init() {
 	tplfunc.Add("bar_chart", barChart)
 	tplfunc.Add("text_chart", textChart)
 	tplfunc.Add("horizontal_chart", HorizontalChart)
+	tplfunc.Add("sparkline", sparkline)
 
 	// Override defaults to take site settings in to account.
 	tplfunc.Add("tformat", func(s *Site, t time.Time, fmt string) string {
@@ -108,11 +109,67 @@ func textChart(ctx context.Context, stats []HitListStat, max int, daily bool) te
 	return template.HTML(symb)
 }
 
-func barChart(ctx context.Context, stats []HitListStat, max int, daily bool) template.HTML {
+// sparkline renders a small line chart as SVG.
+func sparkline(points []int) template.HTML {
+	max := 0
+	for _, p := range points {
+		if p > max {
+			max = p
+		}
+	}
+
+	w := len(points) - 1
+	if w < 1 {
+		w = 1
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, `<svg class="sparkline" viewBox="0 0 %d 20" preserveAspectRatio="none"><polyline points="`, w)
+	for i, p := range points {
+		y := 19.0
+		if max > 0 {
+			y = 19 - float64(p)/float64(max)*18
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(b, "%d,%.1f", i, y)
+	}
+	b.WriteString(`"/></svg>`)
+	return template.HTML(b.String())
+}
+
+// barChart renders the bars for the chart.
+//
+// Bars with annotations get a data-a attribute with the annotation text, one
+// annotation per line.
+func barChart(ctx context.Context, stats []HitListStat, max int, daily bool, annotations ...Annotations) template.HTML {
 	site := MustGetSite(ctx)
 	now := Now().In(site.Settings.Timezone.Loc())
 	today := now.Format("2006-01-02")
 
+	// Annotations by day or hour.
+	annot := make(map[string]string)
+	for _, list := range annotations {
+		for _, a := range list {
+			k := a.At.In(site.Settings.Timezone.Loc()).Format("2006-01-02 15")
+			if daily {
+				k = k[:10]
+			}
+			if annot[k] != "" {
+				annot[k] += "\n"
+			}
+			annot[k] += a.Text
+		}
+	}
+	dataA := func(k string) string {
+		a, ok := annot[k]
+		if !ok {
+			return ""
+		}
+		return ` data-a="` + template.HTMLEscapeString(a) + `"`
+	}
+
 	var (
 		future bool
 		b      strings.Builder
@@ -137,8 +194,8 @@ func barChart(ctx context.Context, stats []HitListStat, max int, daily bool) tem
 				st = fmt.Sprintf(` style="height:%.0f%%" data-u="%.0f%%"`, h, hu)
 			}
 
-			b.WriteString(fmt.Sprintf(`<div%s title="%s|%s|%s"></div>`,
-				st, stat.Day, tplfunc.Number(stat.Daily, site.Settings.NumberFormat),
+			b.WriteString(fmt.Sprintf(`<div%s%s title="%s|%s|%s"></div>`,
+				st, dataA(stat.Day), stat.Day, tplfunc.Number(stat.Daily, site.Settings.NumberFormat),
 				tplfunc.Number(stat.DailyUnique, site.Settings.NumberFormat)))
 		}
 
@@ -166,8 +223,8 @@ func barChart(ctx context.Context, stats []HitListStat, max int, daily bool) tem
 					hu := math.Round(float64(stat.HourlyUnique[shour]) / float64(max) / 0.01)
 					st = fmt.Sprintf(` style="height:%.0f%%" data-u="%.0f%%"`, h, hu)
 				}
-				b.WriteString(fmt.Sprintf(`<div%s title="%s|%[3]d:00|%[3]d:59|%s|%s"></div>`,
-					st, stat.Day, shour,
+				b.WriteString(fmt.Sprintf(`<div%s%s title="%s|%[4]d:00|%[4]d:59|%s|%s"></div>`,
+					st, dataA(fmt.Sprintf("%s %02d", stat.Day, shour)), stat.Day, shour,
 					tplfunc.Number(s, site.Settings.NumberFormat),
 					tplfunc.Number(stat.HourlyUnique[shour], site.Settings.NumberFormat)))
 			}
@@ -283,6 +340,24 @@ type (
 		Site    Site
 		User    User
 	}
+	TplEmailInvite struct {
+		Context   context.Context
+		Site      Site
+		User      User
+		InvitedBy User
+	}
+	TplEmailRecoveryCode struct {
+		Context context.Context
+		Site    Site
+		User    User
+		Left    int
+	}
+	TplEmailLoginLocked struct {
+		Context context.Context
+		Site    Site
+		User    User
+		IP      string
+	}
 	TplEmailImportError struct {
 		Error error
 	}
@@ -304,6 +379,9 @@ func (t TplEmailWelcome) Render() ([]byte, error)       { return E("email_welcom
 func (t TplEmailForgotSite) Render() ([]byte, error)    { return E("email_forgot_site.gotxt", t) }
 func (t TplEmailPasswordReset) Render() ([]byte, error) { return E("email_password_reset.gotxt", t) }
 func (t TplEmailVerify) Render() ([]byte, error)        { return E("email_verify.gotxt", t) }
+func (t TplEmailInvite) Render() ([]byte, error)        { return E("email_invite.gotxt", t) }
+func (t TplEmailRecoveryCode) Render() ([]byte, error)  { return E("email_recovery_code.gotxt", t) }
+func (t TplEmailLoginLocked) Render() ([]byte, error)   { return E("email_login_locked.gotxt", t) }
 func (t TplEmailImportError) Render() ([]byte, error)   { return E("email_import_error.gotxt", t) }
 func (t TplEmailExportDone) Render() ([]byte, error)    { return E("email_export_done.gotxt", t) }
 func (t TplEmailImportDone) Render() ([]byte, error)    { return E("email_import_done.gotxt", t) }
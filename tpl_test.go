@@ -44,6 +44,10 @@ func TestTpl(t *testing.T) {
 		{TplEmailForgotSite{ctx, []Site{}, "test@example.com"}},
 		{TplEmailPasswordReset{ctx, site, user}},
 		{TplEmailVerify{ctx, site, user}},
+		{TplEmailInvite{ctx, site, user, User{Email: "b@example.com"}}},
+		{TplEmailRecoveryCode{ctx, site, user, 9}},
+		{TplEmailRecoveryCode{ctx, site, user, 0}},
+		{TplEmailLoginLocked{ctx, site, user, "192.0.2.1"}},
 		{TplEmailImportError{errors.Unwrap(errors.New("oh noes"))}},
 		{TplEmailImportDone{site, 42, errors.NewGroup(10)}},
 		{TplEmailImportDone{site, 42, errs}},
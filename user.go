@@ -7,7 +7,11 @@ package goatcounter
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -19,7 +23,56 @@ import (
 	"zgo.at/zvalidate"
 )
 
-const totpSecretLen = 16
+const (
+	totpSecretLen     = 16
+	totpRecoveryCodes = 10
+
+	// Failed logins are allowed loginFreeAttempts times, after which the
+	// user has to wait for an exponentially increasing time before trying
+	// again. The account is locked for loginLockoutDuration after
+	// loginLockout failures.
+	loginFreeAttempts    = 3
+	loginLockout         = 10
+	loginLockoutDuration = time.Hour
+)
+
+// UserAccess is the access level of a user.
+type UserAccess string
+
+// Access levels; every level includes all the levels before it.
+const (
+	AccessReadOnly UserAccess = "r" // View the dashboard.
+	AccessSettings UserAccess = "s" // Change the site settings.
+	AccessAdmin    UserAccess = "a" // Manage sites and users.
+	AccessOwner    UserAccess = "o" // Billing and deleting the account.
+)
+
+// UserAccesses are all the access levels, from lowest to highest.
+var UserAccesses = []UserAccess{AccessReadOnly, AccessSettings, AccessAdmin, AccessOwner}
+
+func (a UserAccess) level() int {
+	for i, aa := range UserAccesses {
+		if a == aa {
+			return i
+		}
+	}
+	return -1
+}
+
+// Label gets a human-readable description.
+func (a UserAccess) Label() string {
+	switch a {
+	case AccessReadOnly:
+		return "Viewer"
+	case AccessSettings:
+		return "Settings editor"
+	case AccessAdmin:
+		return "Admin"
+	case AccessOwner:
+		return "Owner"
+	}
+	return string(a)
+}
 
 // User entry.
 type User struct {
@@ -32,10 +85,13 @@ type User struct {
 	TOTPEnabled   zbool.Bool `db:"totp_enabled" json:"totp_enabled,readonly"`
 	TOTPSecret    []byte     `db:"totp_secret" json:"-"`
 	Role          string     `db:"role" json:"role,readonly"`
+	Access        UserAccess `db:"access" json:"access,readonly"`
 	LoginAt       *time.Time `db:"login_at" json:"login_at,readonly"`
 	ResetAt       *time.Time `db:"reset_at" json:"reset_at,readonly"`
 	LoginRequest  *string    `db:"login_request" json:"-"`
-	LoginToken    *string    `db:"login_token" json:"-"`
+	LoginToken    *string    `db:"login_token" json:"-"` // Unused; see UserSession.
+	LoginFailures int        `db:"login_failures" json:"-"`
+	LockedUntil   *time.Time `db:"locked_until" json:"-"`
 	Token         *string    `db:"csrf_token" json:"-"`
 	EmailToken    *string    `db:"email_token" json:"-"`
 	SeenUpdatesAt time.Time  `db:"seen_updates_at" json:"-"`
@@ -47,7 +103,7 @@ type User struct {
 // Defaults sets fields to default values, unless they're already set.
 func (u *User) Defaults(ctx context.Context) {
 	if s := GetSite(ctx); s != nil && s.ID > 0 { // Not set in website.
-		u.Site = s.ID
+		u.Site = s.IDOrParent()
 	}
 
 	if u.CreatedAt.IsZero() {
@@ -60,6 +116,9 @@ func (u *User) Defaults(ctx context.Context) {
 	if !u.EmailVerified {
 		u.EmailToken = zcrypto.Secret192P()
 	}
+	if u.Access == "" {
+		u.Access = AccessReadOnly
+	}
 }
 
 // Validate the object.
@@ -70,6 +129,9 @@ func (u *User) Validate(ctx context.Context, validatePassword bool) error {
 	v.Required("email", u.Email)
 	v.Len("email", u.Email, 5, 255)
 	v.Email("email", u.Email)
+	if u.Access.level() == -1 {
+		v.Append("access", "unknown value")
+	}
 
 	if validatePassword {
 		sp := string(u.Password)
@@ -103,20 +165,27 @@ func (u *User) hashPassword(ctx context.Context) error {
 }
 
 // Insert a new row.
-func (u *User) Insert(ctx context.Context) error {
+//
+// The password may be blank if allowBlankPassword is set, for example for
+// invited users who will set a password later.
+func (u *User) Insert(ctx context.Context, allowBlankPassword bool) error {
 	if u.ID > 0 {
 		return errors.New("ID > 0")
 	}
 
 	u.Defaults(ctx)
-	err := u.Validate(ctx, true)
+	err := u.Validate(ctx, !allowBlankPassword || len(u.Password) > 0)
 	if err != nil {
 		return err
 	}
 
-	err = u.hashPassword(ctx)
-	if err != nil {
-		return errors.Wrap(err, "User.Insert")
+	if len(u.Password) > 0 {
+		err = u.hashPassword(ctx)
+		if err != nil {
+			return errors.Wrap(err, "User.Insert")
+		}
+	} else {
+		u.Password = nil
 	}
 
 	u.TOTPEnabled = zbool.Bool(false)
@@ -127,11 +196,11 @@ func (u *User) Insert(ctx context.Context) error {
 	}
 
 	query := `insert into users `
-	args := []interface{}{u.Site, u.Email, u.Password, u.TOTPSecret, u.CreatedAt}
+	args := []interface{}{u.Site, u.Email, u.Password, u.TOTPSecret, u.CreatedAt, u.Access}
 	if u.EmailVerified {
-		query += ` (site_id, email, password, totp_secret, created_at, email_verified) values ($1, $2, $3, $4, $5, 1)`
+		query += ` (site_id, email, password, totp_secret, created_at, access, email_verified) values ($1, $2, $3, $4, $5, $6, 1)`
 	} else {
-		query += ` (site_id, email, password, totp_secret, created_at, email_token) values ($1, $2, $3, $4, $5, $6)`
+		query += ` (site_id, email, password, totp_secret, created_at, access, email_token) values ($1, $2, $3, $4, $5, $6, $7)`
 		args = append(args, u.EmailToken)
 	}
 
@@ -218,13 +287,18 @@ func (u *User) ByEmailToken(ctx context.Context, key string) error {
 		MustGetSite(ctx).IDOrParent(), key), "User.ByEmailToken")
 }
 
+// ByID gets a user by ID, for the current site.
+func (u *User) ByID(ctx context.Context, id int64) error {
+	return errors.Wrap(zdb.Get(ctx, u,
+		`select * from users where user_id=$1 and site_id=$2`,
+		id, MustGetSite(ctx).IDOrParent()), "User.ByID")
+}
+
 // ByEmail gets a user by email address.
 func (u *User) ByEmail(ctx context.Context, email string) error {
 	return errors.Wrap(zdb.Get(ctx, u,
-		`select * from users where
-			lower(email)=lower($1) and
-			(site_id=$2 or site_id=(select parent from sites where user_id=$2))
-		`, email, MustGetSite(ctx).ID), "User.ByEmail")
+		`select * from users where lower(email)=lower($1) and site_id=$2`,
+		email, MustGetSite(ctx).IDOrParent()), "User.ByEmail")
 }
 
 // ByResetToken gets a user by login request key.
@@ -241,29 +315,41 @@ func (u *User) ByResetToken(ctx context.Context, key string) error {
 		key, MustGetSite(ctx).IDOrParent()), "User.ByResetToken")
 }
 
-// ByToken gets a user by login token.
+// ByToken gets a user by the token of a login session.
+//
+// The CSRF token is set to the session's CSRF token.
 func (u *User) ByToken(ctx context.Context, token string) error {
-	if token == "" {
-		return sql.ErrNoRows
-	}
-
-	return errors.Wrap(zdb.Get(ctx, u,
-		`select * from users where login_token=$1`, token),
-		"User.ByToken")
+	return errors.Wrap(u.bySession(ctx, token, 0), "User.ByToken")
 }
 
-// ByTokenAndSite gets a user by login token.
+// ByTokenAndSite gets a user by the token of a login session.
+//
+// The CSRF token is set to the session's CSRF token.
 func (u *User) ByTokenAndSite(ctx context.Context, token string) error {
+	return errors.Wrap(u.bySession(ctx, token, MustGetSite(ctx).IDOrParent()),
+		"User.ByTokenAndSite")
+}
+
+func (u *User) bySession(ctx context.Context, token string, siteID int64) error {
 	if token == "" {
 		return sql.ErrNoRows
 	}
 
-	return errors.Wrap(zdb.Get(ctx, u,
-		`select * from users where login_token=$1 and site_id=$2`,
-		token, MustGetSite(ctx).IDOrParent()), "User.ByTokenAndSite")
+	var s UserSession
+	err := s.ByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	err = zdb.Get(ctx, u, `select * from users where user_id=$1 and ($2 = 0 or site_id=$2)`,
+		s.UserID, siteID)
+	if err != nil {
+		return err
+	}
+	u.Token = &s.CSRFToken
+	return nil
 }
 
-// BySite gets a user by site.
+// BySite gets the owner of a site.
 func (u *User) BySite(ctx context.Context, id int64) error {
 	var s Site
 	err := s.ByID(ctx, id)
@@ -271,8 +357,9 @@ func (u *User) BySite(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return errors.Wrap(zdb.Get(ctx, u,
-		`select * from users where site_id=$1`, s.IDOrParent()), "User.ByID")
+	return errors.Wrap(zdb.Get(ctx, u, `select * from users
+		where site_id=$1 order by access=$2 desc, user_id asc limit 1`,
+		s.IDOrParent(), AccessOwner), "User.BySite")
 }
 
 // RequestReset generates a new password reset key.
@@ -306,9 +393,15 @@ func (u *User) DisableTOTP(ctx context.Context) error {
 		return errors.Wrap(err, "User.DisableTOTP")
 	}
 
-	err = zdb.Exec(ctx, `update users set
-		totp_enabled=0, totp_secret=$1 where user_id=$2 and site_id=$3`,
-		secret, u.ID, MustGetSite(ctx).IDOrParent())
+	err = zdb.TX(ctx, func(ctx context.Context) error {
+		err := zdb.Exec(ctx, `update users set
+			totp_enabled=0, totp_secret=$1 where user_id=$2 and site_id=$3`,
+			secret, u.ID, MustGetSite(ctx).IDOrParent())
+		if err != nil {
+			return err
+		}
+		return zdb.Exec(ctx, `delete from totp_recovery_codes where user_id=$1`, u.ID)
+	})
 	if err != nil {
 		return errors.Wrap(err, "User.DisableTOTP")
 	}
@@ -317,30 +410,281 @@ func (u *User) DisableTOTP(ctx context.Context) error {
 	return nil
 }
 
-// Login a user; create a new key, CSRF token, and reset the request date.
-func (u *User) Login(ctx context.Context) error {
-	u.Token = zcrypto.Secret256P()
-	if u.LoginToken == nil || *u.LoginToken == "" {
-		s := Now().Format("20060102") + "-" + zcrypto.Secret256()
-		u.LoginToken = &s
+// NewRecoveryCodes creates a new set of single-use recovery codes that can be
+// used instead of a TOTP token, replacing any existing ones.
+//
+// Only the hashes are stored, so this is the only time the codes are known.
+func (u *User) NewRecoveryCodes(ctx context.Context) ([]string, error) {
+	codes := make([]string, totpRecoveryCodes)
+	err := zdb.TX(ctx, func(ctx context.Context) error {
+		err := zdb.Exec(ctx, `delete from totp_recovery_codes where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+
+		now := Now()
+		for i := range codes {
+			b := make([]byte, 10)
+			_, err := rand.Read(b)
+			if err != nil {
+				return err
+			}
+
+			// 16 characters, written as "xxxx-xxxx-xxxx-xxxx".
+			c := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+			codes[i] = c[:4] + "-" + c[4:8] + "-" + c[8:12] + "-" + c[12:]
+
+			err = zdb.Exec(ctx, `insert into totp_recovery_codes (user_id, hash, created_at) values ($1, $2, $3)`,
+				u.ID, hashRecoveryCode(codes[i]), now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "User.NewRecoveryCodes")
 	}
+	return codes, nil
+}
 
-	err := zdb.Exec(ctx, `update users set
-			login_request=null, login_token=$1, csrf_token=$2
-			where user_id=$3 and site_id=$4`,
-		u.LoginToken, u.Token, u.ID, MustGetSite(ctx).IDOrParent())
-	return errors.Wrap(err, "User.Login")
+// UseRecoveryCode reports if code is a valid recovery code for this user, and
+// removes it so it can't be used again.
+func (u *User) UseRecoveryCode(ctx context.Context, code string) (bool, error) {
+	// Check and remove in one statement, so concurrent requests can't both use
+	// the same code.
+	n, err := zdb.NumRows(ctx, `/* User.UseRecoveryCode */
+		delete from totp_recovery_codes where user_id=$1 and hash=$2`,
+		u.ID, hashRecoveryCode(code))
+	return n == 1, errors.Wrap(err, "User.UseRecoveryCode")
 }
 
-// Logout a user.
-func (u *User) Logout(ctx context.Context) error {
-	u.LoginToken = nil
-	u.LoginRequest = nil
-	u.LoginAt = nil
-	err := zdb.Exec(ctx,
-		`update users set login_token=null, login_request=null where user_id=$1 and site_id=$2`,
+// RecoveryCodesLeft gets the number of unused recovery codes.
+func (u *User) RecoveryCodesLeft(ctx context.Context) (int, error) {
+	var n int
+	err := zdb.Get(ctx, &n, `select count(*) from totp_recovery_codes where user_id=$1`, u.ID)
+	return n, errors.Wrap(err, "User.RecoveryCodesLeft")
+}
+
+// hashRecoveryCode hashes a recovery code; the codes are random so there's no
+// need for something like bcrypt. Case, dashes, and spaces are ignored, as
+// people may type them in differently.
+func hashRecoveryCode(code string) string {
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+// Login a user; create a new session for the device with this user agent and
+// IP, and reset the request date.
+//
+// The CSRF token is set to the token of the new session.
+func (u *User) Login(ctx context.Context, userAgent, ip string) (*UserSession, error) {
+	s := UserSession{UserID: u.ID, UserAgent: userAgent, IP: ip}
+	err := zdb.TX(ctx, func(ctx context.Context) error {
+		u.LoginRequest, u.LoginFailures, u.LockedUntil = nil, 0, nil
+		err := zdb.Exec(ctx, `update users set
+				login_request=null, login_failures=0, locked_until=null
+				where user_id=$1 and site_id=$2`,
+			u.ID, MustGetSite(ctx).IDOrParent())
+		if err != nil {
+			return err
+		}
+		return s.Insert(ctx)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "User.Login")
+	}
+	u.Token = &s.CSRFToken
+	return &s, nil
+}
+
+// Locked gets the time until the user can try to sign in again after too many
+// failed logins; this is 0 if the user isn't locked.
+func (u User) Locked() time.Duration {
+	if u.LockedUntil == nil {
+		return 0
+	}
+	d := u.LockedUntil.Sub(Now())
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// LoginFailed records a failed login attempt, and locks the user for some time
+// if there were too many. It reports if the account just got locked for
+// loginLockoutDuration, which is a good moment to tell the user.
+func (u *User) LoginFailed(ctx context.Context) (bool, error) {
+	err := zdb.TX(ctx, func(ctx context.Context) error {
+		err := zdb.Exec(ctx, `update users set login_failures=login_failures+1 where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		err = zdb.Get(ctx, &u.LoginFailures, `select login_failures from users where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+
+		u.LockedUntil = nil
+		if d := loginBackoff(u.LoginFailures); d > 0 {
+			t := Now().Add(d)
+			u.LockedUntil = &t
+		}
+		return zdb.Exec(ctx, `update users set locked_until=$1 where user_id=$2`, u.LockedUntil, u.ID)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "User.LoginFailed")
+	}
+	return u.LoginFailures == loginLockout, nil
+}
+
+// loginBackoff gets the time to wait after n failed logins: nothing for the
+// first few, then 2s, 4s, 8s, etc. and loginLockoutDuration once loginLockout
+// is reached.
+func loginBackoff(n int) time.Duration {
+	switch {
+	case n <= loginFreeAttempts:
+		return 0
+	case n >= loginLockout:
+		return loginLockoutDuration
+	default:
+		return time.Duration(1<<(n-loginFreeAttempts)) * time.Second
+	}
+}
+
+// Unlock resets the failed logins, so the user can sign in again right away.
+func (u *User) Unlock(ctx context.Context) error {
+	u.LoginFailures, u.LockedUntil = 0, nil
+	err := zdb.Exec(ctx, `update users set login_failures=0, locked_until=null where user_id=$1 and site_id=$2`,
 		u.ID, MustGetSite(ctx).IDOrParent())
-	return errors.Wrap(err, "User.Logout")
+	return errors.Wrap(err, "User.Unlock")
+}
+
+// NewCSRFToken sets a new CSRF token.
+//
+// This is only used for users signed in by the authentication proxy, which
+// don't have a session.
+func (u *User) NewCSRFToken(ctx context.Context) error {
+	u.Token = zcrypto.Secret256P()
+	err := zdb.Exec(ctx, `update users set csrf_token=$1 where user_id=$2 and site_id=$3`,
+		u.Token, u.ID, MustGetSite(ctx).IDOrParent())
+	return errors.Wrap(err, "User.NewCSRFToken")
+}
+
+// LogoutAll logs out the user on all devices.
+func (u *User) LogoutAll(ctx context.Context) error {
+	err := zdb.Exec(ctx, `delete from user_sessions where user_id=$1`, u.ID)
+	return errors.Wrap(err, "User.LogoutAll")
+}
+
+// HasAccess reports if this user has at least the access level a.
+func (u User) HasAccess(a UserAccess) bool {
+	return u.Access.level() >= a.level() && a.level() > -1
+}
+
+// Pending reports if this user was invited but hasn't set a password yet.
+func (u User) Pending() bool {
+	return len(u.Password) == 0 && !bool(u.EmailVerified)
+}
+
+// UpdateAccess sets the access level for this user.
+//
+// The owner can't be changed with this; use TransferOwnership() for that.
+func (u *User) UpdateAccess(ctx context.Context, a UserAccess) error {
+	if u.ID == 0 {
+		return errors.New("ID == 0")
+	}
+	if a == AccessOwner || u.Access == AccessOwner {
+		return guru.New(400, "can't change the access of the owner; transfer the ownership instead")
+	}
+	if a.level() == -1 {
+		return guru.Errorf(400, "unknown access level: %q", a)
+	}
+
+	u.Defaults(ctx)
+	err := zdb.Exec(ctx,
+		`update users set access=$1, updated_at=$2 where user_id=$3 and site_id=$4`,
+		a, u.UpdatedAt, u.ID, MustGetSite(ctx).IDOrParent())
+	if err != nil {
+		return errors.Wrap(err, "User.UpdateAccess")
+	}
+	u.Access = a
+	return nil
+}
+
+// TransferOwnership makes to the owner of the site; this user will become an
+// admin.
+func (u *User) TransferOwnership(ctx context.Context, to *User) error {
+	if u.Access != AccessOwner {
+		return guru.New(403, "only the owner can transfer the ownership")
+	}
+	if u.ID == to.ID {
+		return guru.New(400, "already the owner")
+	}
+	if u.Site != to.Site {
+		return errors.Errorf("User.TransferOwnership: different sites: %d and %d", u.Site, to.Site)
+	}
+	if to.Pending() {
+		return guru.Errorf(400, "%q hasn't accepted the invitation yet", to.Email)
+	}
+
+	err := zdb.TX(ctx, func(ctx context.Context) error {
+		err := zdb.Exec(ctx, `update users set access=$1 where user_id=$2`, AccessAdmin, u.ID)
+		if err != nil {
+			return err
+		}
+		return zdb.Exec(ctx, `update users set access=$1 where user_id=$2`, AccessOwner, to.ID)
+	})
+	if err != nil {
+		return errors.Wrap(err, "User.TransferOwnership")
+	}
+	u.Access, to.Access = AccessAdmin, AccessOwner
+	return nil
+}
+
+// Delete this user.
+//
+// The API tokens and share links created by this user are removed as well. The
+// owner can't be deleted.
+func (u *User) Delete(ctx context.Context) error {
+	if u.ID == 0 {
+		return errors.New("ID == 0")
+	}
+	if u.Access == AccessOwner {
+		return guru.New(400, "can't remove the owner; transfer the ownership first")
+	}
+
+	err := zdb.TX(ctx, func(ctx context.Context) error {
+		err := zdb.Exec(ctx, `delete from api_tokens where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		err = zdb.Exec(ctx, `delete from share_tokens where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		err = zdb.Exec(ctx, `delete from webauthn_credentials where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		err = zdb.Exec(ctx, `delete from totp_recovery_codes where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		err = zdb.Exec(ctx, `delete from user_sessions where user_id=$1`, u.ID)
+		if err != nil {
+			return err
+		}
+		return zdb.Exec(ctx, `delete from users where user_id=$1 and site_id=$2`,
+			u.ID, MustGetSite(ctx).IDOrParent())
+	})
+	return errors.Wrap(err, "User.Delete")
 }
 
 // CSRFToken gets the CSRF token.
@@ -367,3 +711,11 @@ func (u *Users) ByEmail(ctx context.Context, email string) error {
 		`select * from users where lower(email)=lower($1) order by user_id asc`, email),
 		"Users.ByEmail")
 }
+
+// List all users for the current site.
+func (u *Users) List(ctx context.Context) error {
+	return errors.Wrap(zdb.Select(ctx, u,
+		`select * from users where site_id=$1 order by lower(email) asc`,
+		MustGetSite(ctx).IDOrParent()),
+		"Users.List")
+}
@@ -0,0 +1,153 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/gadget"
+	"zgo.at/zdb"
+	"zgo.at/zstd/zcrypto"
+	"zgo.at/zvalidate"
+)
+
+// SessionExpire is how long a session can go unused before it expires.
+const SessionExpire = 30 * 24 * time.Hour
+
+// UserSession is a logged in session for a user; there is one for every device
+// or browser a user is logged in on.
+type UserSession struct {
+	ID     int64 `db:"user_session_id" json:"id"`
+	UserID int64 `db:"user_id" json:"-"`
+
+	// Token stored in the cookie.
+	Token string `db:"token" json:"-"`
+
+	// CSRF token for forms; every session has its own, so logging out a
+	// device also invalidates its CSRF token.
+	CSRFToken string `db:"csrf_token" json:"-"`
+
+	UserAgent  string    `db:"user_agent" json:"user_agent"`
+	IP         string    `db:"ip" json:"ip"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	LastSeenAt time.Time `db:"last_seen_at" json:"last_seen_at"`
+}
+
+// Defaults sets fields to default values, unless they're already set.
+func (s *UserSession) Defaults(ctx context.Context) {
+	if s.Token == "" {
+		s.Token = Now().Format("20060102") + "-" + zcrypto.Secret256()
+	}
+	if s.CSRFToken == "" {
+		s.CSRFToken = zcrypto.Secret256()
+	}
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = Now()
+	}
+	if s.LastSeenAt.IsZero() {
+		s.LastSeenAt = s.CreatedAt
+	}
+}
+
+// Validate the object.
+func (s *UserSession) Validate(ctx context.Context) error {
+	v := zvalidate.New()
+	v.Required("user_id", s.UserID)
+	v.Required("token", s.Token)
+	v.Required("csrf_token", s.CSRFToken)
+	return v.ErrorOrNil()
+}
+
+// Insert a new row.
+func (s *UserSession) Insert(ctx context.Context) error {
+	if s.ID > 0 {
+		return errors.New("ID > 0")
+	}
+
+	s.Defaults(ctx)
+	err := s.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.ID, err = zdb.InsertID(ctx, "user_session_id",
+		`insert into user_sessions (user_id, token, csrf_token, user_agent, ip, created_at, last_seen_at) values (?, ?, ?, ?, ?, ?, ?)`,
+		s.UserID, s.Token, s.CSRFToken, s.UserAgent, s.IP, s.CreatedAt, s.LastSeenAt)
+	return errors.Wrap(err, "UserSession.Insert")
+}
+
+// ByID gets a session for the current user by ID.
+func (s *UserSession) ByID(ctx context.Context, id int64) error {
+	return errors.Wrapf(zdb.Get(ctx, s, `/* UserSession.ByID */
+		select * from user_sessions where user_session_id=$1 and user_id=$2`,
+		id, GetUser(ctx).ID), "UserSession.ByID %d", id)
+}
+
+// ByToken gets a session by the token; expired sessions aren't returned.
+func (s *UserSession) ByToken(ctx context.Context, token string) error {
+	return errors.Wrap(zdb.Get(ctx, s, `/* UserSession.ByToken */
+		select * from user_sessions where token=$1 and last_seen_at > $2`,
+		token, Now().Add(-SessionExpire)), "UserSession.ByToken")
+}
+
+// Seen records that the session was used from this user agent and IP.
+//
+// This only updates the row if it wasn't updated in the last minute, as it's
+// called on every request. Only the Token needs to be set.
+func (s *UserSession) Seen(ctx context.Context, userAgent, ip string) error {
+	now := Now()
+	err := zdb.Exec(ctx, `/* UserSession.Seen */
+		update user_sessions set last_seen_at=$1, user_agent=$2, ip=$3
+		where token=$4 and last_seen_at < $5`,
+		now, userAgent, ip, s.Token, now.Add(-time.Minute))
+	return errors.Wrap(err, "UserSession.Seen")
+}
+
+// Browser gets a description of the browser and system from the User-Agent,
+// such as "Firefox 86 on Linux".
+func (s UserSession) Browser() string {
+	if s.UserAgent == "" {
+		return "Unknown"
+	}
+
+	ua := gadget.Parse(s.UserAgent)
+	b := strings.TrimSpace(ua.BrowserName + " " + ua.BrowserVersion)
+	if b == "" {
+		return s.UserAgent
+	}
+	if ua.OSName != "" {
+		b += " on " + ua.OSName
+	}
+	return b
+}
+
+// Delete this session.
+func (s *UserSession) Delete(ctx context.Context) error {
+	err := zdb.Exec(ctx, `/* UserSession.Delete */
+		delete from user_sessions where user_session_id=$1 and user_id=$2`,
+		s.ID, s.UserID)
+	return errors.Wrapf(err, "UserSession.Delete %d", s.ID)
+}
+
+type UserSessions []UserSession
+
+// List all sessions for a user, most recently used first.
+func (s *UserSessions) List(ctx context.Context, userID int64) error {
+	return errors.Wrap(zdb.Select(ctx, s, `/* UserSessions.List */
+		select * from user_sessions where user_id=$1 and last_seen_at > $2
+		order by last_seen_at desc, user_session_id desc`,
+		userID, Now().Add(-SessionExpire)), "UserSessions.List")
+}
+
+// DeleteExpired removes all sessions that haven't been used for SessionExpire.
+func (s UserSessions) DeleteExpired(ctx context.Context) error {
+	err := zdb.Exec(ctx, `/* UserSessions.DeleteExpired */
+		delete from user_sessions where last_seen_at <= $1`,
+		Now().Add(-SessionExpire))
+	return errors.Wrap(err, "UserSessions.DeleteExpired")
+}
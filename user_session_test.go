@@ -0,0 +1,67 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+	"zgo.at/zdb"
+)
+
+func TestUserSession(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:00:00")
+	ctx := gctest.DB(t)
+	u := GetUser(ctx)
+
+	s1, err := u.Login(ctx, "", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := u.Login(ctx, "", "192.0.2.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s1.CSRFToken == "" || s1.CSRFToken == s2.CSRFToken {
+		t.Fatalf("CSRF tokens not unique: %q %q", s1.CSRFToken, s2.CSRFToken)
+	}
+
+	var got User
+	err = got.ByTokenAndSite(ctx, s1.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != u.ID || got.CSRFToken() != s1.CSRFToken {
+		t.Errorf("wrong user or CSRF token: %d %q", got.ID, got.CSRFToken())
+	}
+
+	// Logging out one session doesn't affect the other.
+	err = s1.Delete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.ByTokenAndSite(ctx, s1.Token); !zdb.ErrNoRows(err) {
+		t.Errorf("wrong error for deleted session: %v", err)
+	}
+	err = got.ByTokenAndSite(ctx, s2.Token)
+	if err != nil || got.CSRFToken() != s2.CSRFToken {
+		t.Errorf("other session: %v %q", err, got.CSRFToken())
+	}
+
+	// Expire.
+	gctest.SetNow(t, Now().Add(SessionExpire+time.Minute))
+	if err := got.ByTokenAndSite(ctx, s2.Token); !zdb.ErrNoRows(err) {
+		t.Errorf("wrong error for expired session: %v", err)
+	}
+	err = UserSessions{}.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := zdb.Diff(zdb.DumpString(ctx, `select count(*) as n from user_sessions`), "n\n0"); d != "" {
+		t.Error(d)
+	}
+}
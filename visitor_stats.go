@@ -0,0 +1,120 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/zdb"
+)
+
+// VisitorStat is the number of pageviews from new and returning visitors.
+//
+// A pageview is from a new visitor if it's the first time this visitor viewed
+// the path (i.e. Hit.FirstVisit), and from a returning visitor otherwise.
+type VisitorStat struct {
+	Day       string `db:"day" json:"day,omitempty"`
+	PathID    int64  `db:"path_id" json:"path_id,omitempty"`
+	New       int    `db:"count_new" json:"new"`
+	Returning int    `db:"count_returning" json:"returning"`
+}
+
+// Total number of pageviews.
+func (v VisitorStat) Total() int { return v.New + v.Returning }
+
+// PercentNew gets the percentage of new visitors, rounded down.
+func (v VisitorStat) PercentNew() int {
+	if v.Total() == 0 {
+		return 0
+	}
+	return v.New * 100 / v.Total()
+}
+
+type VisitorStats []VisitorStat
+
+// List the new and returning visitors per day; days without any pageviews are
+// included.
+func (v *VisitorStats) List(ctx context.Context, start, end time.Time, filter Filter) error {
+	site := MustGetSite(ctx)
+	startDay, endDay := asUTCDate(site, start), asUTCDate(site, end)
+
+	var st VisitorStats
+	err := filter.sel(ctx, &st, start, end, "load:visitor_stats.List", zdb.P{
+		"site":   site.ID,
+		"start":  startDay,
+		"end":    endDay,
+		"filter": filter.Paths,
+	})
+	if err != nil {
+		return errors.Wrap(err, "VisitorStats.List")
+	}
+
+	// The date may be returned as a date or timestamp, depending on the
+	// database and filter.
+	for i := range st {
+		if len(st[i].Day) > 10 {
+			st[i].Day = st[i].Day[:10]
+		}
+	}
+
+	// Fill in blank days.
+	if startDay > endDay {
+		return nil
+	}
+	day, _ := time.Parse("2006-01-02", startDay)
+	j := 0
+	for {
+		dayFmt := day.Format("2006-01-02")
+		if j < len(st) && st[j].Day == dayFmt {
+			*v = append(*v, st[j])
+			j++
+		} else {
+			*v = append(*v, VisitorStat{Day: dayFmt})
+		}
+		if dayFmt >= endDay {
+			break
+		}
+		day = day.Add(24 * time.Hour)
+	}
+	return nil
+}
+
+// ListPaths gets the total number of new and returning visitors for the paths
+// listed by HitLists.List with the same exclude and limit.
+func (v *VisitorStats) ListPaths(ctx context.Context, start, end time.Time, filter Filter, exclude []int64, limit int) error {
+	site := MustGetSite(ctx)
+	q, err := DB.ReadFile("db/query/visitor_stats.ListPaths.sql")
+	if err != nil {
+		return errors.Wrap(err, "VisitorStats.ListPaths")
+	}
+
+	p := topPaths(ctx, start, end, filter, exclude, pageLimit(site, limit))
+	p["start"], p["end"] = asUTCDate(site, start), asUTCDate(site, end)
+	err = filter.sel(ctx, v, start, end, "with "+topPathsCTE+"\n"+string(q), p)
+	return errors.Wrap(err, "VisitorStats.ListPaths")
+}
+
+// Totals gets the total number of new and returning visitors.
+func (v VisitorStats) Totals() VisitorStat {
+	var t VisitorStat
+	for _, s := range v {
+		t.New += s.New
+		t.Returning += s.Returning
+	}
+	return t
+}
+
+// Path gets the stats for the path ID; returns a zero VisitorStat if there are
+// no stats for this path.
+func (v VisitorStats) Path(pathID int64) VisitorStat {
+	for _, s := range v {
+		if s.PathID == pathID {
+			return s
+		}
+	}
+	return VisitorStat{PathID: pathID}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "zgo.at/goatcounter"
+	"zgo.at/goatcounter/gctest"
+)
+
+func TestVisitorStats(t *testing.T) {
+	gctest.SetNow(t, "2020-06-18 12:00:00")
+	ctx := gctest.DB(t)
+
+	gctest.StoreHits(ctx, t, false,
+		Hit{Path: "/a", FirstVisit: true},
+		Hit{Path: "/a"},
+		Hit{Path: "/a"},
+		Hit{Path: "/b", FirstVisit: true},
+		Hit{Path: "/b", FirstVisit: true, CreatedAt: Now().Add(-24 * time.Hour)},
+	)
+
+	start, end := Now().Add(-48*time.Hour), Now()
+
+	list := func(t *testing.T, ctx context.Context, filter string) string {
+		f, err := NewFilter(ctx, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v VisitorStats
+		err = v.List(ctx, start, end, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprintf("%v", v)
+	}
+
+	tests := []struct {
+		filter, want string
+	}{
+		{"", "[{2020-06-16 0 0 0} {2020-06-17 0 1 0} {2020-06-18 0 2 2}]"},
+		{"/a", "[{2020-06-16 0 0 0} {2020-06-17 0 0 0} {2020-06-18 0 1 2}]"},
+		{"browser:x", "[{2020-06-16 0 0 0} {2020-06-17 0 0 0} {2020-06-18 0 0 0}]"},
+		{"visitor:returning", "[{2020-06-16 0 0 0} {2020-06-17 0 0 0} {2020-06-18 0 0 2}]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got := list(t, ctx, tt.filter)
+			if got != tt.want {
+				t.Errorf("\ngot:  %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+
+	var paths VisitorStats
+	err := paths.ListPaths(ctx, start, end, Filter{}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := paths.Path(1); a.New != 1 || a.Returning != 2 || a.PercentNew() != 33 {
+		t.Errorf("/a: %v", a)
+	}
+	if b := paths.Path(2); b.New != 2 || b.Returning != 0 {
+		t.Errorf("/b: %v", b)
+	}
+	if tot := paths.Totals(); tot.Total() != 5 {
+		t.Errorf("totals: %v", tot)
+	}
+
+	var excl VisitorStats
+	err = excl.ListPaths(ctx, start, end, Filter{}, []int64{1}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := excl.Path(1); a.Total() != 0 {
+		t.Errorf("/a not excluded: %v", a)
+	}
+	if b := excl.Path(2); b.New != 2 {
+		t.Errorf("/b: %v", b)
+	}
+}
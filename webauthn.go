@@ -0,0 +1,122 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package goatcounter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zgo.at/errors"
+	"zgo.at/goatcounter/webauthn"
+	"zgo.at/zdb"
+	"zgo.at/zvalidate"
+)
+
+// WebAuthnCredential is a security key or passkey that a user can sign in
+// with.
+type WebAuthnCredential struct {
+	ID     int64 `db:"webauthn_credential_id" json:"id"`
+	UserID int64 `db:"user_id" json:"-"`
+
+	Name      string `db:"name" json:"name"`
+	RawID     []byte `db:"raw_id" json:"-"`
+	PublicKey []byte `db:"public_key" json:"-"`
+	SignCount int64  `db:"sign_count" json:"-"`
+
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// Defaults sets fields to default values, unless they're already set.
+func (c *WebAuthnCredential) Defaults(ctx context.Context) {
+	if u := GetUser(ctx); u != nil && c.UserID == 0 {
+		c.UserID = u.ID
+	}
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		c.Name = "Security key"
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = Now()
+	}
+}
+
+// Validate the object.
+func (c *WebAuthnCredential) Validate(ctx context.Context) error {
+	v := zvalidate.New()
+	v.Required("user_id", c.UserID)
+	v.Required("raw_id", c.RawID)
+	v.Required("public_key", c.PublicKey)
+	v.Len("name", c.Name, 0, 200)
+	return v.ErrorOrNil()
+}
+
+// Insert a new row.
+func (c *WebAuthnCredential) Insert(ctx context.Context) error {
+	if c.ID > 0 {
+		return errors.New("ID > 0")
+	}
+
+	c.Defaults(ctx)
+	err := c.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.ID, err = zdb.InsertID(ctx, "webauthn_credential_id",
+		`insert into webauthn_credentials (user_id, name, raw_id, public_key, sign_count, created_at) values (?, ?, ?, ?, ?, ?)`,
+		c.UserID, c.Name, c.RawID, c.PublicKey, c.SignCount, c.CreatedAt)
+	return errors.Wrap(err, "WebAuthnCredential.Insert")
+}
+
+// ByID gets a credential for the current user by ID.
+func (c *WebAuthnCredential) ByID(ctx context.Context, id int64) error {
+	return errors.Wrapf(zdb.Get(ctx, c, `/* WebAuthnCredential.ByID */
+		select * from webauthn_credentials where webauthn_credential_id=$1 and user_id=$2`,
+		id, GetUser(ctx).ID), "WebAuthnCredential.ByID %d", id)
+}
+
+// ByRawID gets a credential by the ID the authenticator chose, for any user on
+// this site.
+func (c *WebAuthnCredential) ByRawID(ctx context.Context, rawID []byte) error {
+	return errors.Wrap(zdb.Get(ctx, c, `/* WebAuthnCredential.ByRawID */
+		select webauthn_credentials.* from webauthn_credentials
+		join users using (user_id)
+		where raw_id=$1 and users.site_id=$2`,
+		rawID, MustGetSite(ctx).IDOrParent()), "WebAuthnCredential.ByRawID")
+}
+
+// Credential gets this as a webauthn.Credential.
+func (c WebAuthnCredential) Credential() webauthn.Credential {
+	return webauthn.Credential{ID: c.RawID, PublicKey: c.PublicKey, SignCount: uint32(c.SignCount)}
+}
+
+// UpdateUsed sets the signature counter and last used time.
+func (c *WebAuthnCredential) UpdateUsed(ctx context.Context, signCount uint32) error {
+	now := Now()
+	c.SignCount, c.LastUsedAt = int64(signCount), &now
+	err := zdb.Exec(ctx, `/* WebAuthnCredential.UpdateUsed */
+		update webauthn_credentials set sign_count=$1, last_used_at=$2 where webauthn_credential_id=$3`,
+		c.SignCount, c.LastUsedAt, c.ID)
+	return errors.Wrap(err, "WebAuthnCredential.UpdateUsed")
+}
+
+// Delete this credential.
+func (c *WebAuthnCredential) Delete(ctx context.Context) error {
+	err := zdb.Exec(ctx, `/* WebAuthnCredential.Delete */
+		delete from webauthn_credentials where webauthn_credential_id=$1 and user_id=$2`,
+		c.ID, c.UserID)
+	return errors.Wrapf(err, "WebAuthnCredential.Delete %d", c.ID)
+}
+
+type WebAuthnCredentials []WebAuthnCredential
+
+// List all credentials for a user.
+func (c *WebAuthnCredentials) List(ctx context.Context, userID int64) error {
+	return errors.Wrap(zdb.Select(ctx, c, `/* WebAuthnCredentials.List */
+		select * from webauthn_credentials where user_id=$1 order by created_at, webauthn_credential_id`,
+		userID), "WebAuthnCredentials.List")
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package webauthn
+
+import (
+	"encoding/binary"
+	"math"
+
+	"zgo.at/errors"
+)
+
+// Don't allow nesting deeper than this; WebAuthn never needs more than a few
+// levels.
+const cborMaxDepth = 16
+
+// decodeCBOR decodes the first CBOR item in b, and returns the remaining data.
+//
+// Only the subset that WebAuthn uses is supported: integers (as int64), byte
+// strings, text strings, arrays, maps, and the simple values false, true, null,
+// and undefined. Indefinite lengths, tags, and floats are not supported, as
+// authenticators must use the canonical CBOR encoding.
+func decodeCBOR(b []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (interface{}, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, errors.New("cbor: nested too deep")
+	}
+	if len(b) == 0 {
+		return nil, nil, errors.New("cbor: unexpected end of data")
+	}
+
+	major, info := b[0]>>5, b[0]&0x1f
+	b = b[1:]
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, b, nil
+		case 21:
+			return true, b, nil
+		case 22, 23:
+			return nil, b, nil
+		}
+		return nil, nil, errors.Errorf("cbor: unsupported simple value %d", info)
+	}
+
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info == 24 && len(b) >= 1:
+		n, b = uint64(b[0]), b[1:]
+	case info == 25 && len(b) >= 2:
+		n, b = uint64(binary.BigEndian.Uint16(b)), b[2:]
+	case info == 26 && len(b) >= 4:
+		n, b = uint64(binary.BigEndian.Uint32(b)), b[4:]
+	case info == 27 && len(b) >= 8:
+		n, b = binary.BigEndian.Uint64(b), b[8:]
+	case info == 31:
+		return nil, nil, errors.New("cbor: indefinite length not supported")
+	default:
+		return nil, nil, errors.New("cbor: invalid length")
+	}
+
+	switch major {
+	case 0:
+		if n > math.MaxInt64 {
+			return nil, nil, errors.New("cbor: integer overflow")
+		}
+		return int64(n), b, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, nil, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(n), b, nil
+	case 2, 3:
+		if n > uint64(len(b)) {
+			return nil, nil, errors.New("cbor: unexpected end of data")
+		}
+		if major == 3 {
+			return string(b[:n]), b[n:], nil
+		}
+		return b[:n], b[n:], nil
+	case 4:
+		if n > uint64(len(b)) { // Every item is at least one byte.
+			return nil, nil, errors.New("cbor: unexpected end of data")
+		}
+		arr := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			var (
+				v   interface{}
+				err error
+			)
+			v, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, b, nil
+	case 5:
+		if n > uint64(len(b))/2 {
+			return nil, nil, errors.New("cbor: unexpected end of data")
+		}
+		m := make(map[interface{}]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			var (
+				k, v interface{}
+				err  error
+			)
+			k, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errors.Errorf("cbor: unsupported map key type %T", k)
+			}
+			v, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[k] = v
+		}
+		return m, b, nil
+	}
+	return nil, nil, errors.Errorf("cbor: unsupported major type %d", major)
+}
@@ -0,0 +1,260 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package webauthn implements the server side of Web Authentication, for
+// signing in with security keys and passkeys.
+//
+// Attestations are not verified, as we don't care what kind of authenticator
+// people use; the credential options should ask for the "none" attestation.
+// Only ES256 and RS256 keys are supported, which is what all authenticators
+// use.
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"zgo.at/errors"
+)
+
+// COSE algorithms.
+const (
+	AlgES256 = -7
+	AlgRS256 = -257
+)
+
+// Flags in the authenticator data.
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// RelyingParty is the website that users sign in to.
+type RelyingParty struct {
+	ID     string // Domain name, without port.
+	Name   string // Name to display to the user.
+	Origin string // Origin of the page, e.g. "https://example.com:8080".
+}
+
+// Credential is a registered public key.
+type Credential struct {
+	ID        []byte // Credential ID chosen by the authenticator.
+	PublicKey []byte // Public key, in the COSE format.
+	SignCount uint32
+}
+
+// Challenge creates a new random challenge.
+func Challenge() []byte {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Register verifies the response from navigator.credentials.create().
+func (rp RelyingParty) Register(challenge, clientData, attestation []byte) (Credential, error) {
+	err := rp.verifyClientData("webauthn.create", challenge, clientData)
+	if err != nil {
+		return Credential{}, errors.Wrap(err, "webauthn.Register")
+	}
+
+	att, _, err := decodeCBOR(attestation)
+	if err != nil {
+		return Credential{}, errors.Wrap(err, "webauthn.Register")
+	}
+	attMap, _ := att.(map[interface{}]interface{})
+	authData, ok := attMap["authData"].([]byte)
+	if !ok {
+		return Credential{}, errors.New("webauthn.Register: no authData in attestation")
+	}
+
+	ad, err := rp.parseAuthData(authData)
+	if err != nil {
+		return Credential{}, errors.Wrap(err, "webauthn.Register")
+	}
+	if ad.flags&flagAttested == 0 {
+		return Credential{}, errors.New("webauthn.Register: no attested credential data")
+	}
+	_, err = parseKey(ad.publicKey)
+	if err != nil {
+		return Credential{}, errors.Wrap(err, "webauthn.Register")
+	}
+
+	return Credential{ID: ad.credID, PublicKey: ad.publicKey, SignCount: ad.signCount}, nil
+}
+
+// Verify the response from navigator.credentials.get(), and return the new
+// signature counter.
+//
+// If userVerified is set then the authenticator must have verified the user
+// with a PIN, biometrics, etc. in addition to checking the user is present.
+func (rp RelyingParty) Verify(challenge []byte, cred Credential, clientData, authData, sig []byte, userVerified bool) (uint32, error) {
+	err := rp.verifyClientData("webauthn.get", challenge, clientData)
+	if err != nil {
+		return 0, errors.Wrap(err, "webauthn.Verify")
+	}
+	ad, err := rp.parseAuthData(authData)
+	if err != nil {
+		return 0, errors.Wrap(err, "webauthn.Verify")
+	}
+	if userVerified && ad.flags&flagUserVerified == 0 {
+		return 0, errors.New("webauthn.Verify: user not verified")
+	}
+
+	key, err := parseKey(cred.PublicKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "webauthn.Verify")
+	}
+
+	cdHash := sha256.Sum256(clientData)
+	h := sha256.Sum256(append(append([]byte{}, authData...), cdHash[:]...))
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		ok := ecdsa.VerifyASN1(k, h[:], sig)
+		if !ok {
+			return 0, errors.New("webauthn.Verify: invalid signature")
+		}
+	case *rsa.PublicKey:
+		err := rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig)
+		if err != nil {
+			return 0, errors.New("webauthn.Verify: invalid signature")
+		}
+	}
+
+	// The counter is always 0 if the authenticator doesn't support it;
+	// otherwise it should always increase, and if it doesn't the credential
+	// may have been cloned.
+	if (ad.signCount != 0 || cred.SignCount != 0) && ad.signCount <= cred.SignCount {
+		return 0, errors.New("webauthn.Verify: signature counter didn't increase")
+	}
+	return ad.signCount, nil
+}
+
+func (rp RelyingParty) verifyClientData(typ string, challenge, clientData []byte) error {
+	var cd struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	err := json.Unmarshal(clientData, &cd)
+	if err != nil {
+		return errors.Wrap(err, "decoding client data")
+	}
+
+	if cd.Type != typ {
+		return errors.Errorf("wrong type %q", cd.Type)
+	}
+	c, err := base64.RawURLEncoding.DecodeString(cd.Challenge)
+	if err != nil || subtle.ConstantTimeCompare(c, challenge) != 1 {
+		return errors.New("wrong challenge")
+	}
+	if cd.Origin != rp.Origin {
+		return errors.Errorf("wrong origin %q", cd.Origin)
+	}
+	return nil
+}
+
+type authData struct {
+	flags     byte
+	signCount uint32
+	credID    []byte
+	publicKey []byte
+}
+
+func (rp RelyingParty) parseAuthData(b []byte) (authData, error) {
+	var ad authData
+	if len(b) < 37 {
+		return ad, errors.New("authenticator data too short")
+	}
+
+	rpHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(b[:32], rpHash[:]) {
+		return ad, errors.New("wrong relying party ID")
+	}
+	ad.flags = b[32]
+	if ad.flags&flagUserPresent == 0 {
+		return ad, errors.New("user not present")
+	}
+	ad.signCount = binary.BigEndian.Uint32(b[33:37])
+
+	if ad.flags&flagAttested == 0 {
+		return ad, nil
+	}
+
+	// AAGUID (16 bytes), credential ID length (2 bytes), credential ID, and the
+	// public key.
+	b = b[37:]
+	if len(b) < 18 {
+		return ad, errors.New("attested credential data too short")
+	}
+	l := int(binary.BigEndian.Uint16(b[16:18]))
+	b = b[18:]
+	if len(b) < l {
+		return ad, errors.New("attested credential data too short")
+	}
+	ad.credID, b = b[:l], b[l:]
+
+	_, rest, err := decodeCBOR(b)
+	if err != nil {
+		return ad, errors.Wrap(err, "decoding public key")
+	}
+	ad.publicKey = b[:len(b)-len(rest)]
+	return ad, nil
+}
+
+// parseKey parses a public key in the COSE format.
+func parseKey(b []byte) (crypto.PublicKey, error) {
+	k, _, err := decodeCBOR(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "parseKey")
+	}
+	m, ok := k.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("parseKey: not a map")
+	}
+
+	bigInt := func(k int64) *big.Int {
+		b, _ := m[k].([]byte)
+		return new(big.Int).SetBytes(b)
+	}
+
+	switch alg, _ := m[int64(3)].(int64); alg {
+	case AlgES256:
+		if kty, _ := m[int64(1)].(int64); kty != 2 {
+			return nil, errors.Errorf("parseKey: wrong key type %d for ES256", kty)
+		}
+		if crv, _ := m[int64(-1)].(int64); crv != 1 {
+			return nil, errors.Errorf("parseKey: unsupported curve %d", crv)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: bigInt(-2), Y: bigInt(-3)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("parseKey: point not on curve")
+		}
+		return key, nil
+	case AlgRS256:
+		if kty, _ := m[int64(1)].(int64); kty != 3 {
+			return nil, errors.Errorf("parseKey: wrong key type %d for RS256", kty)
+		}
+		n, e := bigInt(-1), bigInt(-2)
+		if n.BitLen() < 2048 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("parseKey: invalid RSA key")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	default:
+		return nil, errors.Errorf("parseKey: unsupported algorithm %d", alg)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package webauthn
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"zgo.at/goatcounter/webauthn/webauthntest"
+	"zgo.at/zstd/ztest"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	tests := []struct {
+		in      []byte
+		want    interface{}
+		wantErr string
+	}{
+		{[]byte{0x00}, int64(0), ""},
+		{[]byte{0x17}, int64(23), ""},
+		{[]byte{0x18, 0xff}, int64(255), ""},
+		{[]byte{0x19, 0x01, 0x00}, int64(256), ""},
+		{[]byte{0x20}, int64(-1), ""},
+		{[]byte{0x39, 0x01, 0x00}, int64(-257), ""},
+		{[]byte{0x43, 'a', 'b', 'c'}, []byte("abc"), ""},
+		{[]byte{0x63, 'a', 'b', 'c'}, "abc", ""},
+		{[]byte{0x82, 0x01, 0x61, 'x'}, []interface{}{int64(1), "x"}, ""},
+		{[]byte{0xa2, 0x01, 0x02, 0x61, 'k', 0xf5}, map[interface{}]interface{}{int64(1): int64(2), "k": true}, ""},
+		{[]byte{0xf4}, false, ""},
+		{[]byte{0xf6}, nil, ""},
+
+		{[]byte{}, nil, "unexpected end"},
+		{[]byte{0x43, 'a'}, nil, "unexpected end"},
+		{[]byte{0x9f}, nil, "indefinite length"},
+		{[]byte{0xc0, 0x00}, nil, "unsupported major type 6"},
+		{[]byte{0xfb, 0, 0, 0, 0, 0, 0, 0, 0}, nil, "unsupported simple value"},
+		{[]byte{0xa1, 0x80, 0x00}, nil, "unsupported map key"},
+		{[]byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, nil, "overflow"},
+		{bytes.Repeat([]byte{0x81}, 20), nil, "nested too deep"},
+		{[]byte{0x9a, 0xff, 0xff, 0xff, 0xff}, nil, "unexpected end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%x", tt.in), func(t *testing.T) {
+			got, _, err := decodeCBOR(tt.in)
+			if !ztest.ErrorContains(err, tt.wantErr) {
+				t.Fatalf("wrong error\nwant: %s\ngot:  %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\nwant: %#v\ngot:  %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterVerify(t *testing.T) {
+	rp := RelyingParty{ID: "example.com", Name: "Example", Origin: "https://example.com"}
+
+	for _, alg := range []int64{AlgES256, AlgRS256} {
+		t.Run(fmt.Sprintf("%d", alg), func(t *testing.T) {
+			a := webauthntest.New(alg)
+
+			challenge := Challenge()
+			cred, err := rp.Register(challenge, a.ClientData("webauthn.create", challenge, rp.Origin), a.Attestation(rp.ID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(cred.ID, a.ID) {
+				t.Errorf("wrong ID: %x", cred.ID)
+			}
+
+			challenge = Challenge()
+			cd := a.ClientData("webauthn.get", challenge, rp.Origin)
+			ad, sig := a.Sign(rp.ID, flagUserPresent|flagUserVerified, cd)
+			count, err := rp.Verify(challenge, cred, cd, ad, sig, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != a.Count {
+				t.Errorf("count: %d", count)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		a := webauthntest.New(AlgES256)
+		challenge := Challenge()
+		cred, err := rp.Register(challenge, a.ClientData("webauthn.create", challenge, rp.Origin), a.Attestation(rp.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name    string
+			modify  func(cd, ad, sig *[]byte)
+			flags   byte
+			uv      bool
+			wantErr string
+		}{
+			{"ok", nil, flagUserPresent, false, ""},
+			{"wrong type", func(cd, ad, sig *[]byte) {
+				*cd = a.ClientData("webauthn.create", challenge, rp.Origin)
+			}, flagUserPresent, false, "wrong type"},
+			{"wrong challenge", func(cd, ad, sig *[]byte) {
+				*cd = a.ClientData("webauthn.get", Challenge(), rp.Origin)
+			}, flagUserPresent, false, "wrong challenge"},
+			{"wrong origin", func(cd, ad, sig *[]byte) {
+				*cd = a.ClientData("webauthn.get", challenge, "https://evil.com")
+			}, flagUserPresent, false, "wrong origin"},
+			{"wrong rp", func(cd, ad, sig *[]byte) {
+				*ad, *sig = a.Sign("evil.com", flagUserPresent, *cd)
+			}, flagUserPresent, false, "wrong relying party"},
+			{"bad signature", func(cd, ad, sig *[]byte) {
+				(*sig)[10] ^= 0xff
+			}, flagUserPresent, false, "invalid signature"},
+			{"not present", nil, 0, false, "user not present"},
+			{"not verified", nil, flagUserPresent, true, "user not verified"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cd := a.ClientData("webauthn.get", challenge, rp.Origin)
+				ad, sig := a.Sign(rp.ID, tt.flags, cd)
+				if tt.modify != nil {
+					tt.modify(&cd, &ad, &sig)
+				}
+				_, err := rp.Verify(challenge, cred, cd, ad, sig, tt.uv)
+				if !ztest.ErrorContains(err, tt.wantErr) {
+					t.Fatalf("wrong error\nwant: %s\ngot:  %v", tt.wantErr, err)
+				}
+			})
+		}
+
+		t.Run("counter", func(t *testing.T) {
+			cred.SignCount = a.Count + 10
+			cd := a.ClientData("webauthn.get", challenge, rp.Origin)
+			ad, sig := a.Sign(rp.ID, flagUserPresent, cd)
+			_, err := rp.Verify(challenge, cred, cd, ad, sig, false)
+			if !ztest.ErrorContains(err, "counter didn't increase") {
+				t.Fatalf("wrong error: %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+// Package webauthntest provides a virtual WebAuthn authenticator for tests.
+package webauthntest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// Flags in the authenticator data.
+const (
+	FlagUserPresent  = 0x01
+	FlagUserVerified = 0x04
+	FlagAttested     = 0x40
+)
+
+// Authenticator is a virtual authenticator.
+type Authenticator struct {
+	ID    []byte // Credential ID.
+	Alg   int64  // COSE algorithm: -7 for ES256, -257 for RS256.
+	Count uint32 // Signature counter; incremented for every signature.
+
+	key crypto.Signer
+}
+
+// New creates a new authenticator with a new key.
+func New(alg int64) *Authenticator {
+	a := &Authenticator{ID: make([]byte, 16), Alg: alg}
+	_, err := rand.Read(a.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	switch alg {
+	case -7:
+		a.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case -257:
+		a.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		err = fmt.Errorf("webauthntest.New: unsupported algorithm %d", alg)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// ClientData creates the clientDataJSON the browser would send.
+func (a *Authenticator) ClientData(typ string, challenge []byte, origin string) []byte {
+	return []byte(fmt.Sprintf(`{"type":%q,"challenge":%q,"origin":%q,"crossOrigin":false}`,
+		typ, base64.RawURLEncoding.EncodeToString(challenge), origin))
+}
+
+// Attestation creates an attestation object with the "none" format, as
+// returned by navigator.credentials.create().
+func (a *Authenticator) Attestation(rpID string) []byte {
+	var coseKey map[interface{}]interface{}
+	switch k := a.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		coseKey = map[interface{}]interface{}{
+			int64(1): int64(2), int64(3): a.Alg, int64(-1): int64(1),
+			int64(-2): k.X.FillBytes(make([]byte, 32)), int64(-3): k.Y.FillBytes(make([]byte, 32)),
+		}
+	case *rsa.PublicKey:
+		coseKey = map[interface{}]interface{}{
+			int64(1): int64(3), int64(3): a.Alg,
+			int64(-1): k.N.Bytes(), int64(-2): []byte{0x01, 0x00, 0x01},
+		}
+	}
+
+	ad := a.authData(rpID, FlagUserPresent|FlagAttested)
+	ad = append(ad, make([]byte, 16)...) // AAGUID
+	l := make([]byte, 2)
+	binary.BigEndian.PutUint16(l, uint16(len(a.ID)))
+	ad = append(ad, l...)
+	ad = append(ad, a.ID...)
+	ad = append(ad, EncodeCBOR(coseKey)...)
+
+	return EncodeCBOR(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": ad,
+	})
+}
+
+// Sign creates the authenticator data and signature, as returned by
+// navigator.credentials.get().
+func (a *Authenticator) Sign(rpID string, flags byte, clientData []byte) ([]byte, []byte) {
+	ad := a.authData(rpID, flags)
+	cdHash := sha256.Sum256(clientData)
+	h := sha256.Sum256(append(append([]byte{}, ad...), cdHash[:]...))
+	sig, err := a.key.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		panic(err)
+	}
+	return ad, sig
+}
+
+func (a *Authenticator) authData(rpID string, flags byte) []byte {
+	a.Count++
+	h := sha256.Sum256([]byte(rpID))
+	b := append([]byte{}, h[:]...)
+	b = append(b, flags)
+	return append(b, byte(a.Count>>24), byte(a.Count>>16), byte(a.Count>>8), byte(a.Count))
+}
+
+// EncodeCBOR encodes int64, []byte, string, and map[interface{}]interface{}
+// values to CBOR.
+func EncodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 1<<16:
+			return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+		default:
+			return []byte{major<<5 | 26, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+		}
+	}
+
+	switch vv := v.(type) {
+	case int64:
+		if vv < 0 {
+			return head(1, uint64(-1-vv))
+		}
+		return head(0, uint64(vv))
+	case []byte:
+		return append(head(2, uint64(len(vv))), vv...)
+	case string:
+		return append(head(3, uint64(len(vv))), vv...)
+	case map[interface{}]interface{}:
+		// Sort for a stable output.
+		keys := make([]interface{}, 0, len(vv))
+		for k := range vv {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+		b := head(5, uint64(len(vv)))
+		for _, k := range keys {
+			b = append(b, EncodeCBOR(k)...)
+			b = append(b, EncodeCBOR(vv[k])...)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("webauthntest.EncodeCBOR: unsupported type %T", v))
+}
@@ -8,9 +8,11 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"strings"
 	"time"
 
 	"zgo.at/goatcounter"
+	"zgo.at/zhttp/ztpl"
 	"zgo.at/zstd/zint"
 )
 
@@ -31,11 +33,15 @@ type (
 
 	Args struct {
 		Start, End  time.Time
-		PathFilter  []int64
+		Filter      goatcounter.Filter
 		Daily       bool
 		ForcedDaily bool
 		ShowRefs    string
 		AsText      bool
+
+		// Maximum number of rows to load for widgets that paginate; the
+		// widget's default is used if this is 0.
+		Limit int
 	}
 
 	// SharedData gets passed to every widget.
@@ -51,6 +57,15 @@ type (
 	}
 )
 
+// limit gets the limit for the number of rows, falling back to def if no limit
+// was set.
+func (a Args) limit(def int) int {
+	if a.Limit > 0 {
+		return a.Limit
+	}
+	return def
+}
+
 type List []Widget
 
 var (
@@ -71,6 +86,11 @@ func FromSiteWidgets(www goatcounter.Widgets, params zint.Bitflag8) List {
 		}
 
 		name := w["name"].(string)
+		if _, ok := registry[name]; !ok {
+			// Can happen if a widget was registered earlier but no longer
+			// is; just skip it.
+			continue
+		}
 		ww := NewWidget(name)
 
 		switch name {
@@ -107,66 +127,146 @@ func (l List) Get(name string) Widget {
 	return nil
 }
 
+// Registration for a widget; see Register().
+type Registration struct {
+	// Create a new instance of the widget; the name is taken from the Name()
+	// method on this.
+	New func() Widget
+
+	// Enable by default for new sites.
+	On bool
+
+	// Settings that can be configured for this widget; may be nil.
+	Settings goatcounter.WidgetSettings
+
+	// Templates to render the template name returned by RenderHTML() with.
+	// May be nil to use GoatCounter's templates.
+	Template *template.Template
+}
+
+// All widgets, including the internal ones.
+var registry = map[string]Registration{
+	"totalcount": {New: func() Widget { return &TotalCount{} }},
+	"max":        {New: func() Widget { return &Max{} }},
+	"refs":       {New: func() Widget { return &Refs{} }},
+
+	"pages":      {New: func() Widget { return &Pages{} }},
+	"totalpages": {New: func() Widget { return &TotalPages{} }},
+	"toprefs":    {New: func() Widget { return &TopRefs{} }},
+	"browsers":   {New: func() Widget { return &Browsers{} }},
+	"systems":    {New: func() Widget { return &Systems{} }},
+	"sizes":      {New: func() Widget { return &Sizes{} }},
+	"locations":  {New: func() Widget { return &Locations{} }},
+	"heatmap":    {New: func() Widget { return &Heatmap{} }},
+	"visitors":   {New: func() Widget { return &Visitors{} }},
+	"channels":   {New: func() Widget { return &Channels{} }},
+	"worldmap":   {New: func() Widget { return &WorldMap{} }},
+}
+
+// Register a new widget.
+//
+// The widget is added to the list of widgets for all sites, and will show up
+// on the dashboard and in the dashboard settings. GetData() is called to load
+// the data, and RenderHTML() returns the template name and data to render.
+//
+// This is not safe for concurrent use and should be called from init(). It
+// will panic if a widget with this name is already registered.
+func Register(r Registration) {
+	if r.New == nil {
+		panic("widgets.Register: New is nil")
+	}
+	name := r.New().Name()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("widgets.Register: widget %q already registered", name))
+	}
+
+	goatcounter.RegisterWidget(name, r.On, r.Settings)
+	registry[name] = r
+}
+
+// NewWidget creates a new widget by name; it will panic if there is no widget
+// with this name registered.
 func NewWidget(name string) Widget {
-	switch name {
-	case "totalcount":
-		return &TotalCount{}
-	case "max":
-		return &Max{}
-	case "refs":
-		return &Refs{}
-
-	case "pages":
-		return &Pages{}
-	case "totalpages":
-		return &TotalPages{}
-	case "toprefs":
-		return &TopRefs{}
-	case "browsers":
-		return &Browsers{}
-	case "systems":
-		return &Systems{}
-	case "sizes":
-		return &Sizes{}
-	case "locations":
-		return &Locations{}
+	r, ok := registry[name]
+	if !ok {
+		panic(fmt.Errorf("unknown widget: %q", name))
+	}
+	return r.New()
+}
+
+// ExecuteTemplate renders the template for the widget, as returned by
+// RenderHTML().
+//
+// Templates from a widget's Registration.Template are used if it has a
+// template with this name, and GoatCounter's templates otherwise.
+func ExecuteTemplate(w Widget, name string, data interface{}) (string, error) {
+	if t := registry[w.Name()].Template; t != nil {
+		if tt := t.Lookup(name); tt != nil {
+			b := new(strings.Builder)
+			err := tt.Execute(b, data)
+			return b.String(), err
+		}
 	}
-	panic(fmt.Errorf("unknown widget: %q", name))
+	return ztpl.ExecuteString(name, data)
 }
 
 func (w *TotalCount) GetData(ctx context.Context, a Args) (err error) {
-	w.TotalCount, err = goatcounter.GetTotalCount(ctx, a.Start, a.End, a.PathFilter)
+	w.TotalCount, err = goatcounter.GetTotalCount(ctx, a.Start, a.End, a.Filter)
 	return err
 }
 
 func (w *Pages) GetData(ctx context.Context, a Args) (err error) {
 	w.Display, w.UniqueDisplay, w.More, err = w.Pages.List(
-		ctx, a.Start, a.End, a.PathFilter, nil, a.Daily)
-	return err
+		ctx, a.Start, a.End, a.Filter, nil, a.Limit, a.Daily)
+	if err != nil {
+		return err
+	}
+	err = w.Visitors.ListPaths(ctx, a.Start, a.End, a.Filter, nil, a.Limit)
+	if err != nil {
+		return err
+	}
+	return w.Annotations.ListRange(ctx, a.Start, a.End)
 }
 func (w *Max) GetData(ctx context.Context, a Args) (err error) {
-	w.Max, err = goatcounter.GetMax(ctx, a.Start, a.End, a.PathFilter, a.Daily)
+	w.Max, err = goatcounter.GetMax(ctx, a.Start, a.End, a.Filter, a.Daily)
 	return err
 }
 func (w *TotalPages) GetData(ctx context.Context, a Args) (err error) {
-	w.Max, err = w.Total.Totals(ctx, a.Start, a.End, a.PathFilter, a.Daily)
-	return err
+	w.daily = a.Daily
+	w.Max, err = w.Total.Totals(ctx, a.Start, a.End, a.Filter, a.Daily)
+	if err != nil {
+		return err
+	}
+	return w.Annotations.ListRange(ctx, a.Start, a.End)
 }
 func (w *Refs) GetData(ctx context.Context, a Args) (err error) {
-	return w.Refs.ListRefsByPath(ctx, a.ShowRefs, a.Start, a.End, 0)
+	return w.Refs.ListRefsByPath(ctx, a.ShowRefs, a.Start, a.End, a.Filter, 0)
 }
 func (w *TopRefs) GetData(ctx context.Context, a Args) (err error) {
-	return w.TopRefs.ListTopRefs(ctx, a.Start, a.End, a.PathFilter, 0)
+	return w.TopRefs.ListTopRefs(ctx, a.Start, a.End, a.Filter, a.limit(6), 0)
 }
 func (w *Browsers) GetData(ctx context.Context, a Args) (err error) {
-	return w.Browsers.ListBrowsers(ctx, a.Start, a.End, a.PathFilter, 6, 0)
+	return w.Browsers.ListBrowsers(ctx, a.Start, a.End, a.Filter, a.limit(6), 0)
 }
 func (w *Systems) GetData(ctx context.Context, a Args) (err error) {
-	return w.Systems.ListSystems(ctx, a.Start, a.End, a.PathFilter, 6, 0)
+	return w.Systems.ListSystems(ctx, a.Start, a.End, a.Filter, a.limit(6), 0)
 }
 func (w *Sizes) GetData(ctx context.Context, a Args) (err error) {
-	return w.SizeStat.ListSizes(ctx, a.Start, a.End, a.PathFilter)
+	return w.SizeStat.ListSizes(ctx, a.Start, a.End, a.Filter)
 }
 func (w *Locations) GetData(ctx context.Context, a Args) (err error) {
-	return w.LocStat.ListLocations(ctx, a.Start, a.End, a.PathFilter, 6, 0)
+	return w.LocStat.ListLocations(ctx, a.Start, a.End, a.Filter, a.limit(6), 0)
+}
+func (w *Heatmap) GetData(ctx context.Context, a Args) (err error) {
+	w.Heatmap, err = goatcounter.GetHeatmap(ctx, a.Start, a.End, a.Filter)
+	return err
+}
+func (w *Visitors) GetData(ctx context.Context, a Args) (err error) {
+	return w.Visitors.List(ctx, a.Start, a.End, a.Filter)
+}
+func (w *Channels) GetData(ctx context.Context, a Args) (err error) {
+	return w.Channels.ListChannels(ctx, a.Start, a.End, a.Filter)
+}
+func (w *WorldMap) GetData(ctx context.Context, a Args) (err error) {
+	return w.LocStat.ListLocations(ctx, a.Start, a.End, a.Filter, len(countryShapes), 0)
 }
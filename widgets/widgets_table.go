@@ -0,0 +1,93 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"zgo.at/goatcounter"
+)
+
+// Tabler is implemented by widgets that can be downloaded as a table, such as
+// CSV.
+type Tabler interface {
+	// Table gets the header and rows from the data loaded with GetData().
+	Table() (header []string, rows [][]string)
+}
+
+// statsTable converts HitStats to a table.
+func statsTable(col string, s goatcounter.HitStats) ([]string, [][]string) {
+	rows := make([][]string, 0, len(s.Stats))
+	for _, st := range s.Stats {
+		rows = append(rows, []string{st.Name, strconv.Itoa(st.CountUnique), strconv.Itoa(st.Count)})
+	}
+	return []string{col, "Visitors", "Pageviews"}, rows
+}
+
+// locationTable is like statsTable, but also adds the ID as the location code.
+func locationTable(s goatcounter.HitStats) ([]string, [][]string) {
+	rows := make([][]string, 0, len(s.Stats))
+	for _, st := range s.Stats {
+		rows = append(rows, []string{st.ID, st.Name, strconv.Itoa(st.CountUnique), strconv.Itoa(st.Count)})
+	}
+	return []string{"Code", "Location", "Visitors", "Pageviews"}, rows
+}
+
+func (w Pages) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(w.Pages))
+	for _, p := range w.Pages {
+		rows = append(rows, []string{p.Path, p.Title, strconv.FormatBool(bool(p.Event)),
+			strconv.Itoa(p.CountUnique), strconv.Itoa(p.Count)})
+	}
+	return []string{"Path", "Title", "Event", "Visitors", "Pageviews"}, rows
+}
+
+func (w TotalPages) Table() ([]string, [][]string) {
+	if w.daily {
+		rows := make([][]string, 0, len(w.Total.Stats))
+		for _, st := range w.Total.Stats {
+			rows = append(rows, []string{st.Day, strconv.Itoa(st.DailyUnique), strconv.Itoa(st.Daily)})
+		}
+		return []string{"Date", "Visitors", "Pageviews"}, rows
+	}
+
+	rows := make([][]string, 0, len(w.Total.Stats)*24)
+	for _, st := range w.Total.Stats {
+		for h := range st.Hourly {
+			rows = append(rows, []string{fmt.Sprintf("%s %02d:00", st.Day, h),
+				strconv.Itoa(st.HourlyUnique[h]), strconv.Itoa(st.Hourly[h])})
+		}
+	}
+	return []string{"Hour", "Visitors", "Pageviews"}, rows
+}
+
+func (w Heatmap) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, 7*24)
+	for d := range w.Heatmap.Total {
+		for h := range w.Heatmap.Total[d] {
+			rows = append(rows, []string{time.Weekday(d).String(), strconv.Itoa(h),
+				strconv.Itoa(w.Heatmap.TotalUnique[d][h]), strconv.Itoa(w.Heatmap.Total[d][h])})
+		}
+	}
+	return []string{"Day", "Hour", "Visitors", "Pageviews"}, rows
+}
+
+func (w Visitors) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(w.Visitors))
+	for _, v := range w.Visitors {
+		rows = append(rows, []string{v.Day, strconv.Itoa(v.New), strconv.Itoa(v.Returning)})
+	}
+	return []string{"Date", "New", "Returning"}, rows
+}
+
+func (w TopRefs) Table() ([]string, [][]string)   { return statsTable("Referrer", w.TopRefs) }
+func (w Browsers) Table() ([]string, [][]string)  { return statsTable("Browser", w.Browsers) }
+func (w Systems) Table() ([]string, [][]string)   { return statsTable("System", w.Systems) }
+func (w Sizes) Table() ([]string, [][]string)     { return statsTable("Size", w.SizeStat) }
+func (w Channels) Table() ([]string, [][]string)  { return statsTable("Channel", w.Channels) }
+func (w Locations) Table() ([]string, [][]string) { return locationTable(w.LocStat) }
+func (w WorldMap) Table() ([]string, [][]string)  { return locationTable(w.LocStat) }
@@ -0,0 +1,90 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package widgets_test
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"zgo.at/goatcounter"
+	"zgo.at/goatcounter/widgets"
+)
+
+type testWidget struct {
+	err  error
+	html template.HTML
+	Data string
+}
+
+func (w *testWidget) GetData(ctx context.Context, a widgets.Args) error {
+	w.Data = "some data"
+	return nil
+}
+func (w testWidget) RenderHTML(ctx context.Context, shared widgets.SharedData) (string, interface{}) {
+	return "test.gohtml", w.Data
+}
+func (w *testWidget) SetHTML(h template.HTML) { w.html = h }
+func (w testWidget) HTML() template.HTML      { return w.html }
+func (w *testWidget) SetErr(h error)          { w.err = h }
+func (w testWidget) Err() error               { return w.err }
+func (w testWidget) Name() string             { return "test" }
+func (w testWidget) Type() string             { return "full-width" }
+func (w testWidget) Label() string            { return "Test widget" }
+
+func TestRegister(t *testing.T) {
+	widgets.Register(widgets.Registration{
+		New: func() widgets.Widget { return &testWidget{} },
+		On:  true,
+		Settings: goatcounter.WidgetSettings{
+			"size": goatcounter.WidgetSetting{Type: "number", Label: "Size", Value: float64(5)},
+		},
+		Template: template.Must(template.New("test.gohtml").Parse(`<p>{{.}}</p>`)),
+	})
+
+	// New sites.
+	var ss goatcounter.SiteSettings
+	ss.Defaults()
+	if !ss.Widgets.On("test") {
+		t.Fatalf("not on for new sites: %v", ss.Widgets)
+	}
+	if v := ss.Widgets.GetSettings("test")["size"].Value; v != float64(5) {
+		t.Errorf("wrong setting: %#v", v)
+	}
+
+	// Existing sites.
+	ss2 := goatcounter.SiteSettings{Widgets: goatcounter.Widgets{{"name": "pages", "on": true}}}
+	ss2.Defaults()
+	if ss2.Widgets.Get("test") == nil || ss2.Widgets.On("test") {
+		t.Fatalf("not added as disabled for existing sites: %v", ss2.Widgets)
+	}
+
+	l := widgets.FromSiteWidgets(ss.Widgets, widgets.FilterInternal)
+	w := l.Get("test")
+	if w == nil {
+		t.Fatalf("not in list: %v", l)
+	}
+	err := w.GetData(context.Background(), widgets.Args{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, data := w.RenderHTML(context.Background(), widgets.SharedData{})
+	out, err := widgets.ExecuteTemplate(w, name, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "<p>some data</p>" {
+		t.Errorf("wrong output: %q", out)
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("no panic")
+			}
+		}()
+		widgets.Register(widgets.Registration{New: func() widgets.Widget { return &testWidget{} }})
+	})
+}
@@ -6,6 +6,9 @@ package widgets
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"zgo.at/goatcounter"
@@ -63,13 +66,15 @@ func (w Pages) RenderHTML(ctx context.Context, shared SharedData) (string, inter
 		TotalEventsUnique int
 		MorePages         bool
 
-		Refs     goatcounter.HitStats
-		ShowRefs string
+		Refs        goatcounter.HitStats
+		ShowRefs    string
+		Annotations goatcounter.Annotations
+		Visitors    goatcounter.VisitorStats
 	}{
 		ctx, w.err, w.Pages, shared.Site, shared.Args.Start, shared.Args.End, shared.Args.Daily,
 		shared.Args.ForcedDaily, 1, w.Max, w.Display,
 		w.UniqueDisplay, shared.Total, shared.TotalUnique, shared.TotalEvents, shared.TotalEventsUnique,
-		w.More, w.Refs, shared.Args.ShowRefs,
+		w.More, w.Refs, shared.Args.ShowRefs, w.Annotations, w.Visitors,
 	}
 }
 
@@ -89,8 +94,9 @@ func (w TotalPages) RenderHTML(ctx context.Context, shared SharedData) (string,
 		TotalUnique       int
 		TotalEvents       int
 		TotalEventsUnique int
+		Annotations       goatcounter.Annotations
 	}{ctx, w.err, shared.Site, w.Total, shared.Args.Daily, w.Max, shared.Total,
-		shared.TotalUnique, shared.TotalEvents, shared.TotalEventsUnique}
+		shared.TotalUnique, shared.TotalEvents, shared.TotalEventsUnique, w.Annotations}
 }
 
 func (w TopRefs) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
@@ -103,6 +109,61 @@ func (w TopRefs) RenderHTML(ctx context.Context, shared SharedData) (string, int
 	}{ctx, w.err, isCol(ctx, goatcounter.CollectReferrer), shared.TotalUnique, w.TopRefs}
 }
 
+func (w Channels) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
+	return "_dashboard_channels.gohtml", struct {
+		Context     context.Context
+		Err         error
+		IsCollected bool
+		TotalUnique int
+		Stats       goatcounter.HitStats
+	}{ctx, w.err, isCol(ctx, goatcounter.CollectReferrer), shared.TotalUnique, w.Channels}
+}
+
+type worldMapCountry struct {
+	Code, Name string
+	Path       string
+	Count      int
+	Alpha      float64
+}
+
+func (w WorldMap) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
+	var (
+		stats = make(map[string]goatcounter.HitStat, len(w.LocStat.Stats))
+		max   int
+	)
+	for _, s := range w.LocStat.Stats {
+		stats[s.ID] = s
+		if s.CountUnique > max {
+			max = s.CountUnique
+		}
+	}
+
+	// Draw all countries, with the fill opacity scaled by the number of
+	// visitors.
+	countries := make([]worldMapCountry, 0, len(countryShapes))
+	for code, path := range countryShapes {
+		c := worldMapCountry{Code: code, Path: path}
+		if s, ok := stats[code]; ok && s.CountUnique > 0 {
+			c.Name, c.Count, c.Alpha = s.Name, s.CountUnique, 1
+			if max > 0 {
+				c.Alpha = math.Round((.2+.8*float64(s.CountUnique)/float64(max))*100) / 100
+			}
+		}
+		countries = append(countries, c)
+	}
+	sort.Slice(countries, func(i, j int) bool { return countries[i].Code < countries[j].Code })
+
+	return "_dashboard_worldmap.gohtml", struct {
+		Context     context.Context
+		Err         error
+		Site        *goatcounter.Site
+		IsCollected bool
+		Regions     bool
+		Countries   []worldMapCountry
+	}{ctx, w.err, shared.Site, isCol(ctx, goatcounter.CollectLocation),
+		isCol(ctx, goatcounter.CollectLocationRegion), countries}
+}
+
 func (w Browsers) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
 	return "_dashboard_browsers.gohtml", struct {
 		Context        context.Context
@@ -142,3 +203,62 @@ func (w Locations) RenderHTML(ctx context.Context, shared SharedData) (string, i
 		Stats          goatcounter.HitStats
 	}{ctx, w.err, isCol(ctx, goatcounter.CollectLocation), shared.TotalUniqueUTC, w.LocStat}
 }
+
+type (
+	heatmapRow struct {
+		Day   string
+		Cells []heatmapCell
+	}
+	heatmapCell struct {
+		Title string
+		Alpha float64
+	}
+)
+
+func (w Heatmap) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
+	days := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+		time.Friday, time.Saturday, time.Sunday}
+	if shared.Site.Settings.SundayStartsWeek {
+		days = append([]time.Weekday{time.Sunday}, days[:6]...)
+	}
+
+	hours := make([]string, 24)
+	for h := 0; h < 24; h += 3 {
+		if shared.Site.Settings.TwentyFourHours {
+			hours[h] = fmt.Sprintf("%02d", h)
+		} else {
+			hours[h] = time.Date(2000, 1, 1, h, 0, 0, 0, time.UTC).Format("3pm")
+		}
+	}
+
+	rows := make([]heatmapRow, 0, 7)
+	for _, d := range days {
+		row := heatmapRow{Day: d.String()[:3], Cells: make([]heatmapCell, 24)}
+		for h := range row.Cells {
+			c := heatmapCell{Title: fmt.Sprintf("%s %02d:00 – %02d:59, %d visits; %d pageviews",
+				d, h, h, w.Heatmap.TotalUnique[d][h], w.Heatmap.Total[d][h])}
+			if w.Heatmap.MaxUnique > 0 {
+				c.Alpha = float64(w.Heatmap.TotalUnique[d][h]) / float64(w.Heatmap.MaxUnique)
+			}
+			row.Cells[h] = c
+		}
+		rows = append(rows, row)
+	}
+
+	return "_dashboard_heatmap.gohtml", struct {
+		Context context.Context
+		Err     error
+		Hours   []string
+		Rows    []heatmapRow
+	}{ctx, w.err, hours, rows}
+}
+
+func (w Visitors) RenderHTML(ctx context.Context, shared SharedData) (string, interface{}) {
+	return "_dashboard_visitors.gohtml", struct {
+		Context context.Context
+		Err     error
+		Site    *goatcounter.Site
+		Stats   goatcounter.VisitorStats
+		Totals  goatcounter.VisitorStat
+	}{ctx, w.err, shared.Site, w.Visitors, w.Visitors.Totals()}
+}
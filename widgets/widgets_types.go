@@ -37,12 +37,16 @@ type (
 		Pages                  goatcounter.HitLists
 		Refs                   goatcounter.HitStats
 		Max                    int
+		Annotations            goatcounter.Annotations
+		Visitors               goatcounter.VisitorStats
 	}
 	TotalPages struct {
-		err   error
-		html  template.HTML
-		Max   int
-		Total goatcounter.HitList
+		err         error
+		html        template.HTML
+		daily       bool
+		Max         int
+		Total       goatcounter.HitList
+		Annotations goatcounter.Annotations
 	}
 	Refs struct {
 		err  error
@@ -74,6 +78,26 @@ type (
 		html    template.HTML
 		LocStat goatcounter.HitStats
 	}
+	Heatmap struct {
+		err     error
+		html    template.HTML
+		Heatmap goatcounter.Heatmap
+	}
+	Visitors struct {
+		err      error
+		html     template.HTML
+		Visitors goatcounter.VisitorStats
+	}
+	Channels struct {
+		err      error
+		html     template.HTML
+		Channels goatcounter.HitStats
+	}
+	WorldMap struct {
+		err     error
+		html    template.HTML
+		LocStat goatcounter.HitStats
+	}
 )
 
 func (w Max) Name() string        { return "max" }
@@ -86,6 +110,10 @@ func (w Browsers) Name() string   { return "browsers" }
 func (w Systems) Name() string    { return "systems" }
 func (w Sizes) Name() string      { return "sizes" }
 func (w Locations) Name() string  { return "locations" }
+func (w Heatmap) Name() string    { return "heatmap" }
+func (w Visitors) Name() string   { return "visitors" }
+func (w Channels) Name() string   { return "channels" }
+func (w WorldMap) Name() string   { return "worldmap" }
 
 func (w Max) Type() string        { return "data-only" }
 func (w Refs) Type() string       { return "data-only" }
@@ -97,6 +125,10 @@ func (w Browsers) Type() string   { return "hchart" }
 func (w Systems) Type() string    { return "hchart" }
 func (w Sizes) Type() string      { return "hchart" }
 func (w Locations) Type() string  { return "hchart" }
+func (w Heatmap) Type() string    { return "full-width" }
+func (w Visitors) Type() string   { return "full-width" }
+func (w Channels) Type() string   { return "hchart" }
+func (w WorldMap) Type() string   { return "full-width" }
 
 func (w Max) Label() string        { return "" }
 func (w Refs) Label() string       { return "" }
@@ -108,6 +140,10 @@ func (w Browsers) Label() string   { return "Browser stats" }
 func (w Systems) Label() string    { return "System stats" }
 func (w Sizes) Label() string      { return "Size stats" }
 func (w Locations) Label() string  { return "Location stats" }
+func (w Heatmap) Label() string    { return "Traffic heatmap" }
+func (w Visitors) Label() string   { return "New and returning visitors" }
+func (w Channels) Label() string   { return "Referrer channels" }
+func (w WorldMap) Label() string   { return "World map" }
 
 func (w *Max) SetHTML(h template.HTML)        {}
 func (w *Refs) SetHTML(h template.HTML)       {}
@@ -119,6 +155,10 @@ func (w *Browsers) SetHTML(h template.HTML)   { w.html = h }
 func (w *Systems) SetHTML(h template.HTML)    { w.html = h }
 func (w *Sizes) SetHTML(h template.HTML)      { w.html = h }
 func (w *Locations) SetHTML(h template.HTML)  { w.html = h }
+func (w *Heatmap) SetHTML(h template.HTML)    { w.html = h }
+func (w *Visitors) SetHTML(h template.HTML)   { w.html = h }
+func (w *Channels) SetHTML(h template.HTML)   { w.html = h }
+func (w *WorldMap) SetHTML(h template.HTML)   { w.html = h }
 
 func (w Max) HTML() template.HTML        { return w.html }
 func (w Refs) HTML() template.HTML       { return w.html }
@@ -130,6 +170,10 @@ func (w Browsers) HTML() template.HTML   { return w.html }
 func (w Systems) HTML() template.HTML    { return w.html }
 func (w Sizes) HTML() template.HTML      { return w.html }
 func (w Locations) HTML() template.HTML  { return w.html }
+func (w Heatmap) HTML() template.HTML    { return w.html }
+func (w Visitors) HTML() template.HTML   { return w.html }
+func (w Channels) HTML() template.HTML   { return w.html }
+func (w WorldMap) HTML() template.HTML   { return w.html }
 
 func (w *Max) SetErr(h error)        { w.err = h }
 func (w *Refs) SetErr(h error)       { w.err = h }
@@ -141,6 +185,10 @@ func (w *Browsers) SetErr(h error)   { w.err = h }
 func (w *Systems) SetErr(h error)    { w.err = h }
 func (w *Sizes) SetErr(h error)      { w.err = h }
 func (w *Locations) SetErr(h error)  { w.err = h }
+func (w *Heatmap) SetErr(h error)    { w.err = h }
+func (w *Visitors) SetErr(h error)   { w.err = h }
+func (w *Channels) SetErr(h error)   { w.err = h }
+func (w *WorldMap) SetErr(h error)   { w.err = h }
 
 func (w Max) Err() error        { return w.err }
 func (w Refs) Err() error       { return w.err }
@@ -152,3 +200,7 @@ func (w Browsers) Err() error   { return w.err }
 func (w Systems) Err() error    { return w.err }
 func (w Sizes) Err() error      { return w.err }
 func (w Locations) Err() error  { return w.err }
+func (w Heatmap) Err() error    { return w.err }
+func (w Visitors) Err() error   { return w.err }
+func (w Channels) Err() error   { return w.err }
+func (w WorldMap) Err() error   { return w.err }
@@ -0,0 +1,188 @@
+// Copyright © 2019 Martin Tournoij – This file is part of GoatCounter and
+// published under the terms of a slightly modified EUPL v1.2 license, which can
+// be found in the LICENSE file or at https://license.goatcounter.com
+
+package widgets
+
+// Outline of every country as SVG path data, for the world map.
+//
+// This is from the public domain Natural Earth 1:110m countries
+// (https://www.naturalearthdata.com), in an equirectangular projection with 1
+// unit per degree and rounded to 0.1 degree. Antarctica is omitted, and some
+// small countries aren't included at this scale.
+var countryShapes = map[string]string{
+	"AE": "M231.6,65.8 231.8,65.7 231.8,66 232.6,65.8 233.4,65.8 234,65.9 234.7,65.2 235.4,64.6 236.1,63.9 236.3,64.3 236.4,65.1 235.9,65.1 235.8,65.7 236,65.9 235.5,66.1 235.5,66.5 235.2,66.9 235.2,67.3 235,67.5 232,67 231.6,66Z",
+	"AF": "M241.2,54.3 242.2,54.7 243,54.6 243.2,54.1 244,54 244.5,53.7 244.7,52.9 245.6,52.7 245.7,52.3 246.2,52.6 246.5,52.6 247.1,52.6 247.8,52.9 248.1,53 248.9,52.7 249.2,52.8 249.5,52.4 250.1,52.4 250.3,52.3 250.4,51.9 250.8,51.5 251.3,51.7 251.2,52 251.5,52.1 251.4,52.9 251.8,53.3 252.2,53.1 252.6,53 253.3,52.5 253.9,52.6 255,52.6 255.2,52.9 254.6,53 254.1,53.2 252.9,53.3 251.8,53.5 251.3,53.9 251.5,54.3 251.6,54.8 251.1,55.3 251.2,55.7 250.9,56 249.9,56 250.3,56.6 249.7,56.9 249.3,57.5 249.3,58.1 248.9,58.4 248.6,58.3 247.8,58.4 247.7,58.7 246.9,58.7 246.4,59.3 246.3,60.1 245,60.5 244.4,60.4 244.1,60.7 243.6,60.5 242.5,60.7 240.9,60.2 241.8,59.3 241.7,58.6 240.9,58.5 240.9,57.8 240.5,57 241,56.5 240.5,56.3 240.8,55.6Z",
+	"AL": "M200.6,48.1 200.5,48.5 200.6,48.9 201,49.2 201,49.4 200.7,49.6 200.6,49.9 200.2,50.4 200,50.3 200,50.1 199.4,49.7 199.3,49.3 199.4,48.6 199.5,48.3 199.4,48.1 199.3,47.8 199.7,47.3 199.8,47.5 200.1,47.4 200.3,47.7 200.5,47.8Z",
+	"AM": "M223.6,48.9 225,48.8 225.2,49 225.6,49.2 225.4,49.4 225.9,49.8 225.6,50.1 226,50.4 226.5,50.5 226.5,51.2 226.1,51.3 225.7,50.7 225.7,50.5 225.3,50.5 225,50.3 224.8,50.3 224.4,50 223.7,49.7 223.8,49.3Z",
+	"AO": "M196.3,95.9 196.6,96.6 196.9,97.2 197.1,97.5 197.5,98.1 198.1,98 198.5,97.8 199,98 199.2,97.7 199.4,97.2 200,97.1 200.1,96.9 200.6,96.9 200.5,97.3 201.7,97.3 201.7,97.9 201.9,98.3 201.8,98.9 201.9,99.5 202.2,99.9 202.2,101.1 202.4,101 202.8,101 203.5,100.9 203.9,100.9 204,101.2 203.9,101.7 204.1,102.2 203.9,102.6 204,102.9 201.9,102.9 201.9,106.1 202.6,106.9 203.2,107.5 201.4,107.9 199,107.8 198.3,107.3 194.2,107.4 194.1,107.4 193.5,107 192.8,106.9 192.2,107.1 191.7,107.3 191.6,106.7 191.8,105.8 192.1,104.9 192.2,104.4 192.5,103.5 192.7,103.1 193.3,102.5 193.6,102 193.7,101.3 193.7,100.7 193.4,100.4 193.1,99.8 192.9,99.2 192.9,99 193.2,98.6 192.9,97.6 192.7,96.9 192.2,96.3 192.3,96.1 192.7,96 193,96 193.4,95.9ZM192.4,95.7 192.2,95.8 191.9,95 192.3,94.6 192.6,94.4 193,94.8 192.6,95 192.5,95.2Z",
+	"AR": "M114.5,145.2 113.5,145.2 113,144.9 112.4,144.9 111.4,144.9 111.4,142.6 111.8,143.1 112.2,143.8 113.5,144.4 115,144.7ZM115,112.1 115.6,112.8 116,112 117.2,112 117.3,112.2 119.2,113.9 120,114 121.2,114.8 122.2,115.2 122.4,115.6 121.4,117.1 122.4,117.4 123.5,117.5 124.3,117.4 125.2,116.6 125.4,115.7 125.9,115.5 126.4,116.1 126.4,116.9 125.5,117.5 124.8,117.9 123.7,118.9 122.4,120.2 122.1,121 121.9,122 121.9,123 121.7,123.3 121.6,123.9 121.5,124.4 122.8,125.3 122.6,126 123.3,126.4 123.2,126.9 122.3,128.2 120.8,128.7 118.8,128.9 117.7,128.8 117.9,129.4 117.7,130.2 117.9,130.7 117.3,131 116.2,131.2 115.3,130.8 114.9,131.1 115,132.1 115.7,132.4 116.2,132 116.5,132.6 115.6,132.9 114.8,133.5 114.7,134.5 114.4,135 113.5,135 112.7,135.6 112.4,136.3 113.4,137 114.4,137.2 114,138.1 112.8,138.7 112.2,139.9 111.3,140.3 110.9,140.7 111.2,141.8 111.9,142.3 111.4,142.3 110.5,142.1 108.1,142 107.7,141.4 107.7,140.7 107,140.7 106.7,140.4 106.6,139.3 107.4,138.9 107.7,138.2 107.6,137.7 108.1,136.9 108.4,135.6 108.3,135 108.8,134.8 108.7,134.4 108.2,134.2 108.5,133.8 108.1,133.4 107.9,132.3 108.3,132.1 108.1,130.8 108.3,129.8 108.6,128.9 109.2,128.6 108.9,127.6 108.9,126.7 109.6,126 109.6,125.2 110.2,124.2 110.2,123.3 109.9,123.1 109.5,121.4 110.1,120.3 110,119.4 110.3,118.5 111,117.5 111.7,116.9 111.4,116.5 111.6,116.2 111.6,114.5 112.7,114 113,113 112.9,112.7 113.7,111.8Z",
+	"AT": "M197,41.9 196.9,42.3 196.3,42.3 196.5,42.5 196.2,43.1 196,43.3 195.1,43.3 194.6,43.6 193.8,43.5 192.4,43.2 192.2,42.9 191.2,43.1 191,43.2 190.4,43.1 189.9,43.1 189.5,42.9 189.6,42.7 189.6,42.5 189.9,42.4 190.4,42.7 190.5,42.4 191.4,42.5 192.1,42.3 192.6,42.3 192.9,42.5 193,42.4 192.9,41.7 193.2,41.6 193.6,41.1 194.3,41.4 194.9,41 195.3,41 196,41.3 196.5,41.2 197,41.4 196.9,41.5Z",
+	"AU": "M325.4,130.8 326.4,131.1 326.9,131 327.7,130.8 328.3,130.9 328.4,132.1 328,132.4 327.9,133.2 327.6,132.9 326.9,133.6 326.7,133.6 326,133.5 325.4,132.7 325.3,132 324.7,131.2 324.7,130.7ZM323.6,103.8 323.9,104.5 324.6,104.2 324.9,104.6 325.4,105 325.3,105.4 325.5,106.3 325.6,106.8 325.9,106.9 326.2,107.8 326.1,108.3 326.4,109 327.5,109.5 328.2,110 328.8,110.4 328.7,110.6 329.3,111.3 329.7,112.3 330.1,112.1 330.5,112.6 330.7,112.4 330.9,113.5 331.6,114.1 332.1,114.5 332.9,115.3 333.1,116.1 333.2,116.6 333.1,117.3 333.6,118.1 333.5,119 333.3,119.5 333.1,120.4 333.1,120.9 332.9,121.6 332.5,122.6 331.7,123 331.3,123.8 331,124.3 330.7,125.2 330.3,125.7 330.1,126.4 329.9,127.1 330,127.4 329.4,127.8 328.3,127.8 327.4,128.2 326.9,128.6 326.3,129 325.5,128.6 324.9,128.4 325,127.9 324.5,128.1 323.6,128.8 322.7,128.5 322.2,128.4 321.6,128.3 320.6,128 320,127.4 319.8,126.6 319.6,126.1 319.1,125.7 318.1,125.6 318.4,125.1 318.2,124.4 317.7,125.1 316.8,125.3 317.4,124.7 317.5,124.1 317.9,123.6 317.8,122.9 317,123.8 316.4,124.1 316,124.9 315.2,124.5 315.2,123.9 314.6,123.2 314.1,122.8 314.3,122.6 313,122 312.3,122 311.3,121.5 309.5,121.6 308.2,121.9 307.1,122.3 306.1,122.2 305.1,122.7 304.2,123 304,123.5 303.7,123.9 302.8,123.9 302.2,124 301.3,123.8 300.6,123.9 299.9,124 299.3,124.5 299,124.5 298.5,124.7 298,125.1 297.3,125 296.6,125 295.6,124.4 295,124.2 295,123.6 295.5,123.5 295.7,123.3 295.7,122.9 295.8,122.2 295.7,121.6 295.2,120.6 295,120 295,119.5 294.6,118.8 294.6,118.5 294.2,118.1 294,117.3 293.5,116.5 293.3,116.1 293.8,116.5 293.4,115.6 293.9,115.9 294.2,116.3 294.2,115.8 293.7,115 293.6,114.7 293.4,114.4 293.5,113.8 293.7,113.6 293.8,113.1 293.7,112.5 294.1,111.8 294.2,112.5 294.6,111.8 295.5,111.5 295.9,111.1 296.7,110.7 297.2,110.6 297.4,110.7 298.2,110.4 298.8,110.3 299,110 299.3,110 299.8,110 300.9,109.7 301.4,109.2 301.7,108.7 302.2,108.2 302.3,107.8 302.3,107.3 303,106.4 303.4,107.3 303.9,107.1 303.5,106.6 303.8,106.1 304.3,106.3 304.4,105.6 304.9,105.1 305.2,104.7 305.7,104.5 305.7,104.2 306.1,104.3 306.1,104.1 306.6,104 307.1,103.8 307.8,104.3 308.4,104.9 309,104.9 309.6,105 309.4,104.4 309.9,103.6 310.3,103.4 310.2,103.1 310.6,102.5 311.2,102.2 311.7,102.3 312.6,102.1 312.6,101.6 311.8,101.3 312.4,101.1 313,101.4 313.6,101.8 314.4,102 314.7,101.9 315.3,102.2 315.9,102 316.3,102 316.5,101.9 317,102.4 316.7,102.9 316.3,103.3 316,103.3 316.1,103.7 315.8,104.2 315.4,104.7 315.5,105 316.3,105.6 317.1,105.9 317.6,106.2 318.3,106.8 318.6,106.8 319.1,107.1 319.3,107.4 320.2,107.7 320.9,107.4 321.1,106.8 321.3,106.4 321.4,105.8 321.7,105 321.6,104.6 321.6,104.3 321.5,103.7 321.7,102.9 321.8,102.7 321.7,102.4 321.9,101.9 322.1,101.3 322.1,101 322.5,100.7 322.8,101.2 322.9,101.8 323.1,101.9 323.2,102.3 323.5,102.8 323.6,103.4Z",
+	"AZ": "M225,50.3 225.3,50.5 225.7,50.5 225.7,50.7 226.1,51.3 225.5,51.1 225,50.7 224.8,50.3ZM227.4,48.8 227.8,48.8 228,48.6 228.6,48.2 229.1,48.7 229.6,49.4 230.1,49.5 230.4,49.7 229.6,49.8 229.4,50.6 229.2,51 228.9,51.2 228.9,51.7 228.6,51.7 228,51.2 228.4,50.7 228.1,50.4 227.7,50.5 226.5,51.2 226.5,50.5 226,50.4 225.6,50.1 225.9,49.8 225.4,49.4 225.6,49.2 225.2,49 225,48.8 225.2,48.6 226,48.9 226.5,48.9 226.6,48.8 226.1,48.3 226.4,48.1 226.7,48.2Z",
+	"BA": "M199,45.1 199.4,45.1 199.1,45.6 199.6,46 199.5,46.4 199.2,46.5 199,46.6 198.7,46.8 198.6,47.4 197.7,47 197.3,46.6 196.9,46.3 196.5,46 196.2,45.6 195.8,45.2 196,44.8 196.3,45 196.5,44.8 197,44.8 197.9,44.9 198.6,44.9Z",
+	"BD": "M272.7,68 272.7,68.7 272.3,68.5 272.4,69.3 272.1,68.8 272,68.3 271.8,67.8 271.4,67.2 270.5,67.2 270.6,67.6 270.3,68.2 269.8,68 269.7,68.1 269.4,68 269,67.9 268.9,67.1 268.5,66.4 268.7,65.8 268.1,65.5 268.3,65.1 268.9,64.8 268.2,64.2 268.6,63.6 269.4,64 269.8,64 269.9,64.7 270.9,64.9 271.8,64.9 272.4,65 271.9,65.9 271.5,65.9 271.2,66.5 271.7,67 271.9,66.4 272.1,66.4Z",
+	"BE": "M183.3,38.7 184,38.7 185,38.5 185.6,39 186.2,39.2 186,39.9 185.8,39.9 185.7,40.5 184.8,40 184.3,40.1 183.6,39.6 183.1,39.2 182.7,39.2 182.5,38.9Z",
+	"BF": "M177.2,80.4 176.5,80.1 176,80.1 175.7,80.4 175.2,80.2 175,79.8 174.6,79.6 174.5,79 174.8,78.6 174.8,78.3 175.6,77.5 175.7,76.8 176,76.5 176.5,76.7 176.9,76.5 177,76.2 177.8,75.8 178,75.4 178.9,75 179.5,74.9 179.7,75.1 180.4,75.1 180.3,75.6 180.4,76 181,76.7 181,77.1 182.2,77.4 182.2,78.1 181.9,78.4 181.4,78.5 181.2,78.9 180.9,79 180,79 179.6,78.9 179.2,79.1 178.8,79 177.1,79 177,79.6Z",
+	"BG": "M202.7,45.8 202.9,46.2 203.3,46.1 204.1,46.3 205.6,46.3 206.1,46.1 207.2,45.8 208,46.2 208.6,46.3 208,46.7 207.7,47.4 208,48 207.1,47.9 206.1,48.2 206.1,48.7 205.2,48.8 204.5,48.4 203.7,48.7 203,48.7 202.9,48 202.4,47.7 202.5,47.5 202.4,47.4 202.6,47.1 203,46.8 202.5,46.4 202.4,46Z",
+	"BI": "M209.3,94.5 209.3,93.3 209,92.8 209.6,92.9 209.9,92.3 210.5,92.4 210.5,92.8 210.7,93 210.8,93.4 210.5,93.6 210.1,94.1 209.8,94.5Z",
+	"BJ": "M182.7,83.7 181.9,83.9 181.6,83.2 181.7,80.9 181.5,80.7 181.4,80.2 181.1,79.8 180.8,79.5 180.9,79 181.2,78.9 181.4,78.5 181.9,78.4 182.2,78.1 182.5,77.8 182.8,77.8 183.6,78.3 183.6,78.7 183.8,79.3 183.6,79.7 183.7,79.9 183.2,80.6 182.9,80.9 182.7,81.5 182.7,82.1Z",
+	"BN": "M294.2,85.5 294.6,85.1 295.5,84.6 295.4,85 295.3,85.7 294.9,85.7 294.7,86Z",
+	"BO": "M117.2,112 116,112 115.6,112.8 115,112.1 113.7,111.8 112.9,112.7 112.2,112.9 111.8,111.5 111.2,110.4 111.6,109.4 111,109 110.9,108.3 110.4,107.6 111,106.5 110.6,105.7 110.8,105.3 110.7,105 111.1,104.5 111.1,103.6 111.1,102.9 111.3,102.6 110.5,101 111.2,101 111.7,101 112,100.7 112.8,100.3 113.4,99.9 114.7,99.8 114.6,100.5 114.7,100.9 114.6,101.6 115.7,102.5 116.8,102.6 117.2,103 117.9,103.2 118.3,103.5 118.9,103.5 119.5,103.8 119.5,104.4 119.7,104.6 119.7,105.1 119.5,105.1 119.8,106.3 121.8,106.3 121.6,106.9 121.7,107.3 122.3,107.6 122.5,108.2 122.3,109 122.1,109.4 122.1,110 121.8,110.2 121.8,109.9 120.9,109.4 120,109.3 118.2,109.6 117.7,110.5 117.7,111.1 117.3,112.2Z",
+	"BR": "M122.4,120.2 123.7,118.9 124.8,117.9 125.5,117.5 126.4,116.9 126.4,116.1 125.9,115.5 125.4,115.7 125.6,115.2 125.7,114.6 125.7,114 125.3,113.8 125,114 124.6,114 124.5,113.6 124.4,112.7 124.2,112.4 123.5,112.1 123.1,112.3 122.1,112.1 122.1,110.7 121.8,110.2 122.1,110 122.1,109.4 122.3,109 122.5,108.2 122.3,107.6 121.7,107.3 121.6,106.9 121.8,106.3 119.8,106.3 119.5,105.1 119.7,105.1 119.7,104.6 119.5,104.4 119.5,103.8 118.9,103.5 118.3,103.5 117.9,103.2 117.2,103 116.8,102.6 115.7,102.5 114.6,101.6 114.7,100.9 114.6,100.5 114.7,99.8 113.4,99.9 112.8,100.3 112,100.7 111.7,101 111.2,101 110.5,101 109.9,101.1 109.5,101 109.5,99.5 108.7,100.1 107.8,100.1 107.4,99.5 106.8,99.5 107,99 106.4,98.4 106,97.5 106.3,97.3 106.3,96.9 106.9,96.6 106.8,96.1 107,95.7 107.1,95.3 108.3,94.6 109.1,94.4 109.2,94.3 110.1,94.3 110.6,91.6 110.6,91.1 110.4,90.5 110,90.2 110,89.5 110.5,89.3 110.7,89.4 110.8,89 110.2,88.9 110.2,88.3 112.1,88.3 112.5,88 112.7,88.3 112.9,88.9 113.1,88.7 113.7,89.3 114.5,89.2 114.6,88.9 115.4,88.7 115.8,88.5 115.9,88.1 116.6,87.8 116.6,87.6 115.7,87.5 115.6,86.9 115.6,86.2 115.2,85.9 115.4,85.9 116.1,86 116.9,86.2 117.2,86 117.9,85.8 119,85.5 119.4,85.1 119.3,84.8 119.8,84.8 120,85 119.9,85.4 120.2,85.6 120.5,86 120.2,86.4 120,87.2 120.3,87.8 120.4,88.2 121,88.7 121.5,88.7 121.6,88.5 121.9,88.5 122.3,88.3 122.7,88.1 123.2,88.1 123.5,88.1 124,88.2 124.1,88 123.9,87.8 124,87.5 124.4,87.6 124.9,87.5 125.5,87.7 125.9,87.9 126.2,87.6 126.4,87.7 126.6,87.9 127.1,87.9 127.4,87.5 127.8,86.8 128.3,85.8 128.7,85.8 128.9,86.3 129.5,88.1 130,88.3 130.1,89 129.3,89.8 129.6,90.1 131.4,90.2 131.4,91.2 132.2,90.6 133.4,90.9 135.1,91.6 135.6,92.1 135.4,92.7 136.6,92.4 138.5,92.9 140,92.9 141.5,93.7 142.8,94.8 143.5,95.1 144.4,95.1 144.8,95.5 145.1,96.7 145.3,97.3 144.9,99 144.4,99.6 143,101 142.3,102.2 141.6,103 141.3,103.1 141,103.8 141.1,105.7 140.8,107.2 140.7,107.9 140.4,108.3 140.2,109.6 139.2,110.9 139.1,111.9 138.2,112.4 138,113 136.9,113 135.4,113.4 134.6,113.8 133.5,114.1 132.4,114.9 131.5,115.9 131.4,116.6 131.5,117.2 131.3,118.2 131.1,118.7 130.4,119.2 129.3,121 128.4,121.8 127.7,122.2 127.3,123.2 126.6,123.8 126.3,123.2 126.8,122.7 126.2,122 125.4,121.5 124.4,120.9 124,120.9 123,120.1Z",
+	"BS": "M102.5,66.2 102.2,66.3 102,65.7 101.6,65.4 101.8,64.8 102.1,64.8 102.5,65.7ZM102.2,63.4 101.1,63.6 101,63.2 101.5,63.1 102.2,63.2ZM103,63.4 102.8,64.1 102.6,64 102.7,63.5 102.2,63.1 102.2,63Z",
+	"BT": "M271.7,62.2 272.1,62.5 272,63.2 271.2,63.2 270.4,63.1 269.7,63.3 268.8,62.9 268.8,62.7 269.5,62 270,61.7 270.7,61.9 271.3,62Z",
+	"BW": "M205.6,108.5 205.9,108.7 206.2,109.3 207.3,110.4 207.7,110.5 207.7,110.9 208,111.5 208.8,111.6 209.4,112.1 208,112.8 207.1,113.6 206.8,114.2 206.5,114.6 205.9,114.7 205.8,115.2 205.7,115.5 205,115.7 204.2,115.7 203.7,115.4 203.3,115.3 202.8,115.5 202.6,116 202.1,116.3 201.6,116.7 200.9,116.8 200.7,116.5 200.8,115.9 200.2,114.9 199.9,114.8 199.9,111.8 200.9,111.8 200.9,108.3 201.7,108.2 203.2,107.9 203.6,108.3 204.2,107.9 204.5,107.9 205.1,107.7 205.3,107.7Z",
+	"BY": "M203.5,36.1 204.5,36.1 205.5,35.7 205.8,35.2 206.6,34.8 206.5,34.4 207.1,34.2 208.2,33.8 209.2,34.1 209.4,34.3 209.9,34.2 210.9,34.4 211,34.9 210.8,35.2 211.4,35.8 211.8,36 211.7,36.2 212.4,36.4 212.7,36.6 212.3,36.9 211.5,36.8 211.3,36.9 211.5,37.3 211.8,37.9 210.9,38 210.6,38.2 210.6,38.7 210.2,38.6 209.3,38.6 209,38.4 208.6,38.6 208.2,38.4 207.5,38.4 206.3,38.2 205.3,38.1 204.6,38.1 204,38.4 203.5,38.4 203.5,38 203.2,37.5 203.8,37.3 203.8,36.9 203.5,36.5Z",
+	"BZ": "M90.9,72.2 90.8,72 91,72 91.2,72.1 91.5,71.5 91.7,71.5 91.7,71.6 91.9,71.7 91.9,71.9 91.7,72.4 91.8,72.5 91.7,72.9 91.8,73 91.6,73.5 91.4,73.7 91.3,73.8 91.1,74.1 90.8,74.1 90.8,73Z",
+	"CA": "M116.3,43.4 117.1,43.6 118,43.6 117.5,44 117.1,44 115.9,43.6 115.6,43.3 116,43ZM118.2,40.9 117.7,40.9 116.4,40.6 115.5,40.1 115.8,40 117.1,40.3 118.2,40.7ZM56.5,41.5 56,41.6 54.3,41.2 54,40.8 53.1,40.5 53,40.2 51.9,40 51.6,39.5 51.6,39.2 52.7,39.4 53.3,39.6 54.2,39.7 54.6,40 55.1,40.5 56.1,40.9ZM123.9,39.3 123.2,40.2 123.9,39.8 124.5,40.1 124.2,40.4 125.1,40.7 125.5,40.4 126.5,40.8 126.2,41.5 126.9,41.3 127,41.8 127.4,42.5 126.9,43.3 126.5,43.4 125.8,43.2 126,42.4 125.8,42.2 124.6,43.1 124,43.1 124.7,42.6 123.7,42.4 122.7,42.4 120.7,42.4 120.6,42.1 121.2,41.7 120.8,41.5 121.6,40.9 122.6,39.3 123.3,38.7 124.1,38.4 124.6,38.4 124.4,38.7ZM46.8,35.8 47.3,36 48.3,35.9 48,37 48.8,37.8 48.4,37.8 47.8,37.4 47.5,36.9 46.9,36.6 46.8,36.1ZM100.7,27.8 100.3,28.4 99.9,28.3 99.6,28 99.7,27.9 100.1,27.6 100.5,27.6ZM98.1,27.3 96.9,27.8 96.2,27.8 96,27.5 96.7,27.1 98.1,27.1ZM94.8,24.3 95,24.8 95.5,24.6 96.1,24.9 97.2,25.2 98.4,25.5 98.4,26 99.2,25.9 99.9,26.3 99,26.6 97.5,26.3 96.9,25.9 95.9,26.4 94.5,26.9 94.1,26.4 92.8,26.5 93.6,26 93.8,25.2 94.1,24.3ZM104.1,22.9 103,22.9 102.8,22.4 103.2,21.9 104.1,21.7 104.9,22 104.9,22.4 104.8,22.6ZM84.4,20.9 83.7,21.2 82.4,20.9 81.6,21 80.2,20.6 81.1,20.3 81.8,19.9 82.8,20.1 83.4,20.3 83.7,20.5ZM89.5,20.5 89.4,21.5 90.8,20.7 92,21.4 91.7,22.1 92.6,22.8 93.7,22.1 94.4,21.2 94.5,20.1 95.9,20.2 97.4,20.3 98.7,20.8 98.8,21.3 98,21.9 98.7,22.4 98.6,22.9 96.7,23.6 95.3,23.7 94.2,23.4 93.9,23.9 93,24.8 92.7,25.2 91.5,25.9 90.1,26 89.3,26.4 89.2,27 88.1,27.2 86.8,28 85.8,29.1 85.4,29.9 85.3,31.1 86.8,31.2 87.2,32.2 87.7,32.9 89.1,32.7 91,33.1 92,33.5 92.7,34 93.9,34.3 95,34.7 96.6,34.8 97.7,34.9 97.6,35.7 97.9,36.7 98.6,37.8 100.1,38.8 100.9,38.5 101.4,37.4 100.9,35.9 100.2,35.3 101.8,34.9 102.9,34.2 103.5,33.5 103.4,32.8 102.7,31.9 101.5,31.2 102.7,30.1 102.2,29.2 101.9,27.7 102.6,27.4 104.3,27.7 105.3,27.8 106.2,27.6 107.1,27.9 108.3,28.5 108.6,28.9 110.4,28.9 110.4,29.8 110.7,31 111.6,31.2 112.4,31.8 113.8,31.2 114.8,30.1 115.4,29.7 116.2,30.6 117.5,31.8 118.6,33 118.2,33.7 119.5,34.2 120.4,34.8 122,35.1 122.7,35.4 123.1,36.2 123.8,36.4 124.2,36.7 124.3,37.9 123.6,38.2 122.9,38.6 121.2,38.9 120,39.8 118.3,39.9 116.1,39.7 114.6,39.7 113.6,39.8 112.8,40.5 111.5,40.9 110,42.3 108.9,43.2 109.7,43 111.3,41.7 113.4,40.9 114.9,40.8 115.8,41.3 114.9,41.9 115.2,43 115.5,43.8 116.8,44.3 118.5,44.1 119.5,43 119.6,43.7 120.2,44.1 119,44.7 116.7,45.3 115.8,45.7 114.6,46.5 113.9,46.4 113.8,45.5 115.6,44.7 114,44.7 112.9,44.9 112.2,44.3 112.2,42.9 111.8,42.6 111.1,42.8 110.8,42.6 110,43.3 109.7,44.1 109.3,44.5 108.9,44.7 108.6,44.7 108.5,45 106.7,45 105.1,45 104.7,45.2 103.6,45.9 103.5,46 103.2,46.4 102.3,46.4 101.3,46.4 100.8,46.5 101,46.7 101.1,47 101.1,47.1 99.8,47.6 98.7,47.8 97.6,48.3 97.3,48.3 97,48.2 96.9,48 96.9,47.9 97.1,47.6 97.6,47 97.9,46.4 97.7,45.6 97.4,44.7 96.4,44.2 96.5,44 96.4,43.9 96.1,43.9 95.9,43.7 95.9,43.5 95.7,43.6 95.4,43.6 95.5,43.5 95.2,43.4 95.1,43.1 94.3,42.8 93.5,42.4 92.6,42.1 91.6,41.7 90.7,42 90.4,42 89.2,41.7 88.4,41.9 87.4,41.5 86.4,41.4 85.7,41.3 85.4,41.2 85.2,40.6 84.8,40.6 84.8,41 82.8,41 79.3,41 76,41 73,41 70,41 67,41 64,41 63,41 60,41 57.2,41 57,41 55.1,40 54.4,39.6 52.6,39.2 52,38.3 52.1,37.7 50.9,37.2 50.7,36.4 49.5,35.7 49.5,35.2 50,34.7 50,34.1 48.3,33.4 47.3,32.3 46.6,31.6 45.7,31.1 45.1,30.7 44.5,30.2 43.5,30.5 42.5,31.1 41.7,30.4 41,30 40,29.7 39,29.7 39,24 39,20.3 40.9,20.5 42.5,21 43.5,21.1 44.4,20.7 45.6,20.4 47.1,20.5 48.6,20.1 50.2,19.8 50.9,20.2 51.6,20 51.9,19.5 52.6,19.6 54.2,20.5 55.6,19.8 55.7,20.6 56.9,20.4 57.3,20.1 58.5,20.2 60.1,20.6 62.4,21 63.8,21.2 64.8,21.1 66.1,21.6 64.7,22.1 66.5,22.3 69.2,22.2 70.1,22 71.1,22.6 72.2,22.1 71.2,21.7 71.8,21.3 73,21.3 73.8,21.2 74.7,21.4 75.7,22 76.8,21.9 78.5,22.4 80.1,22.2 81.6,22.2 81.4,21.6 82.3,21.4 83.9,21.8 83.9,22.7 84.5,21.9 85.3,21.9 85.8,20.9 84.7,20.3 83.5,19.9 83.6,18.8 84.8,18.1 86.1,18.2 87.1,18.7 88.5,19.8 87.6,20.3ZM65.8,16.9 65.3,17.3 67.6,17 68.9,17.5 70.1,17 71,17.4 71.8,18.3 72.3,17.9 71.6,16.9 72.5,16.8 73.5,16.9 74.6,17.3 75.2,18.3 75.5,19 77.2,19.5 79,20 78.9,20.4 77.3,20.5 77.9,20.9 77.6,21.2 75.8,21.1 74,20.8 72.9,20.9 71,21.2 68.5,21.4 66.7,21.5 66.1,21 64.8,20.7 63.9,20.8 62.7,20 63.3,19.9 64.9,19.8 66.3,19.8 67.6,19.6 65.7,19.4 63.5,19.5 62.1,19.5 61.6,19.1 63.9,18.7 62.3,18.7 60.6,18.4 61.4,17.7 62.1,17.3 64.8,16.7ZM75.5,16.6 74.6,17.2 73.1,16.5 73.4,16.4 74.7,16.4ZM103.7,16.9 103.7,17.2 102.7,17.1 101.6,17.1 100.5,17.3 100.2,17.2 99.1,16.7 99.2,16.3 99.6,16.2 101.9,16.3ZM93.4,16.8 94.2,17.5 95.1,16.7 97.7,16.2 99.4,17.3 99.3,17.9 101.2,17.6 102.2,17.3 104.4,17.8 105.8,18.2 105.9,18.7 107.8,18.4 108.8,19.1 111.2,19.5 112.1,19.9 113,20.8 111.2,21.3 113.6,21.9 115.1,22.2 116.6,23.1 118.1,23.1 117.8,23.8 116.1,25 114.9,24.6 113.3,23.6 112,23.7 111.9,24.3 112.9,24.9 114.3,25.4 114.7,25.6 115.3,26.6 115,27.3 113.7,27.1 111.2,26.3 112.6,27.1 113.7,27.7 113.8,28.1 111.1,27.7 109,27.1 107.8,26.6 108.1,26.3 106.6,25.8 105.2,25.3 105.2,25.6 102.3,25.8 101.4,25.4 102.1,24.7 104,24.7 106,24.5 105.7,24.2 106.1,23.7 107.3,22.7 107.1,22.3 106.7,21.9 105.2,21.4 103.1,21.1 103.8,20.9 102.7,20.2 101.8,20.2 101,19.8 100.5,20.1 98.7,20.3 95.1,20 92.9,19.7 91.3,19.6 90.5,19.2 91.5,18.8 90.1,18.8 89.8,17.8 90.6,16.9 91.6,16.5 94.2,16.2ZM79.6,16.2 80.8,16.4 82.6,16.2 82.9,16.5 81.9,17 83.5,17.4 83.3,18.3 81.6,18.7 80.7,18.6 80,18.3 77.5,17.5 77.5,17.2 79.6,17.3 78.5,16.6ZM86.8,17.2 85.7,18 84.6,17.9 84,17.1 84,16.6 84.5,16.1 85.5,15.9 87.6,15.9 89.5,16.1 88,17ZM59.5,18.6 56.9,19.1 56.4,18.7 54.1,18.1 54.5,17.7 55.2,17 56.1,16.3 55.1,15.7 58.5,15.6 59.9,15.8 62.4,15.8 63.4,16.1 64.5,16.5 63.2,16.8 60.8,17.5 59.5,18.2ZM86.4,15 85.8,15.4 84.4,15.3 83.2,15.1 83.7,14.6 85.1,14.4 86,14.7ZM81.5,13.3 82.3,13.7 82.3,14.3 81.8,15 80.2,15.1 79.1,14.9 79.1,14.4 77.5,14.4 77.4,13.7 78.5,13.7 80,13.4 81.4,13.4ZM71.8,13.8 72.2,14.2 73.1,14 74.1,14 74.3,14.5 73.7,15 70.3,15.2 67.8,15.6 66.3,15.6 66.1,15.3 68.2,14.8 63.7,15 62.3,14.8 63.7,13.8 64.6,13.5 67.4,13.9 69.2,14.5 70.9,14.5 69.5,13.6 70.4,13.2 71.5,13.3ZM85.3,12.9 86.4,13.2 88.4,13.2 89.3,13.6 89,13.9 90.2,14.2 90.8,14.4 92.2,14.4 93.6,14.5 95.2,14.3 97.2,14.2 98.9,14.3 99.9,14.7 100.2,15.1 99.5,15.3 98.1,15.6 96.8,15.4 93.9,15.6 91.8,15.6 90.2,15.5 87.6,15.2 87.2,14.6 87.1,14.1 86.1,13.7 84,13.6 82.9,13.2 83.3,12.8ZM63.8,12.4 63.7,13.1 62.9,13.5 62,13.5 60.1,13.9 58.5,14.1 57.1,13.9 58.8,13.1 60.9,12.5 62.4,12.5ZM86.2,12.5 85.7,12.5 83.8,12.4 83.6,12.2 85.6,12.2 86.3,12.4ZM69.8,12.3 67.9,12.6 66.5,12.3 67.3,11.9 68.7,11.8 70.1,12ZM70.3,11.4 69.1,11.6 67.5,11.6 67.5,11.4 68.5,11.2 69,11.2ZM84.2,11.9 82.7,12.1 81.9,11.9 81.4,11.5 81.4,11.1 82.7,11.2 83.2,11.2 84.4,11.6ZM79.9,11.7 80.3,12.1 78.7,12 77.1,11.7 74.8,11.6 75.8,11.3 74.6,11.1 74.5,10.7 76.5,10.8 79.2,11.2ZM93,10.3 94.2,10.7 92.8,11 91,11.7 89.2,11.8 87.1,11.7 86,11.2 86.1,10.9 86.9,10.6 85,10.6 83.9,10.3 83.3,9.8 84,9.4 84.7,9.1 85.7,9 85.3,8.8 87.6,8.7 88.9,9.3 90.5,9.5 92.2,9.7ZM111.5,6.9 114.2,7 116.3,7.1 118.2,7.4 118.1,7.6 115.7,8.1 113.2,8.3 112.3,8.5 114.5,8.5 112.2,9.1 110.5,9.4 108.8,10.2 106.8,10.4 106.1,10.6 103.1,10.7 104.5,10.8 103.8,11 104.6,11.5 103.7,11.8 102.1,12.1 101.6,12.5 100.2,12.8 100.4,13 102.1,13 102.1,13.2 99.4,13.8 96.8,13.5 93.9,13.7 92.4,13.6 90.5,13.5 90.4,13 92.2,12.8 91.7,12.1 92.3,12 95,12.5 93.7,11.8 92,11.6 92.8,11.2 94.6,11 94.9,10.7 93.5,10.3 93.1,9.7 95.8,9.8 96.6,9.9 98.2,9.5 95.9,9.4 92.4,9.5 90.6,9.1 89.8,8.7 88.6,8.4 88.4,8.1 89.9,7.9 91.1,7.9 93,7.7 94.5,7.3 95.7,7.4 96.8,7.7 97.6,7.1 98.9,7 100.7,6.9 103.8,6.8 104.3,6.9 107.2,6.8 109.3,6.8Z",
+	"CD": "M210.8,86.5 210.8,87.7 211.2,87.8 210.9,88.2 210.5,88.4 210.1,88.9 209.9,89.4 209.8,90.2 209.6,90.6 209.6,91.3 209.3,91.6 209.3,92.2 209.1,92.3 209,92.8 209.3,93.3 209.3,94.5 209.5,95.4 209.4,95.9 209.6,96.5 210.2,97.1 210.7,98.3 210.3,98.2 209,98.4 208.7,98.5 208.4,99.2 208.7,99.6 208.5,100.8 208.4,101.8 208.6,102 209.3,102.4 209.6,102.2 209.7,103.3 208.9,103.2 208.5,102.7 208.2,102.3 207.4,102.1 207.2,101.6 206.6,101.9 205.8,101.8 205.4,101.3 204.8,101.2 204.3,101.3 204.3,101 203.9,100.9 203.5,100.9 202.8,101 202.4,101 202.2,101.1 202.2,99.9 201.9,99.5 201.8,98.9 201.9,98.3 201.7,97.9 201.7,97.3 200.5,97.3 200.6,96.9 200.1,96.9 200,97.1 199.4,97.2 199.2,97.7 199,98 198.5,97.8 198.1,98 197.5,98.1 197.1,97.5 196.9,97.2 196.6,96.6 196.3,95.9 193.4,95.9 193,96 192.7,96 192.3,96.1 192.2,95.8 192.4,95.7 192.5,95.2 192.6,95 193,94.8 193.3,94.9 193.6,94.5 194.1,94.5 194.2,94.8 194.6,95 195.2,94.3 195.8,93.9 196,93.5 196,92.7 196.4,91.7 196.9,91.2 197.5,90.7 197.6,90.4 197.7,90.1 197.8,89.7 197.8,89.1 197.9,88.3 198.1,87.6 198.4,87.1 198.5,86.5 198.5,85.8 198.9,85.3 199.5,85 200.3,85.3 200.9,85.7 201.7,85.8 202.4,86 202.7,85.4 202.8,85.3 203.3,85.4 204.4,84.9 204.8,85.1 205.1,85.1 205.3,84.8 205.7,84.7 206.4,84.8 207,84.9 207.4,84.8 208,85.6 208.4,85.7 208.7,85.5 209.2,85.6 209.7,85.4 210,85.8Z",
+	"CF": "M195.3,82.6 196.1,82.5 196.3,82.2 196.5,82.3 196.7,82.5 198,82.1 198.4,81.7 198.9,81.4 198.8,81 199.1,80.9 200.1,81 201,80.5 201.7,79.4 202.2,79 202.9,78.9 203,79.3 203.6,79.9 203.6,80.3 203.4,80.7 203.5,81 203.8,81.3 204.6,81.8 205.1,82.2 205.1,82.5 205.8,83 206.2,83.5 206.5,84.1 207.2,84.4 207.4,84.8 207,84.9 206.4,84.8 205.7,84.7 205.3,84.8 205.1,85.1 204.8,85.1 204.4,84.9 203.3,85.4 202.8,85.3 202.7,85.4 202.4,86 201.7,85.8 200.9,85.7 200.3,85.3 199.5,85 198.9,85.3 198.5,85.8 198.5,86.5 197.8,86.4 197.1,86.3 196.5,86.8 196,87.7 195.9,87.4 195.9,87 195.4,86.7 195,86.1 195,85.8 194.5,85.3 194.6,85 194.5,84.5 194.5,83.8 194.8,83.6Z",
+	"CG": "M193,94.8 192.6,94.4 192.3,94.6 191.9,95 191.1,94 191.9,93.4 191.5,92.8 191.8,92.5 192.5,92.4 192.6,91.9 193.1,92.4 194,92.5 194.3,92 194.4,91.3 194.3,90.6 193.8,90 194.3,88.8 194,88.6 193.3,88.7 193,88.2 193.1,87.7 194.3,87.8 195.1,88 195.9,88.3 196,87.7 196.5,86.8 197.1,86.3 197.8,86.4 198.5,86.5 198.4,87.1 198.1,87.6 197.9,88.3 197.8,89.1 197.8,89.7 197.7,90.1 197.6,90.4 197.5,90.7 196.9,91.2 196.4,91.7 196,92.7 196,93.5 195.8,93.9 195.2,94.3 194.6,95 194.2,94.8 194.1,94.5 193.6,94.5 193.3,94.9Z",
+	"CH": "M189.6,42.5 189.6,42.7 189.5,42.9 189.9,43.1 190.4,43.1 190.4,43.5 189.9,43.7 189.2,43.6 189,44 188.5,44 188.3,43.8 187.8,44.2 187.3,44.2 186.8,44 186.5,43.6 186,43.7 186,43.3 186.8,42.7 186.7,42.5 187.2,42.6 187.5,42.4 188.3,42.4 188.5,42.2Z",
+	"CI": "M177.1,85 176.7,85 176,84.8 175.4,84.8 174.2,85 173.5,85.3 172.5,85.7 172.3,85.6 172.4,84.8 172.5,84.7 172.4,84.3 172,83.9 171.7,83.8 171.4,83.5 171.6,83.1 171.5,82.6 171.6,82.3 171.7,82.3 171.8,81.9 171.7,81.7 171.8,81.5 172.2,81.4 171.9,80.6 171.7,80.2 171.8,79.9 172,79.8 172.1,79.7 172.4,79.9 173.1,79.9 173.3,79.6 173.5,79.6 173.8,79.5 173.9,79.9 174.2,79.8 174.6,79.6 175,79.8 175.2,80.2 175.7,80.4 176,80.1 176.5,80.1 177.2,80.4 177.4,81.8 177,82.6 176.8,83.7 177.2,84.6Z",
+	"CL": "M111.4,142.6 111.4,144.9 112.4,144.9 113,144.9 112.7,145.3 111.9,145.6 111.4,145.6 110.8,145.5 110,145.2 109,145.1 107.7,144.5 106.7,144 105.3,142.8 106.2,143 107.6,143.7 108.9,144.1 109.4,143.6 109.7,142.9 110.7,142.5ZM111.8,111.5 112.2,112.9 112.9,112.7 113,113 112.7,114 111.6,114.5 111.6,116.2 111.4,116.5 111.7,116.9 111,117.5 110.3,118.5 110,119.4 110.1,120.3 109.5,121.4 109.9,123.1 110.2,123.3 110.2,124.2 109.6,125.2 109.6,126 108.9,126.7 108.9,127.6 109.2,128.6 108.6,128.9 108.3,129.8 108.1,130.8 108.3,132.1 107.9,132.3 108.1,133.4 108.5,133.8 108.2,134.2 108.7,134.4 108.8,134.8 108.3,135 108.4,135.6 108.1,136.9 107.6,137.7 107.7,138.2 107.4,138.9 106.6,139.3 106.7,140.4 107,140.7 107.7,140.7 107.7,141.4 108.1,142 110.5,142.1 111.4,142.3 110.5,142.3 110.1,142.5 109.2,142.9 109,143.8 108.6,143.9 107.4,143.5 106.3,142.8 105.1,142.3 104.7,141.6 105,141 104.5,140.4 104.4,138.7 104.8,137.7 105.9,136.9 104.4,136.6 105.3,135.8 105.6,134.1 106.8,134.5 107.3,132.4 106.6,132.1 106.3,133.4 105.7,133.2 106,131.8 106.3,129.9 106.8,129.3 106.5,128.3 106.4,127.2 106.8,127.1 107.4,125.5 108.1,123.9 108.6,122.4 108.3,120.9 108.6,120.1 108.5,118.9 109.1,117.6 109.3,115.7 109.6,113.6 109.9,111.4 109.8,109.8 109.6,108.3 110.1,108.1 110.4,107.6 110.9,108.3 111,109 111.6,109.4 111.2,110.4Z",
+	"CM": "M193.1,87.7 193,87.7 192.4,87.8 191.8,87.7 191.3,87.7 189.6,87.7 189.8,86.9 189.4,86.3 188.9,86.1 188.7,85.6 188.5,85.5 188.5,85.2 188.8,84.5 189.2,83.6 189.5,83.5 190.1,83 190.5,82.9 191.1,83.4 191.7,83 191.8,82.6 192.1,82.2 192.2,81.7 192.8,81.3 193,80.6 193.2,80.4 193.3,79.8 193.6,79.2 194.4,78.4 194.5,78.1 194.6,77.9 194.2,77.5 194.2,77.2 194.5,77.1 194.9,77.8 195,78.4 194.9,79.1 195.5,80 194.9,80 194.6,80.1 194.2,80 194,80.5 194.5,81 195,81.2 195.1,81.6 195.4,82.3 195.3,82.6 194.8,83.6 194.5,83.8 194.5,84.5 194.6,85 194.5,85.3 195,85.8 195,86.1 195.4,86.7 195.9,87 195.9,87.4 196,87.7 195.9,88.3 195.1,88 194.3,87.8Z",
+	"CN": "M290.3,71.3 289.5,71.8 288.7,71.5 288.6,70.6 289.1,70.2 290.2,69.9 290.8,69.9 291,70.3 290.6,70.7ZM307.7,40.2 309.4,40.6 310.6,41.3 311,42.2 312.5,42.2 313.4,41.8 315,41.5 314.5,42.4 314.1,42.8 313.8,43.9 313.1,44.9 311.9,44.7 311,45 311.3,45.9 311.1,47.1 310.6,47.1 310.6,47.6 310,47 309.6,47.6 308.1,48 308.2,48.5 307.3,48.5 306.9,48.2 306.2,48.9 305.1,49.4 304.3,50.1 302.9,50.4 302.1,50.8 301.1,51.1 301.6,50.6 301.4,50.2 302.2,49.6 301.6,49.1 300.8,49.4 299.6,50.1 299,50.7 298,50.8 297.5,51.3 298.1,51.9 298.9,52.1 298.9,52.6 299.7,52.8 300.8,52.1 301.7,52.5 302.4,52.5 302.5,53.1 301.1,53.3 300.6,53.9 299.7,54.4 299.2,55.1 300.2,55.6 300.6,56.6 301.2,57.5 301.9,58.3 301.9,59.1 301.3,59.3 301.5,59.9 302.1,60.2 301.9,61 301.7,61.8 301.1,61.9 300.4,62.9 299.6,64.3 298.7,65.5 297.3,66.4 295.9,67.2 294.8,67.3 294.2,67.8 293.8,67.5 293.2,67.9 291.8,68.4 290.8,68.6 290.4,69.7 289.9,69.7 289.6,69 289.9,68.6 288.5,68.3 288.1,68.4 287,68.2 286.6,67.8 286.7,67.2 285.8,67 285.3,66.6 284.5,67.2 283.5,67.3 282.7,67.3 282.2,67.5 281.7,67.7 281.8,68.8 281.3,68.8 281.2,68.6 281.2,68.2 280.4,68.4 280,68.3 279.2,67.9 279.5,67.1 278.9,66.9 278.7,65.9 277.6,66.1 277.7,64.9 278.7,64.1 278.7,63.3 278.7,62.5 278.2,62.3 277.9,61.7 277.3,61.7 276.2,61.6 276.6,61.2 276.1,60.5 275.4,61 274.6,60.7 273.4,61.4 272.5,62.1 271.7,62.2 271.3,62 270.7,61.9 270,61.7 269.5,62 268.8,62.7 268.7,61.9 268.1,62.1 267,62 265.8,61.8 265,61.4 264.2,61.2 263.9,60.7 263.3,60.5 262.3,59.9 261.5,59.6 261.1,59.8 259.7,59.1 258.7,58.5 258.5,57.4 259.2,57.5 259.2,57 258.8,56.5 258.9,55.7 257.8,54.5 256.2,54.1 255.9,53.3 255.2,52.9 255,52.6 254.8,52 254.9,51.6 254.3,51.4 253.9,51.5 253.7,50.6 254,50.3 253.8,50.1 254.8,49.6 255.5,49.4 256.5,49.6 256.9,48.9 258.2,48.8 258.5,48.4 260.1,47.9 260.3,47.7 260.2,47.1 260.9,46.8 260,45.1 261.9,44.7 262.5,44.5 263.2,42.7 265.2,43 265.7,42.5 265.8,41.5 266.6,41.5 267.4,40.8 267.8,40.7 268,41.4 268.9,41.9 270.3,42.3 271,43.1 270.6,44.3 270.9,44.7 272.1,44.9 273.5,45 274.7,45.6 275.3,45.8 275.8,46.7 276.3,47.3 277.5,47.3 279.5,47.5 280.8,47.3 281.8,47.5 283.3,48.1 284.5,48.1 285,48.4 286.1,47.9 287.7,47.5 289.2,47.5 290.4,47.1 291.1,46.6 291.8,46.3 291.7,45.9 291.3,45.5 291.9,44.9 292.4,45 293.5,45.2 294.5,44.7 296,44.3 296.7,43.6 297.4,43.3 298.9,43.2 299.7,43.3 299.8,43 298.9,42.3 298.1,41.9 297.3,42.3 296.3,42.1 295.7,42.3 295.5,41.9 296.2,40.9 296.7,40.1 297.9,40.5 299.3,39.9 299.3,39.4 300.2,38.4 300.7,38 300.7,37.5 300.2,37.2 301,36.7 302.2,36.6 303.6,36.5 305.1,36.8 305.9,37.2 306.6,38.2 306.9,38.6 307.3,39.3Z",
+	"CO": "M104.6,90.2 104.2,89.9 103.7,89.6 103.4,89.7 102.6,89.6 102.3,89.2 102.1,89.2 101.1,88.6 101,88.3 101.4,88.2 101.3,87.7 101.6,87.4 102.1,87.3 102.5,86.7 102.9,86.2 102.5,85.9 102.7,85.3 102.5,84.4 102.7,84.2 102.5,83.3 102.1,82.8 102.2,82.3 102.6,82.4 102.8,82.1 102.5,81.5 102.6,81.3 103.2,81.4 103.9,80.7 104.3,80.6 104.3,80.2 104.5,79.4 105.1,78.9 105.7,78.9 105.8,78.7 106.6,78.8 107.4,78.3 107.8,78 108.2,77.6 108.6,77.6 108.9,77.9 108.7,78.2 108,78.4 107.8,78.9 107.4,79.2 107.1,79.5 107,80.3 106.7,80.8 107.2,80.9 107.3,81.4 107.6,81.6 107.6,82 107.5,82.4 107.6,82.6 107.8,82.7 108,83 109.3,82.9 109.9,83 110.6,83.9 111,83.8 111.7,83.8 112.3,83.7 112.7,83.9 112.5,84.4 112.3,84.8 112.2,85.5 112.4,86.2 112.7,86.5 112.7,86.7 112.2,87.2 112.6,87.4 112.8,87.7 113.1,88.7 112.9,88.9 112.7,88.3 112.5,88 112.1,88.3 110.2,88.3 110.2,88.9 110.8,89 110.7,89.4 110.5,89.3 110,89.5 110,90.2 110.4,90.5 110.6,91.1 110.6,91.6 110.1,94.3 109.6,93.8 109.3,93.7 110,92.7 109.2,92.3 108.6,92.3 108.2,92.2 107.7,92.4 106.9,92.3 106.3,91.3 105.9,91 105.6,90.5 104.9,90.1Z",
+	"CR": "M97,81.8 96.5,81.6 96.3,81.3 96.4,81.2 96.4,80.9 96.1,80.7 95.7,80.5 95.4,80.4 95.3,80.1 95,79.9 95.1,80.2 94.9,80.4 94.7,80.2 94.3,80.1 94.2,79.9 94.2,79.6 94.3,79.2 94.1,79.1 94.3,78.9 94.4,78.8 95.1,79 95.3,78.9 95.6,79 95.8,79.2 96.1,79.3 96.3,79.1 96.6,79.6 97,80 97.5,80.4 97.1,80.5 97.1,80.9 97.3,81.1 97.1,81.2 97.2,81.4 97.1,81.6Z",
+	"CU": "M97.7,66.8 98.6,66.9 99.4,66.9 100.3,67.2 100.7,67.6 101.7,67.5 102,67.7 102.9,68.3 103.5,68.8 103.8,68.8 104.4,69 104.3,69.3 105.1,69.3 105.8,69.7 105.7,69.9 105,70.1 104.4,70.1 103.7,70 102.2,70.1 102.9,69.6 102.5,69.3 101.9,69.3 101.5,69 101.3,68.4 100.7,68.4 99.8,68.2 99.5,68 98.2,67.8 97.8,67.6 98.2,67.4 97.2,67.3 96.5,67.8 96.1,67.8 95.9,68.1 95.5,68.2 95,68.1 95.6,67.8 95.8,67.4 96.2,67.2 96.7,67 97.5,66.9Z",
+	"CY": "M212.7,54.9 212.8,54.9 212.9,54.6 213.7,54.6 214.6,54.3 213.9,54.8 214,54.9 213.9,54.9 213.7,55 213.5,55 213.5,54.9 213.4,54.8 213.2,54.8 212.9,54.9ZM214,54.9 214,55 213,55.4 212.5,55.3 212.3,54.9 212.7,54.9 212.9,54.9 213.2,54.8 213.4,54.8 213.5,54.9 213.5,55 213.7,55 213.9,54.9Z",
+	"CZ": "M197,41.4 196.5,41.2 196,41.3 195.3,41 194.9,41 194.3,41.4 193.6,41.1 193,40.7 192.5,40.5 192.4,40 192.2,39.7 193,39.5 193.3,39.3 194.1,39.1 194.3,38.9 194.6,39 195,38.9 195.5,39.2 196.2,39.3 196.2,39.6 196.7,39.8 196.9,39.5 197.6,39.6 197.6,40 198.4,40 198.9,40.5 198.6,40.5 198.4,40.7 198.2,40.7 198.1,41 197.9,41 197.9,41.1 197.5,41.2 197.1,41.2Z",
+	"DE": "M189.9,35 189.9,35.4 191,35.6 190.9,36 192,35.8 192.5,35.5 193.6,35.9 194.1,36.2 194.4,36.8 194.1,37 194.4,37.4 194.7,37.9 194.6,38.3 195,38.9 194.6,39 194.3,38.9 194.1,39.1 193.3,39.3 193,39.5 192.2,39.7 192.4,40 192.5,40.5 193,40.7 193.6,41.1 193.2,41.6 192.9,41.7 193,42.4 192.9,42.5 192.6,42.3 192.1,42.3 191.4,42.5 190.5,42.4 190.4,42.7 189.9,42.4 189.6,42.5 188.5,42.2 188.3,42.4 187.5,42.4 187.6,41.7 188.1,41 186.7,40.8 186.2,40.5 186.2,40.1 186,39.9 186.2,39.2 186,38.1 186.6,38.1 186.8,37.8 187.1,36.9 186.9,36.5 187.1,36.3 187.9,36.3 188.1,36.5 188.8,36 188.6,35.6 188.5,35 189.3,35.2Z",
+	"DJ": "M223.1,77.3 223.3,77.6 223.3,78 222.7,78.3 223.1,78.5 222.8,79.1 222.6,78.9 222.3,79 221.8,78.9 221.7,78.6 221.7,78.4 222,77.9 222.4,77.5 222.8,77.5Z",
+	"DK": "M192.7,34.4 192.1,35.2 191,34.6 190.9,34.2 192.4,33.9ZM190.9,33.5 190.7,33.9 190.4,33.8 189.6,34.5 189.9,35 189.3,35.2 188.5,35 188.1,34.5 188.1,33.5 188.3,33.2 188.5,32.9 189.4,32.8 189.8,32.6 190.6,32.3 190.5,32.8 190.3,33.1 190.4,33.4Z",
+	"DO": "M108.3,70.3 108.4,70.1 109.2,70.1 109.8,70.4 110,70.4 110.2,70.7 110.8,70.7 110.7,71 111.2,71 111.7,71.4 111.3,71.8 110.8,71.6 110.4,71.6 110,71.6 109.9,71.8 109.5,71.8 109.3,71.6 109,71.7 108.6,72.4 108.3,72.2 108.3,72 108.3,71.7 108.1,71.4 108.3,71.2 108.4,70.8Z",
+	"DZ": "M192,66.5 188.6,68.4 185.7,70.4 184.3,70.8 183.2,70.9 183.1,70.3 182.7,70.1 182.1,69.9 181.8,69.4 178.4,67.2 175.1,65 171.3,62.6 171.3,62.4 171.3,62.3 171.3,61.2 172.9,60.4 173.9,60.3 174.8,60 175.1,59.5 176.3,59.1 176.4,58.4 176.9,58.3 177.4,57.9 178.7,57.7 178.9,57.3 178.6,57.1 178.3,56.1 178.2,55.5 177.8,54.8 178.8,54.3 179.9,54.1 180.5,53.7 181.5,53.4 183.2,53.2 184.8,53.1 185.3,53.3 186.3,52.9 187.3,52.9 187.7,53.1 188.4,53.1 188.2,53.6 188.4,54.5 188.1,55.3 187.5,55.9 187.6,56.7 188.4,57.3 188.4,57.5 189.1,57.9 189.5,59.7 189.8,60.6 189.9,61 189.7,61.9 189.8,62.3 189.6,62.9 189.7,63.5 189.3,63.9 189.9,64.6 189.9,65.1 190.3,65.6 190.8,65.4 191.6,65.9Z",
+	"EC": "M99.7,93.4 100.2,92.7 100,92.2 99.6,92.7 99,92.2 99.2,92 99.1,91.1 99.4,90.9 99.6,90.3 100,89.6 99.9,89.2 100.5,89 101.1,88.6 102.1,89.2 102.3,89.2 102.6,89.6 103.4,89.7 103.7,89.6 104.2,89.9 104.6,90.2 104.8,90.9 104.5,91.6 103.4,92.6 102.2,93 101.5,93.9 101.4,94.5 100.8,95 100.4,94.5 100,94.3 99.6,94.4 99.5,94.1 99.8,93.8Z",
+	"EE": "M204.3,32.2 204.4,31.6 204.1,31.7 203.4,31.4 203.3,30.8 204.6,30.5 205.9,30.4 206.9,30.6 208,30.5 208.1,30.7 207.4,31.3 207.7,32.2 207.3,32.5 206.5,32.5 205.6,32.2 205.2,32Z",
+	"EG": "M214.9,60.5 214.6,60.9 214.4,61.7 214.2,62.2 213.9,62.4 213.6,62 213.1,61.6 212.4,60.1 212.3,60.2 212.7,61.3 213.3,62.3 214.1,63.9 214.5,64.4 214.8,65 215.7,66.1 215.5,66.2 215.5,66.9 216.7,67.8 216.9,68 212.9,68 209,68 205,68 205,64.3 205,60.8 204.7,60 205,59.3 204.8,58.9 205.2,58.4 206.5,58.4 207.5,58.7 208.5,59 208.9,59.1 209.7,58.8 210.1,58.5 211,58.4 211.7,58.6 212,59.1 212.2,58.7 213,59 213.8,59 214.3,58.8Z",
+	"EH": "M171.2,62.9 171.2,62.3 171.3,62.3 171.3,62.4 171.3,62.6 171.3,64.1 168,64.1 168.1,66.6 167.1,66.7 166.9,67.2 167.1,68.7 163.2,68.7 162.9,69 163,68.6 165.2,68.5 165.4,68.1 165.8,67.7 166.1,66.3 167.5,65.2 168,64 168.3,63.9 168.6,63.1 169.4,63 169.8,63.1 170.3,63.1 170.6,62.9Z",
+	"ER": "M222.4,77.5 222,77.1 221.6,76.5 221.2,76.2 220.9,75.9 220,75.5 219.3,75.5 219.1,75.3 218.5,75.5 217.9,75 217.6,75.8 216.4,75.6 216.3,75.2 216.8,73.7 216.9,73 217.2,72.7 217.9,72.6 218.4,72 219,73.2 219.3,74.1 219.8,74.6 221.2,75.5 221.7,76.1 222.3,76.7 222.6,77 223.1,77.3 222.8,77.5Z",
+	"ES": "M171,48.1 171,47.4 170.6,47 172,46.3 173.2,46.4 174.6,46.4 175.7,46.6 176.5,46.5 178.1,46.6 178.5,47 180.3,47.4 180.7,47.2 181.8,47.7 183,47.5 183,48.1 182.1,48.8 180.8,49 180.7,49.3 180.1,49.9 179.7,50.7 180.1,51.3 179.5,51.7 179.3,52.4 178.6,52.6 177.9,53.3 176.6,53.3 175.6,53.3 175,53.7 174.6,54.1 174.1,54 173.8,53.6 173.5,53.1 172.5,52.9 172.5,52.6 172.8,52.2 173,51.9 172.6,51.6 172.9,51 172.5,50.4 172.9,50.3 173,49.8 173.1,49.7 173.1,48.9 173.6,48.6 173.3,48.1 172.7,48.1 172.6,48.2 172,48.2 171.7,47.7 171.3,47.9Z",
+	"ET": "M217.9,75 218.5,75.5 219.1,75.3 219.3,75.5 220,75.5 220.9,75.9 221.2,76.2 221.6,76.5 222,77.1 222.4,77.5 222,77.9 221.7,78.4 221.7,78.6 221.8,78.9 222.3,79 222.6,78.9 222.8,79.1 222.6,79.4 222.9,80 223.3,80.5 223.7,80.8 226.9,82 227.8,82 225,85 223.7,85 222.8,85.7 222.1,85.8 221.9,86.1 221.2,86.1 220.8,85.7 219.9,86.2 219.6,86.6 218.9,86.5 218.7,86.4 218.4,86.4 218.1,86.4 216.9,85.6 216.2,85.6 215.8,85.2 215.8,84.7 215.3,84.5 214.7,83.4 214.3,83.2 214.1,82.8 213.6,82.3 213,82.2 213.3,81.6 213.8,81.6 214,81.3 214,80.4 214.3,79.4 214.7,79.1 214.8,78.7 215.3,77.9 215.9,77.4 216.3,76.4 216.4,75.6 217.6,75.8Z",
+	"FI": "M208.6,20.9 208.4,21.6 210,22.3 209.1,23.1 210.2,24.2 209.5,25.1 210.4,25.8 210,26.4 211.5,27.1 211.1,27.6 210.2,28.2 208.1,29.5 206.3,29.6 204.5,29.9 202.9,30.2 202.3,29.6 201.3,29.3 201.5,28.3 201.1,27.4 201.5,26.8 202.4,26.2 204.7,25.1 205.4,24.9 205.3,24.5 203.9,24 203.6,23.6 203.5,22.1 202,21.4 200.6,20.9 201.2,20.6 202.4,21.2 203.7,21.1 204.7,21.4 205.7,20.9 206.2,20.2 207.7,19.8 209,20.2Z",
+	"FJ": "M358.4,107.3 358.7,107.6 358.6,108.2 357.9,108.3 357.4,108.2 357.3,107.7 357.7,107.4 358.1,107.5ZM359.4,106.8 358.7,107 358.6,106.6 359.1,106.4 359.4,106.4 360,106.1 360,106.6ZM0.1,106.5 0,106.6 0,106.1 0.2,106Z",
+	"FK": "M118.8,141.8 120,141.2 120.8,141.5 121.5,141.1 122.2,141.6 122,141.9 120.6,142.2 120.2,141.8 119.3,142.3Z",
+	"FR": "M127.4,87.5 127.1,87.9 126.6,87.9 126.4,87.7 126.2,87.6 125.9,87.9 125.5,87.7 125.7,87.3 125.8,86.8 126,86.4 125.6,85.8 125.5,85.1 126,84.2 126.4,84.4 127.1,84.6 128.2,85.4 128.3,85.8 127.8,86.8ZM189.6,47.8 189.2,48.6 188.8,48.4 188.5,47.7 188.7,47.4 189.4,47ZM183.6,39.6 184.3,40.1 184.8,40 185.7,40.5 185.9,40.6 186.2,40.5 186.7,40.8 188.1,41 187.6,41.7 187.5,42.4 187.2,42.6 186.7,42.5 186.8,42.7 186,43.3 186,43.7 186.5,43.6 186.8,44 186.8,44.3 187.1,44.7 186.7,45 187,45.7 187.5,45.9 187.4,46.3 186.5,46.9 184.6,46.6 183.1,46.9 183,47.5 181.8,47.7 180.7,47.2 180.3,47.4 178.5,47 178.1,46.6 178.6,46 178.8,44 177.8,42.9 177,42.4 175.5,42 175.4,41.3 176.7,41.1 178.4,41.4 178.1,40.2 179,40.7 181.3,39.9 181.6,39.1 182.5,38.9 182.7,39.2 183.1,39.2Z",
+	"GA": "M191.1,94 190.1,93 189.4,92.1 188.8,91.1 188.8,90.8 189,90.5 189.3,89.7 189.5,89 189.8,88.9 191.3,88.9 191.3,87.7 191.8,87.7 192.4,87.8 193,87.7 193.1,87.7 193,88.2 193.3,88.7 194,88.6 194.3,88.8 193.8,90 194.3,90.6 194.4,91.3 194.3,92 194,92.5 193.1,92.4 192.6,91.9 192.5,92.4 191.8,92.5 191.5,92.8 191.9,93.4Z",
+	"GB": "M174.3,35.4 173.8,36.1 173,35.9 172.4,35.9 172.6,35.4 172.4,34.9 173.3,34.8ZM177,31.4 175.9,32.4 176.9,32.3 178,32.3 177.8,33.1 176.9,34 177.9,34.1 178,34.2 178.9,35.4 179.6,35.5 180.2,36.7 180.5,37.1 181.7,37.3 181.6,37.9 181.1,38.2 181.4,38.7 180.6,39.2 179.2,39.2 177.5,39.5 177,39.3 176.4,39.8 175.5,39.7 174.8,40 174.2,39.8 175.7,38.8 176.6,38.6 175,38.4 174.7,38 175.8,37.7 175.2,37.2 175.4,36.5 176.9,36.6 177.1,36 176.4,35.4 175.2,35.2 174.9,34.9 175.3,34.5 175,34.2 174.4,34.7 174.4,33.7 173.9,33.2 174.2,32.2 175,31.4 175.8,31.4Z",
+	"GE": "M221.6,48.5 221.7,48 221.5,47.4 220.9,47 220.3,46.9 220,46.6 220.1,46.4 220.9,46.6 222.4,46.8 223.8,47.3 223.9,47.4 224.5,47.3 225.5,47.5 225.8,47.9 226.4,48.1 226.1,48.3 226.6,48.8 226.5,48.9 226,48.9 225.2,48.6 225,48.8 223.6,48.9 222.6,48.4Z",
+	"GH": "M181.1,84.1 179.5,84.7 178.9,85 178,85.3 177.1,85 177.2,84.6 176.8,83.7 177,82.6 177.4,81.8 177.2,80.4 177,79.6 177.1,79 178.8,79 179.2,79.1 179.6,78.9 180,79 180,79.3 180.4,79.8 180.4,80.5 180.5,81.3 180.7,81.7 180.5,82.6 180.6,83.1 180.8,83.7Z",
+	"GL": "M133.2,7.4 136.6,6.8 140.1,6.8 141.4,6.5 144.9,6.4 152.9,6.5 159.2,7.3 157.3,7.7 153.5,7.7 148.1,7.8 148.6,8 152.1,7.9 155.2,8.2 157.1,7.9 157.9,8.3 156.8,8.8 159.4,8.5 164.2,8.1 167.2,8.3 167.8,8.7 163.7,9.4 163.2,9.7 160,9.8 162.3,9.9 161.1,10.6 160.3,11.2 160.3,12.4 161.5,13 160,13.1 158.3,13.4 160.2,13.9 160.4,14.8 159.3,14.8 160.6,15.7 158.4,15.8 159.6,16.2 159.2,16.5 157.8,16.7 156.4,16.7 157.7,17.4 157.7,17.8 155.7,17.4 155.2,17.7 156.6,17.9 157.9,18.5 158.2,19.3 156.5,19.5 155.7,19.1 154.5,18.6 154.8,19.2 153.6,19.8 156.3,19.8 157.7,19.9 155,20.7 152.3,21.5 149.3,21.9 148.2,21.9 147.2,22.3 145.8,23.3 143.6,24 143,24.1 141.6,24.3 140.2,24.5 139.3,25.2 139.3,25.9 138.8,26.5 137.2,27.3 137.6,28.1 137.1,28.9 136.6,29.9 135.2,30 133.7,29.1 131.7,29.1 130.8,28.6 130.1,27.6 128.4,26.4 127.9,25.7 127.7,24.8 126.3,23.9 126.7,23.2 126,22.8 127,21.6 128.5,21.3 128.9,20.9 129.1,20.1 128,20.4 127.4,20.6 126.5,20.7 125.3,20.4 125.2,19.7 125.6,19.2 126.6,19.2 128.6,19.4 126.9,18.8 126,18.5 125,18.6 124.2,18.3 125.3,17.4 124.7,17 123.9,16.4 122.7,15.3 121.4,14.9 121.4,14.5 118.7,13.9 116.6,13.8 113.9,13.9 111.5,13.9 110.3,13.6 108.6,13 111.2,12.7 113.2,12.6 109,12.4 106.7,12 106.8,11.6 110.6,11.1 114.3,10.6 114.7,10.2 112,9.9 112.8,9.5 116.3,8.8 117.8,8.7 117.3,8.2 119.7,8 122.8,7.8 125.9,7.8 127,8.1 129.6,7.6 132,7.9 133.4,8 135.5,8.3 133.1,7.8Z",
+	"GM": "M163.2,76.8 163.3,76.4 164.4,76.4 164.6,76.1 164.9,76.1 165.3,76.4 165.6,76.4 166,76.2 166.2,76.5 165.7,76.7 165.3,76.7 164.9,76.5 164.5,76.7 164.3,76.7 164.1,76.9Z",
+	"GN": "M171.6,82.3 171.3,82.3 171.1,82.7 170.8,82.7 170.6,82.5 170.7,82.1 170.2,81.5 170,81.6 169.8,81.6 169.5,81.7 169.5,81.3 169.3,81 169.4,80.7 169.2,80.3 168.9,80 168.1,80 167.8,80.1 167.6,80.2 167.4,80.4 167.3,80.7 166.8,81.1 166.3,80.5 165.9,80.1 165.7,80 165.4,79.8 165.3,79.3 165.2,79.1 164.9,79 165.3,78.5 165.6,78.5 165.9,78.3 166.1,78.3 166.3,78.2 166.2,77.9 166.3,77.8 166.3,77.4 166.8,77.4 167.5,77.7 167.7,77.6 167.8,77.5 168.3,77.6 168.5,77.6 168.5,77.9 168.7,77.9 169,77.8 169.1,77.8 169.4,78.1 169.8,78.2 170.1,77.9 170.4,77.8 170.7,77.7 170.9,77.7 171.1,77.9 171.2,78.2 171.6,78.6 171.4,78.9 171.4,79.2 171.6,79.1 171.7,79.2 171.7,79.5 172,79.8 171.8,79.9 171.7,80.2 171.9,80.6 172.2,81.4 171.8,81.5 171.7,81.7 171.8,81.9 171.7,82.3Z",
+	"GQ": "M189.5,89 189.3,88.8 189.6,87.7 191.3,87.7 191.3,88.9 189.8,88.9Z",
+	"GR": "M203.7,54.3 204.2,54.6 205,54.6 205.8,54.6 205.7,54.8 206.3,54.7 206.2,55 204.7,55.1 204.7,54.9 203.5,54.7ZM206.6,48.4 206.3,49.1 206.1,49.2 205.4,49.1 204.9,49.1 203.7,49.3 204.4,49.9 203.9,50 203.3,50 202.8,49.5 202.6,49.7 202.8,50.3 203.4,50.8 203,51 203.5,51.5 204,51.8 204,52.3 203.1,52.1 203.4,52.6 202.8,52.7 203.2,53.6 202.5,53.6 201.7,53.2 201.3,52.4 201.1,51.7 200.7,51.2 200.2,50.7 200.2,50.4 200.6,49.9 200.7,49.6 201,49.4 201,49.2 201.7,49.1 202.1,48.9 202.6,48.9 202.8,48.7 203,48.7 203.7,48.7 204.5,48.4 205.2,48.8 206.1,48.7 206.1,48.2Z",
+	"GT": "M89.9,76.3 89.4,76.1 88.8,76.1 88.3,75.9 87.8,75.5 87.8,75.2 87.9,74.9 87.8,74.7 88.3,73.9 89.5,73.9 89.6,73.6 89.4,73.5 89.3,73.3 88.9,73.1 88.5,72.7 89,72.7 89,72.2 89.9,72.2 90.9,72.2 90.8,73 90.8,74.1 91.1,74.1 91.4,74.3 91.5,74.1 91.8,74.3 91.3,74.7 90.8,74.9 90.8,75.1 90.9,75.3 90.6,75.6 90.4,75.6 90.5,75.8 90.3,75.9 89.9,76.1Z",
+	"GW": "M164.9,79 164.3,78.5 163.9,78.5 163.7,78.2 163.7,78 163.4,77.8 163.3,77.6 163.9,77.5 164.2,77.5 164.5,77.4 166.3,77.4 166.3,77.8 166.2,77.9 166.3,78.2 166.1,78.3 165.9,78.3 165.6,78.5 165.3,78.5Z",
+	"GY": "M120.2,81.6 120.9,82 121.5,82.7 121.5,83.2 121.9,83.2 122.5,83.7 122.9,84 122.7,84.9 122.1,85.2 122.1,85.4 122,85.9 122.4,86.7 122.7,86.7 122.8,87.2 123.5,88.1 123.2,88.1 122.7,88.1 122.3,88.3 121.9,88.5 121.6,88.5 121.5,88.7 121,88.7 120.4,88.2 120.3,87.8 120,87.2 120.2,86.4 120.5,86 120.2,85.6 119.9,85.4 120,85 119.8,84.8 119.3,84.8 118.6,84 118.9,83.8 118.8,83.3 119.5,83.1 119.7,83 119.4,82.6 119.4,82.2Z",
+	"HN": "M92.7,77 92.5,76.7 92.2,76.6 92.3,76.2 92.1,76.1 91.9,76 91.5,76.2 91.5,76 91.2,75.9 90.9,75.7 90.6,75.6 90.9,75.3 90.8,75.1 90.8,74.9 91.3,74.7 91.8,74.3 91.9,74.3 92.1,74.1 92.4,74.1 92.5,74.2 92.6,74.2 93.1,74.2 93.6,74.2 93.9,74.1 94,74 94.3,74 94.6,74.1 94.8,74.1 95,74 95.5,74.1 95.6,74.2 95.9,74.4 96.2,74.6 96.6,74.7 96.9,75 96.5,75 96.4,75.1 96,75.3 95.8,75.3 95.6,75.4 95.4,75.3 95.2,75.2 95.1,75.2 94.9,75.4 94.8,75.6 94.5,75.9 94.3,76 94.2,76.2 93.9,76 93.7,76.2 93.5,76.2 93.2,76.2 93.3,76.7 93.1,76.7 93,77Z",
+	"HR": "M198.8,44.1 199.1,44.5 199.4,44.8 199,45.1 198.6,44.9 197.9,44.9 197,44.8 196.5,44.8 196.3,45 196,44.8 195.8,45.2 196.2,45.6 196.5,46 196.9,46.3 197.3,46.6 197.7,47 198.6,47.4 198.5,47.5 197.5,47.2 196.9,46.8 196,46.5 195.2,45.8 195.4,45.7 194.9,45.3 194.9,44.9 194.3,44.8 194,45.2 193.7,44.9 193.7,44.5 194.4,44.5 194.6,44.4 194.9,44.5 195.3,44.5 195.3,44.3 195.7,44.2 195.8,43.8 196.6,43.5 196.9,43.6 197.6,44 198.5,44.2Z",
+	"HT": "M106.8,70.1 107.4,70.1 108.3,70.3 108.4,70.8 108.3,71.2 108.1,71.4 108.3,71.7 108.3,72 107.6,71.8 107.2,71.9 106.5,71.8 106.1,72 105.5,71.7 105.6,71.3 106.6,71.5 107.3,71.6 107.7,71.3 107.2,70.9 107.2,70.5 106.6,70.4Z",
+	"HU": "M196.2,43.1 196.5,42.5 196.3,42.3 196.9,42.3 197,41.9 197.5,42.1 197.9,42.2 198.7,42.1 198.8,41.9 199.2,41.9 199.7,41.7 199.8,41.8 200.2,41.7 200.5,41.4 200.8,41.4 201.9,41.7 202.1,41.6 202.6,41.8 202.7,42.1 202.1,42.3 201.6,43 201,43.7 200.2,43.9 199.6,43.8 198.8,44.1 198.5,44.2 197.6,44 196.9,43.6 196.6,43.5 196.4,43.2Z",
+	"ID": "M300.7,100.2 300.3,100.3 299,99.6 299.9,99.4 300.4,99.7 300.8,100ZM304.4,100.1 303.6,100.4 303.5,100.2 303.6,99.9 304,99.3 305,98.9 305.1,99.1 305.1,99.4ZM297.9,98.1 298.3,98.4 298.9,98.3 299.1,98.7 298,98.9 297.3,99 296.7,99 297.1,98.5 297.6,98.4ZM302.9,98.1 302.8,98.6 301.3,98.9 299.9,98.8 299.9,98.4 300.7,98.2 301.3,98.5 302,98.5ZM288.6,96.8 290.5,96.9 290.8,96.5 292.6,96.9 293,97.6 294.5,97.8 295.7,98.4 294.6,98.8 293.5,98.3 292.6,98.4 291.5,98.3 290.6,98.1 289.4,97.7 288.7,97.6 288.3,97.8 286.5,97.4 286.3,96.9 285.4,96.9 286.1,95.9 287.3,96 288.1,96.3 288.5,96.4ZM314.7,96.2 314.2,96.9 314.1,96.1 314.3,95.8 314.5,95.4 314.7,95.7ZM307.2,93.5 306.9,93.8 306.2,93.6 306,93.2 307,93.1ZM310.5,93.1 310.8,93.9 310,93.4 309.2,93.4 308.6,93.4 307.9,93.4 308.1,92.8 309.4,92.8ZM314.1,91.2 314.4,92.8 315.5,93.4 316.3,92.3 317.4,91.7 318.3,91.7 319.2,92.1 319.9,92.4 321,92.6 321,95.9 321,99.1 320.1,98.3 319.1,98.1 318.9,98.4 317.6,98.4 318,97.6 318.7,97.3 318.4,96.2 317.9,95.4 316,94.5 315.2,94.5 313.7,93.5 313.4,94 313,94.1 312.8,93.7 312.8,93.3 312,92.8 313.1,92.5 313.8,92.5 313.7,92.2 312.2,92.2 311.8,91.6 310.9,91.4 310.5,90.9 311.9,90.7 312.4,90.4 314,90.8ZM305.2,88.6 304.4,89.6 303.7,89.8 302.7,89.6 301.1,89.6 300.2,89.8 300,90.5 300.9,91.4 301.5,91 303.3,90.6 303.3,91.1 302.8,90.9 302.4,91.5 301.5,91.9 302.5,93.2 302.3,93.5 303.2,94.7 303.2,95.3 302.6,95.6 302.2,95.3 302.7,94.5 301.7,94.9 301.5,94.6 301.6,94.2 300.9,93.6 301,92.6 300.3,92.9 300.4,94.1 300.4,95.5 299.8,95.7 299.4,95.4 299.7,94.5 299.5,93.5 299.1,93.5 298.8,92.8 299.2,92.1 299.3,91.4 299.8,89.8 300,89.4 300.9,88.7 301.7,89 302.9,89.1 304.1,89.1 305.1,88.4ZM308.7,88.9 308.6,89.7 308.1,89.6 308,90.3 308.4,90.8 308.1,90.9 307.7,90.3 307.4,89 307.6,88.2 307.9,87.8 308,88.4 308.6,88.5ZM297.9,88.2 299,89.1 297.8,89.2 297.5,89.9 297.5,90.8 296.6,91.5 296.5,92.5 296.1,94 296,93.7 294.9,94.1 294.5,93.5 293.8,93.4 293.3,93.1 292.1,93.5 291.7,93 291,93 290.2,92.9 290.1,91.6 289.6,91.3 289.1,90.5 289,89.6 289.1,88.7 289.7,88 289.8,88.7 290.5,89.2 291.2,89 291.8,89.1 292.4,88.6 292.9,88.5 293.8,88.8 294.6,88.6 295.1,87.2 295.5,86.8 295.9,85.7 297,85.7 297.9,85.9 297.3,86.8 298,87.7ZM285.8,95.9 284.7,95.9 283.9,95 282.6,94.2 282.2,93.6 281.4,92.8 280.9,92.1 280.1,90.7 279.3,89.8 279,89 278.6,88.2 277.7,87.5 277.2,86.7 276.4,86.1 275.4,85 275.3,84.5 275.9,84.6 277.5,84.8 278.4,85.7 279.1,86.4 279.7,86.8 280.6,87.9 281.7,87.9 282.5,88.6 283.1,89.4 283.8,89.9 283.4,90.7 284,91.1 284.4,91.1 284.5,91.8 284.9,92.3 285.6,92.4 286.1,93.1 285.9,94.3Z",
+	"IE": "M173.8,36.1 174,36.8 173.2,37.7 171.4,38.3 170,38.2 170.8,37.1 170.3,36.1 171.7,35.3 172.4,34.9 172.6,35.4 172.4,35.9 173,35.9Z",
+	"IL": "M215.7,57.3 215.5,57.6 215.2,57.5 215,58.1 215.2,58.2 215,58.4 214.9,58.6 215.4,58.5 215.4,58.9 214.9,60.5 214.3,58.8 214.6,58.5 214.5,58.4 214.8,57.9 215,57.2 215.1,56.9 215.5,56.9 215.6,56.7 215.8,56.7 215.8,57.1Z",
+	"IN": "M257.8,54.5 258.9,55.7 258.8,56.5 259.2,57 259.2,57.5 258.5,57.4 258.7,58.5 259.7,59.1 261.1,59.8 260.5,60.3 260.1,61.2 261.1,61.6 262,62.1 263.3,62.6 264.7,62.8 265.3,63.3 266,63.4 267.2,63.6 268.1,63.6 268.2,63.2 268,62.6 268.1,62.1 268.7,61.9 268.8,62.7 268.8,62.9 269.7,63.3 270.4,63.1 271.2,63.2 272,63.2 272.1,62.5 271.7,62.2 272.5,62.1 273.4,61.4 274.6,60.7 275.4,61 276.1,60.5 276.6,61.2 276.2,61.6 277.3,61.7 277.4,62.1 277.1,62.3 277.1,62.9 276.4,62.7 275.1,63.4 275.2,64 274.6,64.8 274.6,65.3 274.1,66.1 273.3,65.9 273.3,67 273.1,67.3 273.2,67.7 272.7,68 272.1,66.4 271.9,66.4 271.7,67 271.2,66.5 271.5,65.9 271.9,65.9 272.4,65 271.8,64.9 270.9,64.9 269.9,64.7 269.8,64 269.4,64 268.6,63.6 268.2,64.2 268.9,64.8 268.3,65.1 268.1,65.5 268.7,65.8 268.5,66.4 268.9,67.1 269,67.9 268.9,68.3 268.2,68.3 267,68.5 267,69.3 266.5,69.8 265.1,70.5 263.9,71.7 263.2,72.3 262.2,73 262.2,73.4 261.7,73.7 260.8,74 260.3,74.1 260,74.9 260.2,76.2 260.3,77 259.9,77.9 259.9,79.6 259.3,79.7 258.9,80.5 259.2,80.8 258.3,81.1 257.9,81.7 257.5,82 256.6,81.1 256.1,79.7 255.7,78.7 255.4,78.2 254.9,77.3 254.6,76 254.4,75.4 253.5,74 253.1,72.1 252.8,70.8 252.8,69.6 252.6,68.6 251.2,69.2 250.5,69.1 249.2,67.9 249.6,67.5 249.3,67.2 248.2,66.3 248.8,65.6 251,65.6 250.8,64.8 250.3,64.3 250.2,63.5 249.5,63.1 250.6,62 251.8,62.1 252.8,61 253.5,60 254.4,59 254.4,58.3 255.3,57.7 254.5,57.2 254.1,56.6 253.7,55.7 254.2,55.3 255.8,55.5 256.9,55.3Z",
+	"IQ": "M225.4,54 226.1,54.3 226.2,54.9 225.6,55.3 225.4,56 226.1,57 227.3,57.5 227.8,58.3 227.7,59 228,59 228,59.5 228.6,60.1 228,60 227.3,59.9 226.6,60.9 224.7,60.8 221.9,58.8 220.4,58.1 219.2,57.8 218.8,56.6 221,55.6 221.4,54.4 221.3,53.6 221.8,53.4 222.3,52.8 222.8,52.6 223.9,52.7 224.3,53 224.8,52.8Z",
+	"IR": "M233.9,52.8 234.8,52.6 235.5,52 236.2,52.1 236.6,51.9 237.3,52 238.4,52.5 239.2,52.6 240.4,53.5 241.1,53.5 241.2,54.3 240.8,55.6 240.5,56.3 241,56.5 240.5,57 240.9,57.8 240.9,58.5 241.7,58.6 241.8,59.3 240.9,60.2 241.4,60.7 241.8,61.3 242.7,61.7 242.8,62.6 243.2,62.8 243.3,63.2 241.9,63.8 241.5,64.9 239.6,64.6 238.5,64.4 237.4,64.3 237,63 236.5,62.9 235.7,63 234.7,63.5 233.5,63.2 232.5,62.4 231.5,62.1 230.9,61.2 230.1,59.9 229.6,60 228.9,59.7 228.6,60.1 228,59.5 228,59 227.7,59 227.8,58.3 227.3,57.5 226.1,57 225.4,56 225.6,55.3 226.2,54.9 226.1,54.3 225.4,54 224.8,52.8 224.2,52 224.4,51.7 224.1,50.6 224.8,50.3 225,50.7 225.5,51.1 226.1,51.3 226.5,51.2 227.7,50.5 228.1,50.4 228.4,50.7 228,51.2 228.6,51.7 228.9,51.7 229.2,52.4 230.1,52.6 230.8,53.1 232.3,53.3 233.8,53Z",
+	"IS": "M165.5,23.5 165.3,24.2 166.4,24.9 165.1,25.6 162.2,26.3 161.3,26.5 160,26.4 157.2,26 158.2,25.6 156,25.1 157.8,24.9 157.8,24.6 155.7,24.4 156.3,23.7 157.9,23.6 159.4,24.3 160.9,23.7 162.2,24 163.8,23.5Z",
+	"IT": "M195.5,51.8 195.2,52.6 195.3,52.9 195.1,53.4 194.3,53 193.8,52.9 192.4,52.4 192.6,51.9 193.7,52 194.8,51.9ZM189.2,48.8 189.8,49.5 189.7,50.8 189.2,50.8 188.8,51.1 188.4,50.8 188.4,49.6 188.2,49 188.7,49.1ZM192.4,43.2 193.8,43.5 193.7,44 193.9,44.4 193.1,44.3 192.3,44.6 192.4,45.1 192.3,45.4 192.6,45.9 193.5,46.4 194,47.2 195.1,48 195.9,48 196.2,48.3 195.9,48.5 196.8,48.8 197.5,49.1 198.4,49.6 198.5,49.8 198.3,50.2 197.7,49.7 196.9,49.6 196.4,50.2 197.2,50.6 197.1,51.1 196.6,51.2 196.1,52 195.7,52.1 195.7,51.8 195.9,51.2 196.1,51 195.7,50.5 195.4,50 195,49.8 194.7,49.4 194.1,49.2 193.6,48.8 192.9,48.7 192.1,48.3 191.2,47.6 190.5,47.1 190.2,46.1 189.7,46 188.9,45.6 188.4,45.8 187.9,46.2 187.4,46.3 187.5,45.9 187,45.7 186.7,45 187.1,44.7 186.8,44.3 186.8,44 187.3,44.2 187.8,44.2 188.3,43.8 188.5,44 189,44 189.2,43.6 189.9,43.7 190.4,43.5 190.4,43.1 191,43.2 191.2,43.1 192.2,42.9Z",
+	"JM": "M102.4,71.5 103.1,71.6 103.6,71.8 103.8,72.1 103.1,72.1 102.8,72.3 102.2,72.1 101.7,71.8 101.8,71.5 102.2,71.5Z",
+	"JO": "M215.5,57.6 215.7,57.3 216.8,57.7 218.8,56.6 219.2,57.8 219,58 217,58.5 218,59.5 217.7,59.7 217.5,60 216.7,60.1 216.5,60.5 216.1,60.8 215,60.6 214.9,60.5 215.4,58.9 215.4,58.5 215.5,58.2Z",
+	"JP": "M314.6,55.9 314.8,56.2 314.2,56.8 313.8,56.5 313.3,56.7 313,57.3 312.4,57 312.4,56.5 312.9,55.9 313.5,56.1 313.9,55.6ZM321,52.9 320.6,53.7 320.8,54.2 320.3,54.9 319,55.3 317.2,55.4 315.8,56.5 315.1,56.2 315.1,55.4 313.3,55.6 312.2,56.1 311,56.1 312,56.9 311.3,58.5 310.7,59 310.2,58.6 310.4,57.7 309.8,57.4 309.4,56.7 310.4,56.4 310.9,55.8 311.9,55.3 312.6,54.6 314.6,54.3 315.7,54.5 316.7,52.7 317.4,53.2 318.9,52.2 319.4,51.8 320.1,50.6 319.9,49.4 320.3,48.8 321.4,48.6 321.9,50 321.9,50.8 321,51.8ZM323.9,45.8 324.6,46 325.3,45.6 325.5,46.7 324.1,47 323.2,48 321.6,47.3 321.1,48.4 320,48.4 319.8,47.4 320.3,46.7 321.4,46.6 321.7,45.2 322,44.4 323.1,45.5Z",
+	"KE": "M221,90.9 221.6,91.7 220.9,92.1 220.6,92.5 220.3,92.6 220.1,93.3 219.8,93.7 219.6,94.3 219.2,94.7 217.8,93.7 217.7,93.1 214.1,91.1 213.9,91 213.9,89.9 214.2,89.5 214.7,88.8 215,88.1 214.6,86.9 214.5,86.4 214,85.8 214.6,85.2 215.3,84.5 215.8,84.7 215.8,85.2 216.2,85.6 216.9,85.6 218.1,86.4 218.4,86.4 218.7,86.4 218.9,86.5 219.6,86.6 219.9,86.2 220.8,85.7 221.2,86.1 221.9,86.1 221,87.2Z",
+	"KG": "M251,47.7 251.2,47.3 251.8,47.2 253.5,47.5 253.6,46.9 254.2,46.7 255.6,47.1 256,47 257.7,47 259.1,47.1 259.6,47.5 260.3,47.7 260.1,47.9 258.5,48.4 258.2,48.8 256.9,48.9 256.5,49.6 255.5,49.4 254.8,49.6 253.8,50.1 254,50.3 253.7,50.6 251.8,50.7 250.5,50.4 249.5,50.5 249.6,49.9 250.6,50.1 251,49.8 251.8,49.9 253.1,49.1 251.9,48.6 251.2,48.9 250.4,48.5 251.3,47.8Z",
+	"KH": "M283.5,79.4 283.1,78.8 282.6,77.8 282.3,76.6 283,75.8 284.3,75.6 285.2,75.7 286,76.1 286.5,75.4 287.4,75.8 287.6,76.5 287.5,77.7 285.8,78.4 286.2,79 285.2,79.1 284.3,79.5Z",
+	"KP": "M310.6,47.6 310.8,47.8 310.4,47.7 310,48.1 309.7,48.4 309.7,49.1 309.2,49.3 309,49.5 308.6,49.8 308,50 307.5,50.2 307.5,50.7 307.4,50.8 307.8,50.9 308.3,51.4 308.2,51.6 307.8,51.7 307.1,51.7 306.7,52.2 306.2,52.2 306.2,52.3 305.7,52.1 305.6,52.2 305.3,52.3 305.2,52.1 305,52.1 304.7,51.9 305,51.5 305.2,51.3 305.1,51.2 305.4,50.6 305.3,50.4 304.7,50.3 304.3,50.1 305.1,49.4 306.2,48.9 306.9,48.2 307.3,48.5 308.2,48.5 308.1,48 309.6,47.6 310,47Z",
+	"KR": "M308.3,51.4 309.2,52.6 309.5,53.2 309.5,54.4 309.1,54.9 308.2,55.1 307.4,55.5 306.5,55.6 306.4,55.1 306.6,54.3 306.1,53.3 306.9,53.1 306.2,52.3 306.2,52.2 306.7,52.2 307.1,51.7 307.8,51.7 308.2,51.6Z",
+	"KW": "M228,60 228.2,60.5 228.1,60.7 228.4,61.4 227.7,61.5 227.5,61 226.6,60.9 227.3,59.9Z",
+	"KZ": "M251,47.7 250.4,47.9 249.1,48.6 248.6,49.3 248.3,49.3 248,48.9 246.7,48.8 246.5,48 246,48 246.1,47 244.9,46.3 243.2,46.3 242,46.5 241.1,45.6 240.2,45.2 238.7,44.5 238.5,44.4 235.9,45 236,48.7 235.5,48.7 234.8,48 234.1,47.7 232.9,47.9 232.5,48.2 232.4,48 232.7,47.6 232.5,47.2 231.3,46.9 230.9,46 230.3,45.7 230.3,45.4 231.3,45.5 231.3,44.8 232.2,44.6 233,44.7 233.2,43.8 233,43.1 232,43.2 231.2,43 230,43.4 229.1,43.6 228.6,43.4 228.7,42.9 228.1,42.3 227.3,42.3 226.5,41.6 227,40.8 226.8,40.6 227.5,39.5 228.6,40.1 228.7,39.4 230.8,38.3 232.3,38.3 234.5,39 235.7,39.4 236.8,39 238.4,38.9 239.6,39.5 239.9,39.2 241.3,39.2 241.6,38.7 240,38 240.9,37.6 240.7,37.3 241.7,37 241,36.3 241.4,36 245.2,35.6 245.7,35.4 248.2,35 249.1,34.6 250.9,34.8 251.2,35.9 252.2,35.6 253.5,36 253.4,36.5 254.4,36.5 256.9,35.5 256.5,35.8 257.8,36.6 260,39.1 260.6,38.6 261.9,39.2 263.4,38.9 263.9,39.1 264.4,39.7 265.1,39.9 265.5,40.3 266.8,40.2 267.4,40.8 266.6,41.5 265.8,41.5 265.7,42.5 265.2,43 263.2,42.7 262.5,44.5 261.9,44.7 260,45.1 260.9,46.8 260.2,47.1 260.3,47.7 259.6,47.5 259.1,47.1 257.7,47 256,47 255.6,47.1 254.2,46.7 253.6,46.9 253.5,47.5 251.8,47.2 251.2,47.3Z",
+	"LA": "M285.2,75.7 285.5,75.3 285.6,74.4 284.8,73.6 284.7,72.6 284,71.8 283.2,71.7 283,72 282.4,72.1 282.1,71.9 281.1,72.5 281,71.6 281.3,70.5 280.6,70.5 280.5,69.9 280.1,69.6 280.3,69.2 281.2,68.6 281.3,68.8 281.8,68.8 281.7,67.7 282.2,67.5 282.8,68.3 283.2,69.2 284.4,69.2 284.8,70.1 284.2,70.4 283.9,70.7 285.1,71.3 285.9,72.5 286.6,73.4 287.3,74.1 287.6,74.8 287.4,75.8 286.5,75.4 286,76.1Z",
+	"LB": "M215.8,56.7 215.6,56.7 215.5,56.9 215.1,56.9 215.5,56.1 216,55.4 216.4,55.4 216.6,55.8 216.1,56.2Z",
+	"LK": "M261.8,82.5 261.6,83.5 261.2,83.8 260.3,84 259.9,83.2 259.7,81.8 260.1,80.2 260.8,80.7 261.3,81.4Z",
+	"LR": "M172.3,85.6 172,85.6 171,85.2 170.1,84.4 169.2,83.9 168.6,83.2 168.8,82.9 168.9,82.6 169.3,82.1 169.8,81.6 170,81.6 170.2,81.5 170.7,82.1 170.6,82.5 170.8,82.7 171.1,82.7 171.3,82.3 171.6,82.3 171.5,82.6 171.6,83.1 171.4,83.5 171.7,83.8 172,83.9 172.4,84.3 172.5,84.7 172.4,84.8Z",
+	"LS": "M209,119 209.3,119.3 209,119.7 208.8,120.1 208.3,120.2 208.1,120.5 207.7,120.6 207,119.9 207.5,119.2 208.1,118.9 208.5,118.6Z",
+	"LT": "M202.7,35.7 202.7,35.4 202.8,35.1 202.3,35 201.3,34.8 201.1,34 202.2,33.7 203.9,33.7 204.9,33.6 205,33.8 205.5,33.9 206.5,34.4 206.6,34.8 205.8,35.2 205.5,35.7 204.5,36.1 203.5,36.1 203.2,35.8Z",
+	"LU": "M186,39.9 186.2,40.1 186.2,40.5 185.9,40.6 185.7,40.5 185.8,39.9Z",
+	"LV": "M201.1,34 201.1,33.2 201.6,32.6 202.5,32.2 203.3,33 204.1,33 204.3,32.2 205.2,32 205.6,32.2 206.5,32.5 207.3,32.5 207.8,32.8 207.9,33.2 208.2,33.8 207.1,34.2 206.5,34.4 205.5,33.9 205,33.8 204.9,33.6 203.9,33.7 202.2,33.7Z",
+	"LY": "M194.9,67.1 194.1,67.5 193.6,67 192,66.5 191.6,65.9 190.8,65.4 190.3,65.6 189.9,65.1 189.9,64.6 189.3,63.9 189.7,63.5 189.6,62.9 189.8,62.3 189.7,61.9 189.9,61 189.8,60.6 189.5,59.7 190,59.5 190.1,59 190,58.6 190.6,58.2 190.9,57.9 191.4,57.6 191.5,56.9 192.7,57.2 193.1,57.1 193.9,57.3 195.2,57.7 195.7,58.6 196.6,58.8 198,59.2 199.1,59.7 199.6,59.5 200.1,59 199.8,58.2 200.1,57.8 200.9,57.3 201.5,57.2 202.9,57.4 203.2,57.8 203.6,57.8 203.9,58 204.9,58.1 205.2,58.4 204.8,58.9 205,59.3 204.7,60 205,60.8 205,64.3 205,68 205,70 203.9,70 203.8,70.4 199.8,68.5 195.9,66.6Z",
+	"MA": "M174.8,54.2 175.4,54.7 176.4,54.6 177.4,54.8 177.8,54.8 178.2,55.5 178.3,56.1 178.6,57.1 178.9,57.3 178.7,57.7 177.4,57.9 176.9,58.3 176.4,58.4 176.3,59.1 175.1,59.5 174.8,60 173.9,60.3 172.9,60.4 171.3,61.2 171.3,62.3 171.2,62.3 171.2,62.9 170.6,62.9 170.3,63.1 169.8,63.1 169.4,63 168.6,63.1 168.3,63.9 168,64 167.5,65.2 166.1,66.3 165.8,67.7 165.4,68.1 165.2,68.5 163,68.6 163,68.1 163.4,67.8 163.7,67.3 163.7,67 164,66.3 164.6,65.6 164.9,65.5 165.2,64.9 165.2,64.4 165.6,63.7 166.2,63.4 166.9,62.4 166.9,62.3 167.4,62 168.3,61.9 169.1,61.2 169.6,60.9 170.4,60.1 170.2,58.8 170.6,58 170.7,57.4 171.3,56.8 172.3,56.3 173.1,55.9 173.8,54.9 174.1,54.2Z",
+	"MD": "M206.6,41.8 206.9,41.6 207.5,41.5 208.3,41.8 208.7,41.9 209.1,42.2 209.1,42.5 209.4,42.7 209.6,43.1 209.9,43.3 209.8,43.5 210,43.6 209.8,43.7 209.2,43.6 209.1,43.5 208.9,43.6 208.9,43.7 208.7,44.1 208.5,44.4 208.2,44.5 208.1,44.1 208.2,43.6 208.1,43.2 207.6,42.6 207.2,42.2 206.9,41.9Z",
+	"ME": "M199.8,47.5 199.7,47.3 199.3,47.8 199.4,48.1 199.2,48 198.9,47.7 198.4,47.5 198.6,47.4 198.7,46.8 199,46.6 199.2,46.5 199.5,46.6 199.6,46.8 200,46.9 200.3,47.1 200.3,47.2 200.1,47.4Z",
+	"MG": "M229.5,102.5 229.8,102.9 230.1,103.6 230.2,104.8 230.5,105.2 230.4,105.7 230.2,106 229.9,105.4 229.7,105.7 229.9,106.5 229.8,106.9 229.5,107.1 229.4,108 229,109.1 228.5,110.5 227.9,112.4 227.5,113.8 227.1,114.9 226.3,115.2 225.4,115.6 224.8,115.3 224,115 223.8,114.5 223.7,113.6 223.3,112.8 223.3,112.1 223.4,111.3 223.9,111.2 223.9,110.8 224.4,110.1 224.5,109.4 224.2,109 224,108.3 224,107.4 224.3,106.9 224.4,106.2 224.9,106.2 225.5,106 225.9,105.8 226.3,105.8 226.9,105.2 227.7,104.6 228,104.1 227.9,103.7 228.3,103.8 228.8,103.1 228.9,102.5 229.2,102Z",
+	"MK": "M200.6,48.1 200.7,48.2 200.8,47.9 201.4,47.8 201.6,47.8 201.9,47.7 202.4,47.7 202.9,48 203,48.7 202.8,48.7 202.6,48.9 202.1,48.9 201.7,49.1 201,49.2 200.6,48.9 200.5,48.5Z",
+	"ML": "M167.8,75.4 168.2,75.2 168.3,74.6 168.7,74.6 169.3,74.9 169.9,74.7 170.3,74.7 170.4,74.5 174.5,74.5 174.7,73.8 174.5,73.7 174,69.4 173.5,65 175.1,65 178.4,67.2 181.8,69.4 182.1,69.9 182.7,70.1 183.1,70.3 183.2,70.9 184.3,70.8 184.3,73.1 183.7,73.8 183.6,74.4 182.7,74.6 181.4,74.7 181,75 180.4,75.1 179.7,75.1 179.5,74.9 178.9,75 178,75.4 177.8,75.8 177,76.2 176.9,76.5 176.5,76.7 176,76.5 175.7,76.8 175.6,77.5 174.8,78.3 174.8,78.6 174.5,79 174.6,79.6 174.2,79.8 173.9,79.9 173.8,79.5 173.5,79.6 173.3,79.6 173.1,79.9 172.4,79.9 172.1,79.7 172,79.8 171.7,79.5 171.7,79.2 171.6,79.1 171.4,79.2 171.4,78.9 171.6,78.6 171.2,78.2 171.1,77.9 170.9,77.7 170.7,77.7 170.4,77.8 170.1,77.9 169.8,78.2 169.4,78.1 169.1,77.8 169,77.8 168.7,77.9 168.5,77.9 168.5,77.6 168.5,77.2 168.4,76.9 168.1,76.6 167.9,76Z",
+	"MM": "M279.5,69.8 279,70.2 278.3,70.3 277.8,71.4 277.4,71.6 277.9,72.4 278.5,73.2 278.9,73.8 278.5,74.7 278.2,74.9 278.4,75.4 279.1,76.2 279.2,76.7 279.2,77.2 279.6,78.1 279,79 278.6,80.1 278.5,79.3 278.8,78.6 278.4,78 278.5,76.9 278.1,76.4 277.8,75.2 277.6,73.9 277.2,73.1 276.5,73.6 275.4,74.3 274.8,74.2 274.2,74 274.5,72.7 274.3,71.8 273.5,70.6 273.7,70.3 273.1,70.1 272.4,69.3 272.3,68.5 272.7,68.7 272.7,68 273.2,67.7 273.1,67.3 273.3,67 273.3,65.9 274.1,66.1 274.6,65.3 274.6,64.8 275.2,64 275.1,63.4 276.4,62.7 277.1,62.9 277.1,62.3 277.4,62.1 277.3,61.7 277.9,61.7 278.2,62.3 278.7,62.5 278.7,63.3 278.7,64.1 277.7,64.9 277.6,66.1 278.7,65.9 278.9,66.9 279.5,67.1 279.2,67.9 280,68.3 280.4,68.4 281.2,68.2 281.2,68.6 280.3,69.2 280.1,69.6Z",
+	"MN": "M267.8,40.7 268.8,40.5 270.7,39.7 272.2,39.2 273.1,39.5 274.1,39.5 274.8,40 275.8,40 277.3,40.3 278.2,39.6 277.8,39 278.9,38 280,38.4 280.9,38.5 282.1,38.7 282.3,39.5 283.7,39.9 284.6,39.7 285.9,39.6 286.9,39.7 287.9,40.2 288.5,40.7 289.4,40.7 290.7,40.9 291.6,40.6 292.9,40.5 294.4,39.8 295,39.9 295.5,40.2 296.7,40.1 296.2,40.9 295.5,41.9 295.7,42.3 296.3,42.1 297.3,42.3 298.1,41.9 298.9,42.3 299.8,43 299.7,43.3 298.9,43.2 297.4,43.3 296.7,43.6 296,44.3 294.5,44.7 293.5,45.2 292.4,45 291.9,44.9 291.3,45.5 291.7,45.9 291.8,46.3 291.1,46.6 290.4,47.1 289.2,47.5 287.7,47.5 286.1,47.9 285,48.4 284.5,48.1 283.3,48.1 281.8,47.5 280.8,47.3 279.5,47.5 277.5,47.3 276.3,47.3 275.8,46.7 275.3,45.8 274.7,45.6 273.5,45 272.1,44.9 270.9,44.7 270.6,44.3 271,43.1 270.3,42.3 268.9,41.9 268,41.4Z",
+	"MR": "M167.8,75.4 167.2,74.7 166.6,74 165.9,73.7 165.4,73.4 164.9,73.4 164.4,73.6 163.9,73.5 163.5,73.9 163.5,73.3 163.7,72.8 163.9,71.9 163.7,70.9 163.6,70.4 163.7,69.9 163.5,69.4 162.9,69 163.2,68.7 167.1,68.7 166.9,67.2 167.1,66.7 168.1,66.6 168,64.1 171.3,64.1 171.3,62.6 175.1,65 173.5,65 174,69.4 174.5,73.7 174.7,73.8 174.5,74.5 170.4,74.5 170.3,74.7 169.9,74.7 169.3,74.9 168.7,74.6 168.3,74.6 168.2,75.2Z",
+	"MW": "M214.6,101.5 214.3,102.3 214.6,103.6 214.9,103.6 215.3,103.9 215.7,104.6 215.8,105.9 215.3,106.1 215,106.8 214.4,106.2 214.3,105.5 214.5,105 214.5,104.6 214.1,104.4 213.8,104.5 213.2,104 212.7,103.7 213,102.8 213.3,102.4 213.1,101.6 213.3,100.8 213.5,100.5 213.2,99.7 212.8,99.2 213.7,99.4 213.9,99.7 214.3,100.2Z",
+	"MX": "M82.9,64.1 82.5,65 82.3,65.7 82.2,67.1 82.1,67.6 82.3,68.1 82.6,68.6 82.8,69.4 83.5,70.1 83.7,70.7 84.1,71.2 85.2,71.4 85.6,71.9 86.5,71.6 87.2,71.5 88,71.3 88.6,71.1 89.2,70.7 89.5,70.1 89.5,69.3 89.7,69 90.4,68.7 91.5,68.5 92.3,68.5 92.9,68.5 93.2,68.7 93.2,69.2 92.6,69.7 92.4,70.4 92.6,70.5 92.4,71 92.2,71.7 91.9,71.5 91.7,71.5 91.5,71.5 91.2,72.1 91,72 90.8,72 90.9,72.2 89.9,72.2 89,72.2 89,72.7 88.5,72.7 88.9,73.1 89.3,73.3 89.4,73.5 89.6,73.6 89.5,73.9 88.3,73.9 87.8,74.7 87.9,74.9 87.8,75.2 87.8,75.5 86.6,74.4 86.1,74.1 85.3,73.8 84.7,73.9 83.9,74.2 83.4,74.3 82.7,74.1 82,73.9 81.1,73.4 80.3,73.3 79.2,72.8 78.3,72.4 78.1,72.1 77.5,72 76.5,71.7 76.1,71.3 75,70.7 74.5,70.1 74.3,69.6 74.6,69.5 74.5,69.2 74.7,68.9 74.7,68.6 74.4,68.1 74.3,67.7 74,67.2 73.1,66.2 72.1,65.5 71.6,64.8 70.7,64.4 70.6,64.2 70.7,63.6 70.2,63.3 69.6,62.8 69.4,62.1 68.8,62.1 68.2,61.5 67.8,61 67.7,60.7 67.2,60 66.8,59.2 66.9,58.8 66.1,58.4 65.8,58.5 65.2,58.2 65.1,58.6 65.2,59.1 65.3,59.8 65.7,60.2 66.4,60.9 66.6,61.2 66.7,61.2 66.9,61.6 67,61.6 67.2,62.2 67.5,62.5 67.8,62.8 68.4,63.3 68.7,64.3 69,64.7 69.3,65.2 69.3,65.7 69.8,65.7 70.2,66.2 70.6,66.6 70.6,66.8 70.1,67.2 70,67.2 69.7,66.6 69.1,66 68.3,65.5 67.8,65.3 67.9,64.5 67.7,64 67.2,63.7 66.5,63.2 66.4,63.4 66.2,63.1 65.5,62.9 64.9,62.3 65,62.2 65.4,62.3 65.8,61.9 65.8,61.4 65.1,60.7 64.5,60.4 64.1,59.8 63.7,59.2 63.3,58.4 62.9,57.5 64,57.4 65.3,57.3 65.2,57.5 66.7,58 69,58.7 71,58.7 71.8,58.7 71.8,58.2 73.5,58.2 73.9,58.6 74.4,58.9 75,59.4 75.3,59.9 75.5,60.4 76.1,60.7 76.9,61 77.5,60.2 78.3,60.2 79,60.6 79.5,61.3 79.9,61.9 80.5,62.5 80.7,63.2 81,63.6 81.8,63.9 82.5,64.2Z",
+	"MY": "M281.1,83.8 281.2,84.3 281.8,84.2 282.1,83.8 282.4,83.9 283,84.5 283.4,85.1 283.4,85.8 283.3,86.3 283.4,86.6 283.5,87.2 283.9,87.5 284.2,88.4 284.2,88.7 283.5,88.8 282.6,88 281.4,87.2 281.3,86.7 280.7,86.1 280.6,85.2 280.2,84.7 280.3,84 280.1,83.5 280.3,83.4ZM298.6,85.5 297.9,85.9 297,85.7 295.9,85.7 295.5,86.8 295.1,87.2 294.6,88.6 293.8,88.8 292.9,88.5 292.4,88.6 291.8,89.1 291.2,89 290.5,89.2 289.8,88.7 289.7,88 290.4,88.3 291.2,88.1 291.4,87.3 291.8,87.1 293,86.9 293.7,86.1 294.2,85.5 294.7,86 294.9,85.7 295.3,85.7 295.4,85 295.5,84.6 296.2,83.9 296.7,83.1 297.1,83.1 297.6,83.6 297.7,84 298.3,84.3 299.2,84.6 299.1,85 298.4,85Z",
+	"MZ": "M214.6,101.5 215.3,101.4 216.5,101.7 216.8,101.6 217.5,101.6 217.8,101.3 218.4,101.3 219.5,100.9 220.3,100.3 220.5,100.8 220.4,101.8 220.6,102.6 220.6,104.2 220.8,104.7 220.5,105.4 220.1,106.1 219.5,106.7 218.5,107.1 217.4,107.6 216.3,108.7 215.9,108.8 215.2,109.6 214.8,109.8 214.7,110.5 215.2,111.3 215.4,111.8 215.4,112.1 215.6,112.1 215.5,113.1 215.4,113.5 215.6,113.7 215.5,114.1 215,114.5 214.2,114.8 213,115.4 212.6,115.7 212.7,116.1 212.9,116.2 212.8,116.7 212.1,116.7 212,116.3 211.8,115.8 211.8,115.5 211.9,114.4 211.7,113.7 211.2,112.3 212.2,111.1 212.5,110.4 212.7,110.3 212.8,109.7 212.6,109.4 212.7,108.7 212.8,108 212.8,106.7 212.3,106.4 211.9,106.3 211.6,106.1 211.2,105.9 210.3,105.9 210.3,105.5 210.2,104.8 213.2,104 213.8,104.5 214.1,104.4 214.5,104.6 214.5,105 214.3,105.5 214.4,106.2 215,106.8 215.3,106.1 215.8,105.9 215.7,104.6 215.3,103.9 214.9,103.6 214.6,103.6 214.3,102.3Z",
+	"NA": "M196.3,118.6 195.6,117.8 195.2,117.1 195,116.1 194.7,115.4 194.4,113.9 194.4,112.7 194.3,112.1 193.9,111.7 193.4,110.9 192.8,109.7 192.6,109 191.8,108.1 191.7,107.3 192.2,107.1 192.8,106.9 193.5,107 194.1,107.4 194.2,107.4 198.3,107.3 199,107.8 201.4,107.9 203.2,107.5 204,107.3 204.7,107.4 205.1,107.6 205.1,107.7 204.5,107.9 204.2,107.9 203.6,108.3 203.2,107.9 201.7,108.2 200.9,108.3 200.9,111.8 199.9,111.8 199.9,114.8 199.9,118.5 199,119 198.5,119 197.8,118.9 197.4,118.8 197.2,118.4 196.8,118.1Z",
+	"NC": "M345.8,111.1 346.6,111.7 347.1,112.2 346.7,112.4 346.2,112.1 345.5,111.7 344.8,111.1 344.2,110.4 344,110.1 344.5,110.1 345,110.5 345.5,110.8Z",
+	"NE": "M182.2,78.1 182.2,77.4 181,77.1 181,76.7 180.4,76 180.3,75.6 180.4,75.1 181,75 181.4,74.7 182.7,74.6 183.6,74.4 183.7,73.8 184.3,73.1 184.3,70.8 185.7,70.4 188.6,68.4 192,66.5 193.6,67 194.1,67.5 194.9,67.1 195.1,68.7 195.5,69 195.5,69.3 195.9,69.6 195.7,70 195.3,72.1 195.2,73.4 194,74.3 193.5,75.6 194,76 194,76.6 194.6,76.7 194.5,77.1 194.2,77.2 194.2,77.5 194,77.5 193.3,76.4 193.1,76.4 192.3,77 191.5,76.7 191,76.6 190.7,76.8 190.1,76.7 189.5,77.1 189,77.2 187.8,76.7 187.3,76.9 186.8,76.9 186.4,76.5 185.4,76.1 184.4,76.3 184.1,76.5 184,77 183.7,77.4 183.6,78.3 182.8,77.8 182.5,77.8Z",
+	"NG": "M188.5,85.2 187.5,85.6 187.1,85.5 186.7,85.8 185.9,85.7 185.4,85.1 185,84.4 184.3,83.7 183.6,83.7 182.7,83.7 182.7,82.1 182.7,81.5 182.9,80.9 183.2,80.6 183.7,79.9 183.6,79.7 183.8,79.3 183.6,78.7 183.6,78.3 183.7,77.4 184,77 184.1,76.5 184.4,76.3 185.4,76.1 186.4,76.5 186.8,76.9 187.3,76.9 187.8,76.7 189,77.2 189.5,77.1 190.1,76.7 190.7,76.8 191,76.6 191.5,76.7 192.3,77 193.1,76.4 193.3,76.4 194,77.5 194.2,77.5 194.6,77.9 194.5,78.1 194.4,78.4 193.6,79.2 193.3,79.8 193.2,80.4 193,80.6 192.8,81.3 192.2,81.7 192.1,82.2 191.8,82.6 191.7,83 191.1,83.4 190.5,82.9 190.1,83 189.5,83.5 189.2,83.6 188.8,84.5Z",
+	"NI": "M94.3,78.9 93.9,78.6 93.5,78.2 93.3,77.9 92.8,77.5 92.3,77.1 92.4,76.9 92.6,77.1 92.7,77 93,77 93.1,76.7 93.3,76.7 93.2,76.2 93.5,76.2 93.7,76.2 93.9,76 94.2,76.2 94.3,76 94.5,75.9 94.8,75.6 94.9,75.4 95.1,75.2 95.2,75.2 95.4,75.3 95.6,75.4 95.8,75.3 96,75.3 96.4,75.1 96.5,75 96.9,75 96.8,75.1 96.7,75.3 96.8,75.7 96.6,76 96.5,76.4 96.4,76.9 96.5,77.1 96.5,77.6 96.4,77.7 96.3,78.1 96.3,78.4 96.1,78.6 96.2,78.9 96.3,79.1 96.1,79.3 95.8,79.2 95.6,79 95.3,78.9 95.1,79 94.4,78.8Z",
+	"NL": "M186.1,36.5 186.9,36.5 187.1,36.9 186.8,37.8 186.6,38.1 186,38.1 186.2,39.2 185.6,39 185,38.5 184,38.7 183.3,38.7 183.8,38.4 184.7,36.9Z",
+	"NO": "M208.2,18.8 211.3,19.5 210,19.8 211.1,20.4 209.4,20.8 208.6,20.9 209,20.2 207.7,19.8 206.2,20.2 205.7,20.9 204.7,21.4 203.7,21.1 202.4,21.2 201.2,20.6 200.6,20.9 200,20.9 199.9,21.6 198,21.4 197.7,22 196.8,22 196.1,22.7 195.1,23.8 193.6,25.2 193.9,25.6 193.6,26 192.6,25.9 191.9,26.9 192,28.2 192.6,28.7 192.3,29.9 191.5,30.6 191,31.1 190.4,30.5 188.4,31.7 187,31.9 185.7,31.4 185.3,30.3 185,28 185.9,27.4 188.6,26.5 190.5,25.5 192.4,24.1 194.8,22.2 196.4,21.4 199.2,20.2 201.4,19.7 203,19.8 204.5,19 206.4,19ZM204.7,12.1 202.5,12.6 200.7,12.3 201.4,12.1 200.8,11.7 202.9,11.5 203.3,11.9ZM198.3,10.3 201.5,11 199,11.4 198.5,12.2 197.6,12.4 197.1,13.2 195.9,13.2 193.8,12.6 194.7,12.3 193.2,12 191.2,11.1 190.4,10.3 193.2,10 193.7,10.3 195.1,10.3 195.5,10 197,9.9ZM205.4,9.6 207.4,9.9 205.9,10.5 203,10.6 200.1,10.4 199.9,10.2 198.5,10.1 197.4,9.7 200.5,9.4 201.9,9.6 202.9,9.3Z",
+	"NP": "M268.1,62.1 268,62.6 268.2,63.2 268.1,63.6 267.2,63.6 266,63.4 265.3,63.3 264.7,62.8 263.3,62.6 262,62.1 261.1,61.6 260.1,61.2 260.5,60.3 261.1,59.8 261.5,59.6 262.3,59.9 263.3,60.5 263.9,60.7 264.2,61.2 265,61.4 265.8,61.8 267,62Z",
+	"NZ": "M353,130.9 353.2,131.3 354,130.9 354.2,131.3 354.2,131.8 353.9,132.2 353.2,133 352.7,133.4 353.1,133.9 352.3,133.9 351.5,134.2 351.2,134.9 350.6,135.9 349.8,136.4 349.3,136.6 348.4,136.6 347.8,136.3 346.7,136.2 346.5,135.9 347,135.1 348.3,134.1 348.9,133.9 349.7,133.6 350.5,133 351.1,132.5 351.6,131.8 351.9,131.5 352.1,131 352.8,130.5ZM354.6,126.2 355.3,127.2 355.4,126.5 355.8,126.8 356,127.6 356.8,127.9 357.4,128 358,127.6 358.5,127.7 358.3,128.6 358,129.2 357.2,129.1 356.9,129.4 357,129.9 356.9,130.1 356.5,130.6 356,131.3 355.2,131.7 355.1,131.4 354.7,131.3 355.2,130.5 354.9,129.9 353.8,129.5 353.9,129.1 354.6,128.8 354.7,128 354.7,127.4 354.3,126.7 354.3,126.5 353.8,126.1 353.1,125.2 352.6,124.5 353,124.5 353.6,125 354.3,125.3Z",
+	"OM": "M238.9,68.9 238.5,69.6 238,69.5 237.8,69.8 237.7,70.3 237.8,70.9 237.7,71.1 237.2,71.1 236.6,71.4 236.5,71.9 236.3,72.1 235.7,72.1 235.3,72.4 235.3,72.8 234.8,73 234.2,73 233.6,73.3 233.1,73.3 232.8,72.7 232,71 235,70 235.7,68 235.2,67.3 235.2,66.9 235.5,66.5 235.5,66.1 236,65.9 235.8,65.7 235.9,65.1 236.4,65.1 236.8,65.8 237.4,66.1 238.1,66.3 238.7,66.4 239.2,67 239.5,67.3 239.8,67.5 239.8,67.7 239.4,68.3 239.3,68.6ZM236.4,64.1 236.3,64.3 236.1,63.9 236.4,63.6 236.5,63.7Z",
+	"PA": "M102.1,82.8 101.8,82.5 101.6,81.9 101.8,81.7 101.6,81.6 101.4,81.3 100.9,81 100.4,81.1 100.2,81.4 99.8,81.7 99.6,81.7 99.5,81.9 100,82.5 99.7,82.6 99.6,82.7 99.1,82.8 98.9,82.2 98.8,82.4 98.5,82.3 98.3,81.9 97.9,81.8 97.6,81.7 97.2,81.7 97.1,81.9 97,81.8 97.1,81.6 97.2,81.4 97.1,81.2 97.3,81.1 97.1,80.9 97.1,80.5 97.5,80.4 97.8,80.8 97.8,81 98.2,81 98.3,81 98.6,81.2 99.1,81.1 99.5,80.9 100.1,80.7 100.4,80.4 101,80.4 100.9,80.5 101.5,80.6 101.9,80.8 102.3,81.1 102.6,81.3 102.5,81.5 102.8,82.1 102.6,82.4 102.2,82.3Z",
+	"PE": "M110.4,107.6 110.1,108.1 109.6,108.3 108.6,107.8 108.5,107.4 106.6,106.4 104.8,105.3 104,104.6 103.6,103.8 103.7,103.5 102.9,102.2 101.9,100.4 101,98.4 100.6,97.9 100.2,97.2 99.5,96.5 98.8,96.1 99.1,95.7 98.6,94.7 98.9,94 99.7,93.4 99.8,93.8 99.5,94.1 99.6,94.4 100,94.3 100.4,94.5 100.8,95 101.4,94.5 101.5,93.9 102.2,93 103.4,92.6 104.5,91.6 104.8,90.9 104.6,90.2 104.9,90.1 105.6,90.5 105.9,91 106.3,91.3 106.9,92.3 107.7,92.4 108.2,92.2 108.6,92.3 109.2,92.3 110,92.7 109.3,93.7 109.6,93.8 110.1,94.3 109.2,94.3 109.1,94.4 108.3,94.6 107.1,95.3 107,95.7 106.8,96.1 106.9,96.6 106.3,96.9 106.3,97.3 106,97.5 106.4,98.4 107,99 106.8,99.5 107.4,99.5 107.8,100.1 108.7,100.1 109.5,99.5 109.5,101 109.9,101.1 110.5,101 111.3,102.6 111.1,102.9 111.1,103.6 111.1,104.5 110.7,105 110.8,105.3 110.6,105.7 111,106.5Z",
+	"PG": "M335.9,96.8 335.6,96.9 335.2,96.5 334.7,95.9 334.5,95.1 334.7,95 334.8,95.3 335.1,95.6 335.5,96.2 336,96.5ZM332,95.5 331.5,95.6 331.3,95.8 330.8,96.1 330.2,96.3 329.7,96.3 328.9,96 328.3,95.7 328.4,95.4 329.3,95.6 329.8,95.5 330,95 330.1,95 330.2,95.5 330.8,95.5 331.1,95.1 331.6,94.8 331.5,94.2 332.1,94.1 332.3,94.3 332.3,94.9ZM327.2,97.4 328.1,98 328.7,99.1 329.3,99.1 329.3,99.5 330,99.7 329.7,99.9 330.8,100.3 330.7,100.6 330,100.7 329.8,100.4 328.9,100.3 327.9,100.1 327.1,99.5 326.6,98.9 326,98.1 324.7,97.6 323.9,97.9 323.3,98.2 323.4,99 322.6,99.3 322.1,99.2 321,99.1 321,95.9 321,92.6 322.7,93.3 324.6,93.9 325.3,94.4 325.8,94.9 326,95.5 327.6,96.1 327.9,96.6 327,96.7ZM333.1,94.5 332.8,94.8 332.6,94.2 332.4,93.8 332,93.5 331.4,93 330.7,92.7 330.9,92.5 331.5,92.8 331.8,93 332.2,93.2 332.6,93.7 333,94Z",
+	"PH": "M306.4,81.6 306.5,82.2 306.5,82.8 306.2,83.7 305.8,82.7 305.4,83.2 305.7,84 305.4,84.4 304.2,83.8 303.9,83.1 304.2,82.6 303.6,82.2 303.3,82.6 302.8,82.5 302.1,83.1 301.9,82.8 302.3,82 302.9,81.7 303.5,81.3 303.8,81.8 304.6,81.5 304.8,81 305.5,81 305.4,80.2 306.2,80.7 306.3,81.2ZM304,79.7 303.6,80 303.3,80.7 303,81 302.4,80.3 302.6,80 302.8,79.7 302.9,79.1 303.5,79.1 303.3,79.7 304.1,78.8ZM298.5,80.7 297.2,81.6 297.7,80.9 298.4,80.3 299,79.6 299.5,78.6 299.7,79.4 299,80ZM301.9,78.1 302.5,78.4 303.1,78.4 303.1,78.8 302.6,79.3 302,79.6 302,79.1 302,78.6ZM305.5,77.8 305.8,79 305,78.7 305,79 305.3,79.6 304.8,79.9 304.8,79.2 304.5,79.1 304.3,78.5 304.9,78.6 304.9,78.2 304.3,77.4 305.2,77.5ZM301.5,76.9 301.3,77.8 300.8,77.3 300.3,76.5 301.2,76.6ZM301.3,71.5 301.9,71.8 302.2,71.5 302.3,71.8 302.2,72.2 302.5,72.9 302.3,73.7 301.7,74.1 301.5,74.9 301.7,75.7 302.3,75.8 302.7,75.7 304,76.2 303.9,76.8 304.2,77 304.1,77.5 303.3,77 302.9,76.4 302.7,76.8 302,76.2 301.1,76.4 300.6,76.1 300.7,75.7 301,75.5 300.7,75.2 300.6,75.6 300.1,75 299.9,74.6 299.9,73.6 300.3,74 300.4,72.4 300.7,71.5Z",
+	"PK": "M255.2,52.9 255.9,53.3 256.2,54.1 257.8,54.5 256.9,55.3 255.8,55.5 254.2,55.3 253.7,55.7 254.1,56.6 254.5,57.2 255.3,57.7 254.4,58.3 254.4,59 253.5,60 252.8,61 251.8,62.1 250.6,62 249.5,63.1 250.2,63.5 250.3,64.3 250.8,64.8 251,65.6 248.8,65.6 248.2,66.3 247.4,66.1 247.1,65.3 246.4,64.6 244.5,64.8 242.9,64.8 241.5,64.9 241.9,63.8 243.3,63.2 243.2,62.8 242.8,62.6 242.7,61.7 241.8,61.3 241.4,60.7 240.9,60.2 242.5,60.7 243.6,60.5 244.1,60.7 244.4,60.4 245,60.5 246.3,60.1 246.4,59.3 246.9,58.7 247.7,58.7 247.8,58.4 248.6,58.3 248.9,58.4 249.3,58.1 249.3,57.5 249.7,56.9 250.3,56.6 249.9,56 250.9,56 251.2,55.7 251.1,55.3 251.6,54.8 251.5,54.3 251.3,53.9 251.8,53.5 252.9,53.3 254.1,53.2 254.6,53Z",
+	"PL": "M195,38.9 194.6,38.3 194.7,37.9 194.4,37.4 194.1,37 194.4,36.8 194.1,36.2 194.8,35.9 196.4,35.5 197.6,35.1 198.6,35.3 198.7,35.6 199.7,35.6 200.9,35.7 202.7,35.7 203.2,35.8 203.5,36.1 203.5,36.5 203.8,36.9 203.8,37.3 203.2,37.5 203.5,38 203.5,38.4 204,39.3 203.9,39.6 203.4,39.7 202.5,40.5 202.8,41 202.6,40.9 201.6,40.5 200.9,40.7 200.4,40.6 199.8,40.8 199.3,40.4 198.9,40.6 198.9,40.5 198.4,40 197.6,40 197.6,39.6 196.9,39.5 196.7,39.8 196.2,39.6 196.2,39.3 195.5,39.2Z",
+	"PR": "M113.7,71.5 114.2,71.6 114.4,71.8 114.2,72 113.4,72 112.8,72.1 112.8,71.6 112.9,71.5Z",
+	"PS": "M215.5,57.6 215.5,58.2 215.4,58.5 214.9,58.6 215,58.4 215.2,58.2 215,58.1 215.2,57.5Z",
+	"PT": "M171,48.1 171.3,47.9 171.7,47.7 172,48.2 172.6,48.2 172.7,48.1 173.3,48.1 173.6,48.6 173.1,48.9 173.1,49.7 173,49.8 172.9,50.3 172.5,50.4 172.9,51 172.6,51.6 173,51.9 172.8,52.2 172.5,52.6 172.5,52.9 172.1,53.2 171.6,53 171.1,53.1 171.3,52.3 171.2,51.7 170.7,51.6 170.5,51.3 170.6,50.6 171,50.2 171,49.8 171.2,49.2 171.2,48.8 171,48.5Z",
+	"PY": "M117.3,112.2 117.7,111.1 117.7,110.5 118.2,109.6 120,109.3 120.9,109.4 121.8,109.9 121.8,110.2 122.1,110.7 122.1,112.1 123.1,112.3 123.5,112.1 124.2,112.4 124.4,112.7 124.5,113.6 124.6,114 125,114 125.3,113.8 125.7,114 125.7,114.6 125.6,115.2 125.4,115.7 125.2,116.6 124.3,117.4 123.5,117.5 122.4,117.4 121.4,117.1 122.4,115.6 122.2,115.2 121.2,114.8 120,114 119.2,113.9Z",
+	"QA": "M230.8,65.2 230.7,64.5 231,64 231.3,63.9 231.6,64.2 231.6,64.8 231.4,65.4 231.1,65.4Z",
+	"RO": "M202.7,42.1 203.1,41.9 203.8,42 204.4,42 204.9,42.3 205.2,42.1 205.9,42 206.2,41.8 206.6,41.8 206.9,41.9 207.2,42.2 207.6,42.6 208.1,43.2 208.2,43.6 208.1,44.1 208.2,44.5 208.7,44.7 209.1,44.5 209.6,44.7 209.6,45 209.1,45.2 208.8,45.1 208.6,46.3 208,46.2 207.2,45.8 206.1,46.1 205.6,46.3 204.1,46.3 203.3,46.1 202.9,46.2 202.7,45.8 202.5,45.6 202.7,45.4 202.5,45.3 202.1,45.5 201.6,45.2 201.5,44.8 200.9,44.6 200.8,44.3 200.2,43.9 201,43.7 201.6,43 202.1,42.3Z",
+	"RS": "M200.9,44.6 201.5,44.8 201.6,45.2 202.1,45.5 202.5,45.3 202.7,45.4 202.5,45.6 202.7,45.8 202.4,46 202.5,46.4 203,46.8 202.6,47.1 202.4,47.4 202.5,47.5 202.4,47.7 201.9,47.7 201.6,47.8 201.5,47.7 201.7,47.6 201.8,47.3 201.6,47.3 201.4,47.1 201.3,47.1 201.1,46.9 201,46.9 200.8,46.7 200.6,46.8 200.5,47.1 200.3,47.2 200.3,47.1 200,46.9 199.6,46.8 199.5,46.6 199.2,46.5 199.5,46.4 199.6,46 199.1,45.6 199.4,45.1 199,45.1 199.4,44.8 199.1,44.5 198.8,44.1 199.6,43.8 200.2,43.9 200.8,44.3Z",
+	"RU": "M323.6,39.3 324.7,41 323.2,40.7 322.6,42.1 323.5,43.2 323.5,43.9 322.7,43.3 322.1,44 321.9,43.2 322,42.2 321.9,41.1 322.1,40.4 322.2,39 321.6,38.1 321.7,36.7 322.6,36.2 322.2,35.8 322.7,35.6 322.9,36.3 323.3,37.3 323.2,38.2ZM202.7,35.7 200.9,35.7 199.7,35.6 199.9,35.1 201.3,34.8 202.3,35 202.8,35.1 202.7,35.4ZM5,23.4 5.7,23.7 5.4,22.9 8.1,23.1 10.1,24 9.1,24.5 7.5,24.6 7.4,25.5 7,25.7 6.1,25.7 5.3,25.4 4,25.1 3.8,24.6 2.8,24.5 1.6,24.6 1.1,24.3 1.3,23.9 0.1,24.1 0.6,24.6 0,25 0,21 2.4,21.8 5.1,22.8ZM360,19.2 358.9,19.2 358.7,18.9 360,18.5ZM1.3,19.1 0,19.2 0,18.5 0.1,18.4 1,18.4 2.4,18.7 2.3,18.9ZM323.6,16.8 322.1,16.8 320,16.7 319.9,16.6 320.8,16.2 322.1,16.1 323.5,16.5ZM330.7,14.9 329.6,15.3 328,15.2 326.1,14.8 326.4,14.5 328.2,14.7ZM325.1,14.4 324.3,15.2 320.6,15.2 319,15.4 317,14.7 317.5,14.1 318.8,13.9 321.5,13.9ZM237.5,19.3 236.9,19.4 233.7,19.2 233.4,18.8 231.6,18.5 231.5,18 232.5,17.8 232.4,17.2 234.4,16.4 233.5,16.3 235.9,15.4 235.6,14.9 237.9,14.4 241.2,13.7 244.5,13.6 246.2,13.2 248.2,13.1 248.9,13.5 248.2,13.8 244.6,14.3 241.6,14.7 238.5,15.7 237,16.7 235.4,17.6 235.6,18.5ZM287,13 287.2,13.5 288.2,13.3 291.1,13.3 293.3,13.8 294.1,14.2 293.9,14.7 292.8,15 290.2,15.5 289.4,15.8 290.6,16 292.1,16.2 293,16 293.5,16.7 294,16.4 295.6,16.2 298.8,16.4 299,16.9 303.2,17 303.3,16.3 305.4,16.4 307,16.4 308.6,17 309.1,17.6 308.5,18 309.7,18.8 311.3,19.2 312.3,18.2 313.9,18.6 315.6,18.3 317.5,18.7 318.2,18.4 319.9,18.5 319.1,17.6 320.5,17.2 329.5,17.8 330.4,18.4 333,19.2 337,19 339,19.1 339.8,19.5 339.7,20.3 340.9,20.6 342.3,20.4 344.1,20.3 345.9,20.5 347.8,20.4 349.6,21.3 350.8,21 350,20.3 350.5,19.9 353.6,20.2 355.7,20.1 358.6,20.6 360,21 360,25 358.7,25.5 357.4,25.4 358.3,25.9 358.9,26.7 359.4,27 359.5,27.4 359.2,27.7 357.4,27.5 354.6,28.2 353.7,28.3 352.1,29 350.7,29.7 350.3,30.1 348.9,29.4 346.3,30.2 345.8,29.8 344.9,30.3 343.5,30.1 343.2,30.8 342,31.8 342.1,32.2 343.2,32.4 343.1,33.8 342.1,33.9 341.7,34.7 342.1,35.1 340.4,35.7 340,36.8 338.5,37 338.2,38.1 336.8,39 336.4,38.3 336,36.8 335.4,34.6 335.9,33.2 336.8,32.6 336.8,32.2 338.4,31.9 340.2,30.7 341.9,29.7 343.7,28.9 344.5,27.4 343.3,27.5 342.7,28.4 340.1,29.5 339.3,28.2 336.7,28.6 334.2,30.2 335,30.9 332.8,31.1 331.3,31.2 331.3,30.5 329.8,30.3 328.5,30.8 325.5,30.7 322.2,31 319,32.9 315.1,35.3 316.7,35.4 317.2,36 318.2,36.2 318.8,35.7 319.9,35.8 321.3,36.9 321.4,37.8 320.6,38.8 320.5,40 320.1,41.6 318.6,43 318.2,43.7 316.9,44.9 315.5,46 314.9,46.6 313.5,47.2 312.9,47.2 312.3,46.7 310.9,47.4 310.8,47.8 310.6,47.6 310.6,47.1 311.1,47.1 311.3,45.9 311,45 311.9,44.7 313.1,44.9 313.8,43.9 314.1,42.8 314.5,42.4 315,41.5 313.4,41.8 312.5,42.2 311,42.2 310.6,41.3 309.4,40.6 307.7,40.2 307.3,39.3 306.9,38.6 306.6,38.2 305.9,37.2 305.1,36.8 303.6,36.5 302.2,36.6 301,36.7 300.2,37.2 300.7,37.5 300.7,38 300.2,38.4 299.3,39.4 299.3,39.9 297.9,40.5 296.7,40.1 295.5,40.2 295,39.9 294.4,39.8 292.9,40.5 291.6,40.6 290.7,40.9 289.4,40.7 288.5,40.7 287.9,40.2 286.9,39.7 285.9,39.6 284.6,39.7 283.7,39.9 282.3,39.5 282.1,38.7 280.9,38.5 280,38.4 278.9,38 277.8,39 278.2,39.6 277.3,40.3 275.8,40 274.8,40 274.1,39.5 273.1,39.5 272.2,39.2 270.7,39.7 268.8,40.5 267.8,40.7 267.4,40.8 266.8,40.2 265.5,40.3 265.1,39.9 264.4,39.7 263.9,39.1 263.4,38.9 261.9,39.2 260.6,38.6 260,39.1 257.8,36.6 256.5,35.8 256.9,35.5 254.4,36.5 253.4,36.5 253.5,36 252.2,35.6 251.2,35.9 250.9,34.8 249.1,34.6 248.2,35 245.7,35.4 245.2,35.6 241.4,36 241,36.3 241.7,37 240.7,37.3 240.9,37.6 240,38 241.6,38.7 241.3,39.2 239.9,39.2 239.6,39.5 238.4,38.9 236.8,39 235.7,39.4 234.5,39 232.3,38.3 230.8,38.3 228.7,39.4 228.6,40.1 227.5,39.5 226.8,40.6 227,40.8 226.5,41.6 227.3,42.3 228.1,42.3 228.7,42.9 228.6,43.4 229.1,43.6 228.6,44.2 227.7,44.4 226.7,45.4 227.6,46.3 227.5,47 228.6,48.2 228,48.6 227.8,48.8 227.4,48.8 226.7,48.2 226.4,48.1 225.8,47.9 225.5,47.5 224.5,47.3 223.9,47.4 223.8,47.3 222.4,46.8 220.9,46.6 220.1,46.4 220,46.6 218.7,45.7 217.5,45.3 216.7,44.8 217.4,44.6 218.2,43.8 217.7,43.4 219.1,43 219.1,42.7 218.2,42.9 218.3,42.5 218.8,42.2 219.7,42.1 219.9,41.8 219.7,41.2 220.1,40.7 220.1,40.4 218.6,40.1 218,40.1 217.4,39.6 216.6,39.8 215.4,39.4 215.4,39.2 215,38.8 214.2,38.7 214.1,38.4 214.4,38.2 213.8,37.7 212.7,37.8 212.4,37.7 212.2,37.9 211.8,37.9 211.5,37.3 211.3,36.9 211.5,36.8 212.3,36.9 212.7,36.6 212.4,36.4 211.7,36.2 211.8,36 211.4,35.8 210.8,35.2 211,34.9 210.9,34.4 209.9,34.2 209.4,34.3 209.2,34.1 208.2,33.8 207.9,33.2 207.8,32.8 207.3,32.5 207.7,32.2 207.4,31.3 208.1,30.7 208,30.5 209.1,30 208.1,29.5 210.2,28.2 211.1,27.6 211.5,27.1 210,26.4 210.4,25.8 209.5,25.1 210.2,24.2 209.1,23.1 210,22.3 208.4,21.6 208.6,20.9 209.4,20.8 211.1,20.4 212.1,20.1 213.8,20.7 216.5,20.9 220.3,22.1 221.1,22.5 221.1,23.2 220,23.7 218.4,24 213.9,23.2 213.2,23.4 214.8,24.1 214.9,24.6 214.9,25.6 216.2,25.9 217,26.2 217.1,25.7 216.5,25.2 217.2,24.9 219.6,25.5 220.4,25.2 219.8,24.5 222.1,23.5 223,23.6 223.9,23.9 224.5,23.2 223.7,22.6 224.2,22 223.5,21.4 226.3,21.8 226.8,22.3 225.6,22.4 225.6,23 226.3,23.3 227.9,23.1 228.1,22.5 230.2,22 233.7,21.1 234.5,21.2 233.5,21.8 234.7,21.9 235.4,21.6 237.3,21.5 238.8,21.1 239.9,21.7 241.1,21.1 240,20.5 240.6,20.2 243.5,20.5 244.9,20.8 248.5,21.9 249.2,21.4 248.2,20.9 248.1,20.6 246.9,20.5 247.3,20.1 246.7,19.3 246.7,19 248.5,18.1 249.2,17.2 249.9,17 252.6,17.2 252.8,17.8 251.8,18.6 252.5,18.9 252.8,19.6 252.6,21 253.7,21.6 253.2,22.3 251.3,23.7 252.4,23.8 252.8,23.5 253.9,23.2 254.2,22.7 255.1,22.2 254.5,21.7 254.9,21 253.8,20.9 253.6,20.4 254.4,19.4 253.1,18.6 254.9,17.9 254.7,17.2 255.2,17.1 255.7,17.7 255.3,18.7 256.4,18.8 255.9,18.1 257.6,17.7 259.7,17.7 261.5,18.2 260.6,17.4 260.5,16.4 262.2,16.1 264.7,16.2 266.8,16.1 266,15.5 267.2,14.9 268.3,14.9 270.3,14.4 272.9,14.2 273.2,14 275.9,13.9 276.7,14.1 278.9,13.6 280.8,13.6 281,13.1 282,12.7 284.4,12.3 286.1,12.6 284.7,12.9ZM285.1,11.7 279.4,12.1 281.3,10.8 282.1,10.7 282.8,10.7 285.4,11.3ZM231.1,9.5 229.8,9.6 228.9,9.7 228.8,9.8 227.6,10 226.5,9.8 227.1,9.4 224.8,9.4 226.8,9.2 228.3,9.2 228.5,9.5 229.1,9.2 230,9.1 231.5,9.3ZM279.9,11.1 277.8,11.2 275,11 273.3,10.6 272.5,9.9 271.2,9.7 273.8,9 275.9,8.7 277.9,9.3 280.2,10.2Z",
+	"RW": "M210.4,91.1 210.8,91.7 210.8,92.3 210.5,92.4 209.9,92.3 209.6,92.9 209,92.8 209.1,92.3 209.3,92.2 209.3,91.6 209.6,91.3 209.8,91.4Z",
+	"SA": "M222.8,73.7 222.6,73.2 222.3,72.9 222.3,72.5 221.8,72.2 221.2,71.3 220.9,70.5 220.2,69.8 219.8,69.7 219.1,68.7 219,68 219.1,67.4 218.5,66.3 218,65.9 217.5,65.7 217.2,65.1 217.2,64.9 216.9,64.4 216.6,64.2 216.2,63.4 215.6,62.6 215.1,61.9 214.6,61.9 214.8,61.4 214.8,61 215,60.6 216.1,60.8 216.5,60.5 216.7,60.1 217.5,60 217.7,59.7 218,59.5 217,58.5 219,58 219.2,57.8 220.4,58.1 221.9,58.8 224.7,60.8 226.6,60.9 227.5,61 227.7,61.5 228.4,61.4 228.8,62.3 229.3,62.5 229.5,62.9 230.2,63.3 230.2,63.7 230.1,64.1 230.2,64.4 230.5,64.7 230.7,65 230.8,65.2 231.1,65.4 231.4,65.4 231.6,65.8 231.6,66 232,67 235,67.5 235.2,67.3 235.7,68 235,70 232,71 229.1,71.4 228.2,71.8 227.5,72.9 227,73.1 226.7,72.7 226.4,72.8 225.4,72.7 225.2,72.6 224.1,72.6 223.8,72.7 223.4,72.4 223.1,72.9 223.2,73.3Z",
+	"SB": "M342.1,100.5 342.4,100.8 341.7,100.8 341.3,100.2 341.9,100.4ZM340.9,99.9 340.5,99.9 339.8,99.8 339.6,99.6 339.7,99.2 340.4,99.4 340.7,99.6ZM341.7,99.6 341.5,99.8 340.8,98.9 340.6,98.3 340.9,98.3 341.3,99.1ZM339.9,98.3 339.9,98.5 339.1,98.1 338.6,97.8 338.2,97.4 338.4,97.3 338.8,97.6 339.6,98ZM337.5,97.3 337.3,97.4 336.9,97.2 336.5,96.8 336.5,96.6 337.1,97Z",
+	"SD": "M214,80.5 213.8,80.5 213.8,80 213.7,79.7 213.2,79.3 213.1,78.6 213.2,77.8 212.7,77.8 212.7,78 212.1,78 212.3,78.3 212.4,78.9 211.9,79.5 211.4,80.2 210.8,80.3 210,79.7 209.6,79.9 209.5,80.2 209,80.4 209,80.6 208,80.6 207.8,80.4 207.1,80.4 206.8,80.5 206.5,80.4 206,79.9 205.8,79.6 205.1,79.7 204.8,80.2 204.5,81.1 204.2,81.3 203.9,81.4 203.8,81.3 203.5,81 203.4,80.7 203.6,80.3 203.6,79.9 203,79.3 202.9,78.9 202.9,78.6 202.5,78.3 202.5,77.7 202.3,77.4 201.9,77.4 202,77 202.3,76.6 202.2,76.2 202.5,75.9 202.3,75.7 202.6,75.1 203,74.3 203.9,74.4 203.8,70.4 203.9,70 205,70 205,68 209,68 212.9,68 216.9,68 217.2,69 217,69.2 217.1,70.2 217.5,71.4 217.9,71.6 218.4,72 217.9,72.6 217.2,72.7 216.9,73 216.8,73.7 216.3,75.2 216.4,75.6 216.3,76.4 215.9,77.4 215.3,77.9 214.8,78.7 214.7,79.1 214.3,79.4 214,80.4Z",
+	"SE": "M202.2,24.3 201.2,25 201.4,25.6 199.8,26.4 197.8,27.3 197.1,28.7 197.8,29.4 198.8,29.9 197.9,31 196.8,31.3 196.4,33 195.9,33.9 194.7,33.8 194.1,34.6 192.9,34.6 192.6,33.7 191.8,32.6 191,31.1 191.5,30.6 192.3,29.9 192.6,28.7 192,28.2 191.9,26.9 192.6,25.9 193.6,26 193.9,25.6 193.6,25.2 195.1,23.8 196.1,22.7 196.8,22 197.7,22 198,21.4 199.9,21.6 200,20.9 200.6,20.9 202,21.4 203.5,22.1 203.6,23.6 203.9,24Z",
+	"SI": "M193.8,43.5 194.6,43.6 195.1,43.3 196,43.3 196.2,43.1 196.4,43.2 196.6,43.5 195.8,43.8 195.7,44.2 195.3,44.3 195.3,44.5 194.9,44.5 194.6,44.4 194.4,44.5 193.7,44.5 193.9,44.4 193.7,44Z",
+	"SK": "M198.9,40.5 198.9,40.6 199.3,40.4 199.8,40.8 200.4,40.6 200.9,40.7 201.6,40.5 202.6,40.9 202.3,41.2 202.1,41.6 201.9,41.7 200.8,41.4 200.5,41.4 200.2,41.7 199.8,41.8 199.7,41.7 199.2,41.9 198.8,41.9 198.7,42.1 197.9,42.2 197.5,42.1 197,41.9 196.9,41.5 197,41.4 197.1,41.2 197.5,41.2 197.9,41.1 197.9,41 198.1,41 198.2,40.7 198.4,40.7 198.6,40.5Z",
+	"SL": "M168.6,83.2 168.3,83.1 167.6,82.7 167.1,82.2 166.9,81.8 166.8,81.1 167.3,80.7 167.4,80.4 167.6,80.2 167.8,80.1 168.1,80 168.9,80 169.2,80.3 169.4,80.7 169.3,81 169.5,81.3 169.5,81.7 169.8,81.6 169.3,82.1 168.9,82.6 168.8,82.9Z",
+	"SN": "M163.3,76.4 162.9,75.6 162.4,75.3 162.8,75.1 163.3,74.4 163.5,73.9 163.9,73.5 164.4,73.6 164.9,73.4 165.4,73.4 165.9,73.7 166.6,74 167.2,74.7 167.8,75.4 167.9,76 168.1,76.6 168.4,76.9 168.5,77.2 168.5,77.6 168.3,77.6 167.8,77.5 167.7,77.6 167.5,77.7 166.8,77.4 166.3,77.4 164.5,77.4 164.2,77.5 163.9,77.5 163.3,77.6 163.2,76.8 164.1,76.9 164.3,76.7 164.5,76.7 164.9,76.5 165.3,76.7 165.7,76.7 166.2,76.5 166,76.2 165.6,76.4 165.3,76.4 164.9,76.1 164.6,76.1 164.4,76.4Z",
+	"SO": "M228.9,80.5 228.5,81.2 227.8,82 226.9,82 223.7,80.8 223.3,80.5 222.9,80 222.6,79.4 222.8,79.1 223.1,78.5 223.5,78.7 223.7,79.1 224.1,79.6 224.6,79.6 225.6,79.3 226.6,79.2 227.5,78.9 228,78.8 228.4,78.6 228.9,78.6 228.9,79 228.9,80ZM229.7,78.4 230.3,78.3 230.7,78 231.1,78 231.1,78.3 231,78.8 231,79.4 230.8,79.7 230.6,80.8 230.1,81.9 229.5,83.2 228.6,84.7 227.7,85.8 226.6,87.1 225.6,88 224.1,88.9 223.1,89.7 222,90.9 221.8,91.4 221.6,91.7 221,90.9 221,87.2 221.9,86.1 222.1,85.8 222.8,85.7 223.7,85 225,85 227.8,82 228.5,81.2 228.9,80.5 228.9,80 228.9,79 228.9,78.6 229.3,78.6Z",
+	"SR": "M122.9,84 124.1,84.2 124.2,84 125,84 126,84.2 125.5,85.1 125.6,85.8 126,86.4 125.8,86.8 125.7,87.3 125.5,87.7 124.9,87.5 124.4,87.6 124,87.5 123.9,87.8 124.1,88 124,88.2 123.5,88.1 122.8,87.2 122.7,86.7 122.4,86.7 122,85.9 122.1,85.4 122.1,85.2 122.7,84.9Z",
+	"SS": "M214,80.5 214,81.3 213.8,81.6 213.3,81.6 213,82.2 213.6,82.3 214.1,82.8 214.3,83.2 214.7,83.4 215.3,84.5 214.6,85.2 214,85.8 213.4,86.2 212.7,86.2 211.9,86.4 211.2,86.2 210.8,86.5 210,85.8 209.7,85.4 209.2,85.6 208.7,85.5 208.4,85.7 208,85.6 207.4,84.8 207.2,84.4 206.5,84.1 206.2,83.5 205.8,83 205.1,82.5 205.1,82.2 204.6,81.8 203.9,81.4 204.2,81.3 204.5,81.1 204.8,80.2 205.1,79.7 205.8,79.6 206,79.9 206.5,80.4 206.8,80.5 207.1,80.4 207.8,80.4 208,80.6 209,80.6 209,80.4 209.5,80.2 209.6,79.9 210,79.7 210.8,80.3 211.4,80.2 211.9,79.5 212.4,78.9 212.3,78.3 212.1,78 212.7,78 212.7,77.8 213.2,77.8 213.1,78.6 213.2,79.3 213.7,79.7 213.8,80 213.8,80.5Z",
+	"SV": "M92.2,76.6 92.1,76.9 91.5,76.8 91.2,76.7 90.7,76.5 90.2,76.5 89.9,76.3 89.9,76.1 90.3,75.9 90.5,75.8 90.4,75.6 90.6,75.6 90.9,75.7 91.2,75.9 91.5,76 91.5,76.2 91.9,76 92.1,76.1 92.3,76.2Z",
+	"SY": "M218.8,56.6 216.8,57.7 215.7,57.3 215.8,57.1 215.8,56.7 216.1,56.2 216.6,55.8 216.4,55.4 216,55.4 215.9,54.6 216.1,54.2 216.4,54 216.7,53.7 216.7,53.2 217.1,53.4 218.2,53.1 218.7,53.3 219.5,53.3 220.7,52.9 221.2,52.9 222.3,52.8 221.8,53.4 221.3,53.6 221.4,54.4 221,55.6Z",
+	"SZ": "M212.1,116.7 211.9,117.2 211.3,117.3 210.7,116.7 210.7,116.4 210.9,116 211,115.7 211.3,115.7 211.8,115.8 212,116.3Z",
+	"TD": "M194.5,77.1 194.6,76.7 194,76.6 194,76 193.5,75.6 194,74.3 195.2,73.4 195.3,72.1 195.7,70 195.9,69.6 195.5,69.3 195.5,69 195.1,68.7 194.9,67.1 195.9,66.6 199.8,68.5 203.8,70.4 203.9,74.4 203,74.3 202.6,75.1 202.3,75.7 202.5,75.9 202.2,76.2 202.3,76.6 202,77 201.9,77.4 202.3,77.4 202.5,77.7 202.5,78.3 202.9,78.6 202.9,78.9 202.2,79 201.7,79.4 201,80.5 200.1,81 199.1,80.9 198.8,81 198.9,81.4 198.4,81.7 198,82.1 196.7,82.5 196.5,82.3 196.3,82.2 196.1,82.5 195.3,82.6 195.4,82.3 195.1,81.6 195,81.2 194.5,81 194,80.5 194.2,80 194.6,80.1 194.9,80 195.5,80 194.9,79.1 195,78.4 194.9,77.8Z",
+	"TF": "M248.9,138.6 249.6,138.9 250.5,139.1 250.6,139.3 250.3,139.7 248.7,139.8 248.7,139.2 248.9,138.8Z",
+	"TG": "M181.9,83.9 181.1,84.1 180.8,83.7 180.6,83.1 180.5,82.6 180.7,81.7 180.5,81.3 180.4,80.5 180.4,79.8 180,79.3 180,79 180.9,79 180.8,79.5 181.1,79.8 181.4,80.2 181.5,80.7 181.7,80.9 181.6,83.2Z",
+	"TH": "M282.6,77.8 281.7,77.4 280.8,77.4 281,76.6 280.1,76.6 280,77.7 279.5,79.2 279.2,80 279.2,80.8 279.9,80.8 280.3,81.7 280.5,82.6 281,83.1 281.6,83.3 282.1,83.8 281.8,84.2 281.2,84.3 281.1,83.8 280.3,83.4 280.1,83.5 279.7,83.2 279.5,82.7 279,82.1 278.5,81.6 278.3,82.2 278.2,81.6 278.3,81 278.6,80.1 279,79 279.6,78.1 279.2,77.2 279.2,76.7 279.1,76.2 278.4,75.4 278.2,74.9 278.5,74.7 278.9,73.8 278.5,73.2 277.9,72.4 277.4,71.6 277.8,71.4 278.3,70.3 279,70.2 279.5,69.8 280.1,69.6 280.5,69.9 280.6,70.5 281.3,70.5 281,71.6 281.1,72.5 282.1,71.9 282.4,72.1 283,72 283.2,71.7 284,71.8 284.7,72.6 284.8,73.6 285.6,74.4 285.5,75.3 285.2,75.7 284.3,75.6 283,75.8 282.3,76.6Z",
+	"TJ": "M251,49.8 250.6,50.1 249.6,49.9 249.5,50.5 250.5,50.4 251.8,50.7 253.7,50.6 253.9,51.5 254.3,51.4 254.9,51.6 254.8,52 255,52.6 253.9,52.6 253.3,52.5 252.6,53 252.2,53.1 251.8,53.3 251.4,52.9 251.5,52.1 251.2,52 251.3,51.7 250.8,51.5 250.4,51.9 250.3,52.3 250.1,52.4 249.5,52.4 249.2,52.8 248.9,52.7 248.1,53 247.8,52.9 248.4,51.8 248.2,51.1 247.4,50.9 247.7,50.4 248.5,50.5 249,49.9 249.3,49.3 250.7,49 250.5,49.5 250.6,49.8Z",
+	"TL": "M305,98.9 305.1,98.7 305.9,98.4 306.6,98.4 307,98.3 307.3,98.4 307,98.7 305.9,99.1 305.1,99.4 305.1,99.1Z",
+	"TM": "M241.2,54.3 241.1,53.5 240.4,53.5 239.2,52.6 238.4,52.5 237.3,52 236.6,51.9 236.2,52.1 235.5,52 234.8,52.6 233.9,52.8 233.7,52.1 233.9,51 233.1,50.7 233.4,50 232.7,50 232.9,49.1 233.9,49.4 234.7,49 234,48.4 233.7,47.9 232.9,48.1 232.8,48.9 232.5,48.2 232.9,47.9 234.1,47.7 234.8,48 235.5,48.7 236,48.7 237.1,48.7 236.9,48.2 237.8,47.8 238.6,47.2 240,47.8 240.1,48.6 240.5,48.8 241.5,48.7 241.9,48.9 242.4,49.9 243.5,50.6 244.2,51.1 245.2,51.6 246.5,52 246.5,52.6 246.2,52.6 245.7,52.3 245.6,52.7 244.7,52.9 244.5,53.7 244,54 243.2,54.1 243,54.6 242.2,54.7Z",
+	"TN": "M189.5,59.7 189.1,57.9 188.4,57.5 188.4,57.3 187.6,56.7 187.5,55.9 188.1,55.3 188.4,54.5 188.2,53.6 188.4,53.1 189.5,52.7 190.2,52.8 190.2,53.3 191,52.9 191.1,53.1 190.6,53.6 190.6,54.1 190.9,54.3 190.8,55.2 190.1,55.7 190.3,56.2 190.9,56.2 191.1,56.7 191.5,56.9 191.4,57.6 190.9,57.9 190.6,58.2 190,58.6 190.1,59 190,59.5Z",
+	"TR": "M216.9,48.7 218.3,49.1 219.5,48.9 220.4,49 221.6,48.5 222.6,48.4 223.6,48.9 223.8,49.3 223.7,49.7 224.4,50 224.8,50.3 224.1,50.6 224.4,51.7 224.2,52 224.8,52.8 224.3,53 223.9,52.7 222.8,52.6 222.3,52.8 221.2,52.9 220.7,52.9 219.5,53.3 218.7,53.3 218.2,53.1 217.1,53.4 216.7,53.2 216.7,53.7 216.4,54 216.1,54.2 215.8,53.7 216.2,53.3 215.6,53.4 214.7,53.2 214,53.8 212.5,53.9 211.7,53.4 210.6,53.3 210.4,53.7 209.7,53.9 208.7,53.3 207.6,53.3 207,52.3 206.3,51.8 206.8,51 206.2,50.5 207.3,49.6 208.8,49.5 209.2,48.8 211.1,48.9 212.3,48.3 213.5,48 215.2,48ZM207.2,49.3 206.4,49.8 206,49.4 206.1,49.2 206.3,49.1 206.6,48.4 206.1,48.2 207.1,47.9 208,48 208.1,48.4 209,48.7 208.8,48.9 207.6,49Z",
+	"TT": "M118.3,79.2 118.9,79.1 119.1,79.1 119.1,79.9 118.2,80 118,79.9 118.3,79.6Z",
+	"TW": "M301.8,65.6 301.2,67.2 300.7,68 300.2,67.2 300.1,66.4 300.7,65.5 301.5,64.7 302,65Z",
+	"TZ": "M213.9,91 214.1,91.1 217.7,93.1 217.8,93.7 219.2,94.7 218.7,95.9 218.8,96.5 219.4,96.8 219.5,97.1 219.2,97.7 219.3,98 219.2,98.5 219.5,99.1 219.9,100.1 220.3,100.3 219.5,100.9 218.4,101.3 217.8,101.3 217.5,101.6 216.8,101.6 216.5,101.7 215.3,101.4 214.6,101.5 214.3,100.2 213.9,99.7 213.7,99.4 212.8,99.2 212.2,98.9 211.6,98.8 211.2,98.6 210.7,98.3 210.2,97.1 209.6,96.5 209.4,95.9 209.5,95.4 209.3,94.5 209.8,94.5 210.1,94.1 210.5,93.6 210.8,93.4 210.7,93 210.5,92.8 210.5,92.4 210.8,92.3 210.8,91.7 210.4,91.1 210.8,91 211.9,91Z",
+	"UA": "M211.8,37.9 212.2,37.9 212.4,37.7 212.7,37.8 213.8,37.7 214.4,38.2 214.1,38.4 214.2,38.7 215,38.8 215.4,39.2 215.4,39.4 216.6,39.8 217.4,39.6 218,40.1 218.6,40.1 220.1,40.4 220.1,40.7 219.7,41.2 219.9,41.8 219.7,42.1 218.8,42.2 218.3,42.5 218.2,42.9 217.4,43 216.8,43.3 215.8,43.4 215,43.7 215,44.3 215.5,44.6 216.5,44.5 216.3,44.9 215.2,45.1 213.9,45.6 213.3,45.4 213.5,45 212.5,44.7 212.6,44.5 213.6,44.1 213.3,43.9 211.7,43.7 211.7,43.3 210.7,43.4 210.4,44 209.6,44.7 209.1,44.5 208.7,44.7 208.2,44.5 208.5,44.4 208.7,44.1 208.9,43.7 208.9,43.6 209.1,43.5 209.2,43.6 209.8,43.7 210,43.6 209.8,43.5 209.9,43.3 209.6,43.1 209.4,42.7 209.1,42.5 209.1,42.2 208.7,41.9 208.3,41.8 207.5,41.5 206.9,41.6 206.6,41.8 206.2,41.8 205.9,42 205.2,42.1 204.9,42.3 204.4,42 203.8,42 203.1,41.9 202.7,42.1 202.6,41.8 202.1,41.6 202.3,41.2 202.6,40.9 202.8,41 202.5,40.5 203.4,39.7 203.9,39.6 204,39.3 203.5,38.4 204,38.4 204.6,38.1 205.3,38.1 206.3,38.2 207.5,38.4 208.2,38.4 208.6,38.6 209,38.4 209.3,38.6 210.2,38.6 210.6,38.7 210.6,38.2 210.9,38Z",
+	"UG": "M211.9,91 210.8,91 210.4,91.1 209.8,91.4 209.6,91.3 209.6,90.6 209.8,90.2 209.9,89.4 210.1,88.9 210.5,88.4 210.9,88.2 211.2,87.8 210.8,87.7 210.8,86.5 211.2,86.2 211.9,86.4 212.7,86.2 213.4,86.2 214,85.8 214.5,86.4 214.6,86.9 215,88.1 214.7,88.8 214.2,89.5 213.9,89.9 213.9,91Z",
+	"US": "M24.5,70.9 24.3,71.1 24.1,70.9 24.1,70.7 23.9,70.3 24,70.2 24.1,70 24.1,69.8 24.1,69.7 24.2,69.8 24.6,69.9 24.8,70 24.9,70.1 25.2,70.5 24.8,70.8ZM23.9,69.4 23.6,69.4 23.4,69.2 23.3,69.1 23.4,69 23.7,69.1 24,69.2ZM23.2,68.8 23.2,68.9 22.7,68.9 22.7,68.8ZM22.3,68.7 22.2,68.7 21.9,68.7 21.7,68.5 21.7,68.4 22,68.3 22.1,68.3ZM20.7,68 20.5,68.1 20.2,67.9 20.3,67.9 20.4,67.8 20.6,67.8ZM85.2,40.6 85.4,41.2 85.7,41.3 86.4,41.4 87.4,41.5 88.4,41.9 89.2,41.7 90.4,42 90.7,42 91.6,41.7 92.6,42.1 93.5,42.4 94.3,42.8 95.1,43.1 95.2,43.4 95.5,43.5 95.4,43.6 95.7,43.6 95.9,43.5 95.9,43.7 96.1,43.9 96.4,43.9 96.5,44 96.4,44.2 97.4,44.7 97.7,45.6 97.9,46.4 97.6,47 97.1,47.6 96.9,47.9 96.9,48 97,48.2 97.3,48.3 97.6,48.3 98.7,47.8 99.8,47.6 101.1,47.1 101.1,47 101,46.7 100.8,46.5 101.3,46.4 102.3,46.4 103.2,46.4 103.5,46 103.6,45.9 104.7,45.2 105.1,45 106.7,45 108.5,45 108.6,44.7 108.9,44.7 109.3,44.5 109.7,44.1 110,43.3 110.8,42.6 111.1,42.8 111.8,42.6 112.2,42.9 112.2,44.3 112.9,44.9 113,45.2 112,45.7 110.9,46 109.9,46.3 109.4,46.9 109.2,47.1 109.2,47.7 109.5,48.2 109.9,48.2 109.8,47.9 110.1,48.1 110,48.4 109.4,48.5 108.9,48.5 108.1,48.7 107.7,48.7 107.1,48.8 106.3,49.1 107.8,48.9 108.1,49.1 106.7,49.4 106,49.4 106,49.2 105.7,49.5 106,49.6 105.8,50.3 105.1,51.1 105,50.8 104.8,50.8 104.5,50.5 104.7,51 104.9,51.2 104.9,51.6 104.6,52 104.1,52.8 104,52.7 104.3,52.1 103.8,51.7 103.7,50.9 103.5,51.3 103.7,51.9 103,51.8 103.7,52.1 103.7,53 104,53.1 104.1,53.4 104.3,54.4 103.6,55.2 102.6,55.5 101.9,56.1 101.4,56.1 100.9,56.5 100.8,56.8 99.7,57.5 99.1,58 98.7,58.6 98.5,59.3 98.7,60 99,60.8 99.5,61.5 99.5,62 99.9,63.1 99.9,63.8 99.9,64.2 99.6,64.8 99.3,64.9 98.8,64.8 98.7,64.4 98.3,64.1 97.8,63.3 97.3,62.5 97.1,62.1 97.3,61.4 97.1,60.9 96.3,60.1 95.9,59.9 94.9,60.4 94.7,60.3 94.2,59.8 93.6,59.6 92.5,59.7 91.6,59.6 90.8,59.7 90.4,59.8 90.6,60.1 90.6,60.5 90.8,60.7 90.6,60.8 90.2,60.7 89.8,60.9 89.1,60.9 88.4,60.3 87.5,60.4 86.8,60.2 86.2,60.3 85.3,60.5 84.4,61.3 83.4,61.7 82.9,62.2 82.6,62.6 82.6,63.3 82.7,63.8 82.9,64.1 82.5,64.2 81.8,63.9 81,63.6 80.7,63.2 80.5,62.5 79.9,61.9 79.5,61.3 79,60.6 78.3,60.2 77.5,60.2 76.9,61 76.1,60.7 75.5,60.4 75.3,59.9 75,59.4 74.4,58.9 73.9,58.6 73.5,58.2 71.8,58.2 71.8,58.7 71,58.7 69,58.7 66.7,58 65.2,57.5 65.3,57.3 64,57.4 62.9,57.5 62.7,57 62.1,56.4 61.6,56.3 61.5,56 60.9,55.9 60.6,55.7 59.6,55.6 59.4,55.4 59.3,54.8 58.3,53.8 57.5,52.4 57.5,52.2 57,51.9 56.3,51 56.1,50.2 55.6,49.7 55.8,48.9 55.8,48 55.5,47.2 55.9,46.3 56,45.4 56.1,44.5 55.9,43.1 55.6,42.3 55.3,41.8 55.4,41.6 56.9,42 57.4,42.9 57.7,42.6 57.5,41.8 57.2,41 60,41 63,41 64,41 67,41 70,41 73,41 76,41 79.3,41 82.8,41 84.8,41 84.8,40.6ZM27,32.9 26,33.3 25.5,33 25.3,32.5 26.2,32.2 26.8,32 27.4,32.1 27.9,32.4ZM14.4,30.1 13.8,30.2 13.2,30.1 12.5,29.8 13.5,29.6 14.3,29.7ZM8.3,26.2 8.9,26.4 9.5,26.3 10.3,26.6 11.3,26.7 11.2,26.8 10.5,27 9.7,26.8 9.3,26.6 8.4,26.7 8.2,26.6ZM24.9,18.9 25.7,19.3 26.1,19.1 27.8,19.2 27.7,19.4 29.3,19.6 30.3,19.5 32.4,19.8 34.3,19.9 35.1,20 36.4,19.8 37.9,20.1 39,20.3 39,24 39,29.7 40,29.7 41,30 41.7,30.4 42.5,31.1 43.5,30.5 44.5,30.2 45.1,30.7 45.7,31.1 46.6,31.6 47.3,32.3 48.3,33.4 50,34.1 50,34.7 49.5,35.2 48.9,34.8 48,34.5 47.7,33.6 46.5,32.8 45.9,31.9 45,31.8 43.4,31.8 42.2,31.5 40.1,30.5 39.2,30.3 37.4,29.9 36,30 34.1,29.5 32.9,29.1 31.8,29.3 32,30 31.4,30.1 30.3,30.3 29.4,30.6 28.3,30.8 28.1,30.3 28.6,29.3 29.7,29 29.4,28.7 28.1,29.3 27.4,29.9 26,30.6 26.7,31.1 25.8,31.9 24.7,32.3 23.7,32.6 23.4,33 21.9,33.5 21.6,34 20.4,34.4 19.7,34.4 18.8,34.6 17.8,35 16.9,35.3 15.2,35.6 15.1,35.4 16.2,35 17.1,34.7 18.2,34.1 19.4,34 19.9,33.6 21.3,33 21.5,32.8 22.3,32.4 22.4,31.7 23,31.1 21.8,31.4 21.5,31.2 20.9,31.6 20.3,31.1 20,31.4 19.6,30.9 18.6,31.3 18,31.3 17.9,30.7 18.1,30.4 17.5,30 16.2,30.2 15.3,29.7 14.7,29.5 14.6,28.9 13.9,28.5 14.3,27.9 15.1,27.4 15.4,26.9 16.2,26.8 16.9,26.9 17.7,26.5 18.5,26.5 19.2,26.2 19,25.8 18.5,25.6 19.2,25.2 18.6,25.2 17.5,25.4 17.2,25.7 16.5,25.4 15,25.6 13.6,25.3 13.2,24.9 11.9,24.3 13.3,23.9 15.5,23.4 16.3,23.4 16.2,23.9 18.3,23.9 17.5,23.3 16.3,22.9 15.6,22.4 14.6,22 13.2,21.6 13.8,21.1 15.6,21.1 16.8,20.6 17.1,20.1 18.1,19.7 19.1,19.6 21,19.1 21.9,19.2 23.4,18.6Z",
+	"UY": "M122.4,120.2 123,120.1 124,120.9 124.4,120.9 125.4,121.5 126.2,122 126.8,122.7 126.3,123.2 126.6,123.8 126.2,124.4 125.1,125 124.3,124.8 123.8,124.9 122.9,124.4 122.2,124.5 121.6,123.9 121.7,123.3 121.9,123 121.9,122 122.1,121Z",
+	"UZ": "M246.5,52.6 246.5,52 245.2,51.6 244.2,51.1 243.5,50.6 242.4,49.9 241.9,48.9 241.5,48.7 240.5,48.8 240.1,48.6 240,47.8 238.6,47.2 237.8,47.8 236.9,48.2 237.1,48.7 236,48.7 235.9,45 238.5,44.4 238.7,44.5 240.2,45.2 241.1,45.6 242,46.5 243.2,46.3 244.9,46.3 246.1,47 246,48 246.5,48 246.7,48.8 248,48.9 248.3,49.3 248.6,49.3 249.1,48.6 250.4,47.9 251,47.7 251.3,47.8 250.4,48.5 251.2,48.9 251.9,48.6 253.1,49.1 251.8,49.9 251,49.8 250.6,49.8 250.5,49.5 250.7,49 249.3,49.3 249,49.9 248.5,50.5 247.7,50.4 247.4,50.9 248.2,51.1 248.4,51.8 247.8,52.9 247.1,52.6Z",
+	"VE": "M108.7,78.2 108.6,78.5 108.1,78.6 108.4,79 108.4,79.6 107.9,80.1 108.3,80.9 108.7,80.9 109,80.1 108.6,79.8 108.6,79 109.8,78.6 109.7,78.2 110.1,77.8 110.4,78.5 111.1,78.6 111.8,79.1 111.8,79.4 112.7,79.5 113.8,79.4 114.3,79.8 115.1,79.9 115.7,79.6 115.7,79.4 116.9,79.3 118.1,79.3 117.3,79.6 117.6,80.1 118.4,80.1 119.2,80.6 119.3,81.4 119.8,81.4 120.2,81.6 119.4,82.2 119.4,82.6 119.7,83 119.5,83.1 118.8,83.3 118.9,83.8 118.6,84 119.3,84.8 119.4,85.1 119,85.5 117.9,85.8 117.2,86 116.9,86.2 116.1,86 115.4,85.9 115.2,85.9 115.6,86.2 115.6,86.9 115.7,87.5 116.6,87.6 116.6,87.8 115.9,88.1 115.8,88.5 115.4,88.7 114.6,88.9 114.5,89.2 113.7,89.3 113.1,88.7 112.8,87.7 112.6,87.4 112.2,87.2 112.7,86.7 112.7,86.5 112.4,86.2 112.2,85.5 112.3,84.8 112.5,84.4 112.7,83.9 112.3,83.7 111.7,83.8 111,83.8 110.6,83.9 109.9,83 109.3,82.9 108,83 107.8,82.7 107.6,82.6 107.5,82.4 107.6,82 107.6,81.6 107.3,81.4 107.2,80.9 106.7,80.8 107,80.3 107.1,79.5 107.4,79.2 107.8,78.9 108,78.4Z",
+	"VN": "M288.1,68.4 286.7,69.3 285.9,70.2 285.7,70.9 286.4,72 287.4,73.3 288.3,73.9 288.9,74.7 289.3,76.6 289.2,78.3 288.4,79 287.2,79.6 286.4,80.5 285.2,81.4 284.8,80.8 285.1,80.1 284.3,79.5 285.2,79.1 286.2,79 285.8,78.4 287.5,77.7 287.6,76.5 287.4,75.8 287.6,74.8 287.3,74.1 286.6,73.4 285.9,72.5 285.1,71.3 283.9,70.7 284.2,70.4 284.8,70.1 284.4,69.2 283.2,69.2 282.8,68.3 282.2,67.5 282.7,67.3 283.5,67.3 284.5,67.2 285.3,66.6 285.8,67 286.7,67.2 286.6,67.8 287,68.2Z",
+	"VU": "M347.8,106.5 347.5,106.6 347.2,106.2 347.2,105.9ZM347.1,104.9 347.3,105.7 347,105.6 346.8,105.7 346.6,105.4 346.6,104.6Z",
+	"XK": "M200.8,47.9 200.7,48.2 200.6,48.1 200.5,47.8 200.3,47.7 200.1,47.4 200.3,47.2 200.5,47.1 200.6,46.8 200.8,46.7 201,46.9 201.1,46.9 201.3,47.1 201.4,47.1 201.6,47.3 201.8,47.3 201.7,47.6 201.5,47.7 201.6,47.8 201.4,47.8Z",
+	"YE": "M233.1,73.3 232.4,73.6 232.2,74.1 232.2,74.4 231.2,74.8 229.6,75.3 228.7,76 228.2,76.1 227.9,76 227.4,76.4 226.7,76.6 225.9,76.7 225.6,76.7 225.4,77 225.1,77 225,77.3 224.5,77.3 224.2,77.4 223.5,77.4 223.2,76.8 223.3,76.2 223.1,75.9 222.9,75.2 222.6,74.8 222.8,74.7 222.7,74.3 222.8,74.1 222.8,73.7 223.2,73.3 223.1,72.9 223.4,72.4 223.8,72.7 224.1,72.6 225.2,72.6 225.4,72.7 226.4,72.8 226.7,72.7 227,73.1 227.5,72.9 228.2,71.8 229.1,71.4 232,71 232.8,72.7Z",
+	"ZA": "M211.5,119.3 211.3,119.4 210.9,119.9 210.6,120.4 210.1,121.1 208.9,122.2 208.2,122.8 207.5,123.2 206.4,123.6 205.9,123.7 205.8,123.9 205.2,123.8 204.7,124 203.6,123.8 203,123.9 202.6,123.9 201.5,124.3 200.7,124.4 200.1,124.8 199.6,124.8 199.2,124.5 198.9,124.4 198.4,124 198.4,124.1 198.2,123.9 198.3,123.3 197.9,122.6 198.2,122.4 198.2,121.7 197.6,120.7 197.1,119.9 196.3,118.6 196.8,118.1 197.2,118.4 197.4,118.8 197.8,118.9 198.5,119 199,119 199.9,118.5 199.9,114.8 200.2,114.9 200.8,115.9 200.7,116.5 200.9,116.8 201.6,116.7 202.1,116.3 202.6,116 202.8,115.5 203.3,115.3 203.7,115.4 204.2,115.7 205,115.7 205.7,115.5 205.8,115.2 205.9,114.7 206.5,114.6 206.8,114.2 207.1,113.6 208,112.8 209.4,112.1 209.8,112.1 210.3,112.3 210.7,112.2 211.2,112.3 211.7,113.7 211.9,114.4 211.8,115.5 211.8,115.8 211.3,115.7 211,115.7 210.9,116 210.7,116.4 210.7,116.7 211.3,117.3 211.9,117.2 212.1,116.7 212.8,116.7 212.6,117.5 212.5,118.3 212.2,118.8ZM209,119 208.5,118.6 208.1,118.9 207.5,119.2 207,119.9 207.7,120.6 208.1,120.5 208.3,120.2 208.8,120.1 209,119.7 209.3,119.3Z",
+	"ZM": "M212.8,99.2 213.2,99.7 213.5,100.5 213.3,100.8 213.1,101.6 213.3,102.4 213,102.8 212.7,103.7 213.2,104 210.2,104.8 210.3,105.5 209.5,105.6 208.9,106 208.8,106.4 208.5,106.5 207.6,107.3 207,107.9 206.7,108 206.4,107.8 205.3,107.7 205.1,107.7 205.1,107.6 204.7,107.4 204,107.3 203.2,107.5 202.6,106.9 201.9,106.1 201.9,102.9 204,102.9 203.9,102.6 204.1,102.2 203.9,101.7 204,101.2 203.9,100.9 204.3,101 204.3,101.3 204.8,101.2 205.4,101.3 205.8,101.8 206.6,101.9 207.2,101.6 207.4,102.1 208.2,102.3 208.5,102.7 208.9,103.2 209.7,103.3 209.6,102.2 209.3,102.4 208.6,102 208.4,101.8 208.5,100.8 208.7,99.6 208.4,99.2 208.7,98.5 209,98.4 210.3,98.2 210.7,98.3 211.2,98.6 211.6,98.8 212.2,98.9Z",
+	"ZW": "M211.2,112.3 210.7,112.2 210.3,112.3 209.8,112.1 209.4,112.1 208.8,111.6 208,111.5 207.7,110.9 207.7,110.5 207.3,110.4 206.2,109.3 205.9,108.7 205.6,108.5 205.3,107.7 206.4,107.8 206.7,108 207,107.9 207.6,107.3 208.5,106.5 208.8,106.4 208.9,106 209.5,105.6 210.3,105.5 210.3,105.9 211.2,105.9 211.6,106.1 211.9,106.3 212.3,106.4 212.8,106.7 212.8,108 212.7,108.7 212.6,109.4 212.8,109.7 212.7,110.3 212.5,110.4 212.2,111.1Z",
+}